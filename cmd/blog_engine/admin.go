@@ -0,0 +1,415 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/application"
+	"github.com/HardDie/blog_engine/internal/boltdb"
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/logger"
+	repositorySession "github.com/HardDie/blog_engine/internal/repository/session"
+	"github.com/HardDie/blog_engine/internal/server"
+	"github.com/HardDie/blog_engine/internal/service/backup"
+)
+
+func runConfigPrint(cfg *config.Config, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v", args)
+	}
+	return cfg.Print(os.Stdout)
+}
+
+/*
+ * Migrations
+ */
+
+func runMigrateUp(cfg *config.Config, args []string) error {
+	return withApp(cfg, args, 0, func(ctx context.Context, app *application.Application) error {
+		return app.Migrate.Up()
+	})
+}
+func runMigrateDown(cfg *config.Config, args []string) error {
+	return withApp(cfg, args, 0, func(ctx context.Context, app *application.Application) error {
+		return app.Migrate.Down()
+	})
+}
+func runMigrateStatus(cfg *config.Config, args []string) error {
+	return withApp(cfg, args, 0, func(ctx context.Context, app *application.Application) error {
+		status, err := app.Migrate.Status()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("current: %d\nlatest: %d\npending: %d\n", status.Current, status.Latest, status.Pending)
+		return nil
+	})
+}
+
+/*
+ * Users
+ */
+
+func runUserCreate(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("user create", flag.ContinueOnError)
+	username := flags.String("username", "", "login of the user")
+	displayedName := flags.String("displayed-name", "", "name shown to other users, the username by default")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *displayedName == "" {
+		*displayedName = *username
+	}
+
+	return withApp(cfg, flags.Args(), needMigrated, func(ctx context.Context, app *application.Application) error {
+		password, err := readPassword()
+		if err != nil {
+			return err
+		}
+		req := &dto.CreateUserDTO{
+			Username:      *username,
+			Password:      password,
+			DisplayedName: *displayedName,
+		}
+		err = server.GetValidator().Struct(req)
+		if err != nil {
+			return err
+		}
+		user, err := app.Services.Auth.Create(ctx, req)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("User %q created with id %d\n", user.Username, user.ID)
+		return nil
+	})
+}
+func runUserResetPassword(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("user reset-password", flag.ContinueOnError)
+	username := flags.String("username", "", "login of the user")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	return withApp(cfg, flags.Args(), needMigrated|needSessions, func(ctx context.Context, app *application.Application) error {
+		user, err := app.Services.Auth.GetByName(ctx, *username)
+		if err != nil {
+			return err
+		}
+		password, err := readPassword()
+		if err != nil {
+			return err
+		}
+		if password == "" {
+			return errors.New("empty password")
+		}
+		err = app.Services.Auth.ResetPassword(ctx, user.ID, password)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Password of %q is reset\n", user.Username)
+		return nil
+	})
+}
+func runUserBan(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("user ban", flag.ContinueOnError)
+	username := flags.String("username", "", "login of the user")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	return withApp(cfg, flags.Args(), needMigrated|needSessions, func(ctx context.Context, app *application.Application) error {
+		user, err := app.Services.Auth.GetByName(ctx, *username)
+		if err != nil {
+			return err
+		}
+		err = app.Services.Auth.Ban(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("User %q is banned\n", user.Username)
+		return nil
+	})
+}
+
+/*
+ * Invites and sessions
+ */
+
+func runInviteCreate(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("invite create", flag.ContinueOnError)
+	username := flags.String("username", "", "the invite is created on behalf of this user, the previous unused invite is replaced")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	return withApp(cfg, flags.Args(), needMigrated, func(ctx context.Context, app *application.Application) error {
+		user, err := app.Services.Auth.GetByName(ctx, *username)
+		if err != nil {
+			return err
+		}
+		invite, err := app.Services.Invite.Generate(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		fmt.Println(invite)
+		return nil
+	})
+}
+func runSessionPurge(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("session purge", flag.ContinueOnError)
+	username := flags.String("username", "", "delete all sessions of the user instead of expired ones")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	return withApp(cfg, flags.Args(), needMigrated|needSessions, func(ctx context.Context, app *application.Application) error {
+		var userID int64
+		if *username != "" {
+			user, err := app.Services.Auth.GetByName(ctx, *username)
+			if err != nil {
+				return err
+			}
+			userID = user.ID
+		}
+		count, err := app.Services.Auth.PurgeSessions(ctx, userID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Deleted %d sessions\n", count)
+		return nil
+	})
+}
+
+// runSessionMigrate copies sessions with their hashes and creation time, so users stay logged in after SESSION_STORE
+// is switched. The source is kept, the server must be stopped, otherwise sessions created meanwhile are lost.
+func runSessionMigrate(cfg *config.Config, args []string) error {
+	stores := repositorySession.StoreBoltDB + " or " + repositorySession.StoreDB
+	flags := flag.NewFlagSet("session migrate", flag.ContinueOnError)
+	from := flags.String("from", "", "store to read sessions from: "+stores)
+	to := flags.String("to", "", "store to write sessions to: "+stores)
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{*from, *to} {
+		if name != repositorySession.StoreBoltDB && name != repositorySession.StoreDB {
+			return fmt.Errorf("-from and -to must be %s, got %q", stores, name)
+		}
+	}
+	if *from == *to {
+		return errors.New("-from and -to are the same store")
+	}
+
+	return withApp(cfg, flags.Args(), needMigrated, func(ctx context.Context, app *application.Application) error {
+		src, err := app.SessionStore(*from)
+		if err != nil {
+			return err
+		}
+		dst, err := app.SessionStore(*to)
+		if err != nil {
+			return err
+		}
+		count, err := repositorySession.Copy(ctx, src, dst)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Copied %d sessions from %s to %s, set SESSION_STORE=%s to use them\n", count, *from, *to, *to)
+		return nil
+	})
+}
+
+/*
+ * Backup
+ */
+
+// runBackupCreate makes the backup itself, unless the server is running. The server holds the lock of the sessions DB,
+// then the backup is requested from the admin endpoint of the server.
+func runBackupCreate(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("backup create", flag.ContinueOnError)
+	url := flags.String("url", localURL(cfg.Port), "address of the running server")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	err = withApp(cfg, flags.Args(), needSessions, func(ctx context.Context, app *application.Application) error {
+		manifest, err := app.Services.Backup.Create(ctx)
+		if err != nil {
+			return err
+		}
+		return printManifests(manifest)
+	})
+	if !errors.Is(err, boltdb.ErrorLocked) {
+		return err
+	}
+	if cfg.AdminToken == "" {
+		return fmt.Errorf("the server is running, set ADMIN_TOKEN to request the backup from it: %w", err)
+	}
+	manifest, err := requestBackup(*url, cfg.AdminToken)
+	if err != nil {
+		return err
+	}
+	return printManifests(manifest)
+}
+func runBackupList(cfg *config.Config, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v", args)
+	}
+	list, err := backup.List(cfg.BackupDir)
+	if err != nil {
+		return err
+	}
+	return printManifests(list...)
+}
+func runBackupVerify(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("backup verify", flag.ContinueOnError)
+	from := flags.String("from", "", "directory of the backup")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *from == "" || flags.NArg() > 0 {
+		return errors.New("-from is required")
+	}
+
+	manifest, err := backup.Verify(context.Background(), *from)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Backup %q is valid\n", manifest.Name)
+	return nil
+}
+func runBackupRestore(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("backup restore", flag.ContinueOnError)
+	from := flags.String("from", "", "directory of the backup")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *from == "" || flags.NArg() > 0 {
+		return errors.New("-from is required")
+	}
+	err = logger.Init(cfg.LogLevel, cfg.LogFormat, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := backup.Restore(context.Background(), cfg, *from)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Backup %q is restored, the replaced files are kept with the .pre-restore suffix, remove them before the next restore\n", manifest.Name)
+	return nil
+}
+
+/*
+ * Helpers
+ */
+
+const (
+	// needMigrated commands change data, queries may not match the schema of a DB with pending migrations
+	needMigrated = 1 << iota
+	// needSessions commands use the session store, BoltDB of SESSION_STORE=boltdb is locked while the server is running
+	needSessions
+)
+
+// withApp opens the databases for the command. Logs are written to stderr, so the output of the command stays clean.
+func withApp(cfg *config.Config, args []string, needs int, fn func(ctx context.Context, app *application.Application) error) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v", args)
+	}
+	err := logger.Init(cfg.LogLevel, cfg.LogFormat, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	app, err := application.Open(cfg, needs&needSessions != 0)
+	if err != nil {
+		return err
+	}
+	if needs&needMigrated != 0 {
+		status, err := app.Migrate.Status()
+		if err != nil {
+			return errors.Join(err, app.Close())
+		}
+		if status.Pending > 0 {
+			return errors.Join(fmt.Errorf("the DB has %d pending migrations, run migrate up", status.Pending), app.Close())
+		}
+	}
+
+	err = fn(context.Background(), app)
+	return errors.Join(err, app.Close())
+}
+
+// localURL the server listens on all interfaces if the host of PORT is empty.
+func localURL(port string) string {
+	if strings.HasPrefix(port, ":") {
+		return "http://127.0.0.1" + port
+	}
+	return "http://" + port
+}
+func requestBackup(url, token string) (*backup.Manifest, error) {
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(url, "/")+"/api/v1/admin/backups", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request the backup: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read the response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request the backup: %s: %s", resp.Status, body)
+	}
+	var res struct {
+		Data *backup.Manifest `json:"data"`
+	}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, fmt.Errorf("parse the response: %w", err)
+	}
+	return res.Data, nil
+}
+func printManifests(list ...*backup.Manifest) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCREATED\tMIGRATION\tSIZE")
+	for _, manifest := range list {
+		var size int64
+		for _, file := range manifest.Files {
+			size += file.Size
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", manifest.Name, manifest.CreatedAt.Format(time.RFC3339), manifest.Migration, size)
+	}
+	return w.Flush()
+}
+
+// readPassword reads the first line of stdin, so the password doesn't get to the shell history and the process list.
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
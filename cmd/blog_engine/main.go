@@ -28,18 +28,87 @@
 package main
 
 import (
-	"github.com/HardDie/blog_engine/internal/application"
-	"github.com/HardDie/blog_engine/internal/logger"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/HardDie/blog_engine/internal/config"
 )
 
+type command struct {
+	name  string
+	usage string
+	run   func(cfg *config.Config, args []string) error
+}
+
+// commands the first one is the default
+var commands = []command{
+	{"serve", "run the server", runServe},
+	{"config print", "print the effective config, secrets are redacted", runConfigPrint},
+	{"migrate up", "apply pending migrations", runMigrateUp},
+	{"migrate down", "roll back the last migration", runMigrateDown},
+	{"migrate status", "print the version of the DB and the number of pending migrations", runMigrateStatus},
+	{"user create", "create a user without an invite, the password is read from stdin", runUserCreate},
+	{"user reset-password", "set a password read from stdin, unlock the user and close the sessions", runUserResetPassword},
+	{"user ban", "forbid the login of the user and close the sessions", runUserBan},
+	{"invite create", "generate an invite on behalf of the user", runInviteCreate},
+	{"session purge", "delete expired sessions, or all sessions of the user", runSessionPurge},
+	{"session migrate", "copy sessions to another store before switching SESSION_STORE", runSessionMigrate},
+	{"backup create", "back up both databases to BACKUP_DIR, through the admin endpoint if the server is running", runBackupCreate},
+	{"backup list", "print backups of BACKUP_DIR", runBackupList},
+	{"backup verify", "check the checksums and the consistency of the backup", runBackupVerify},
+	{"backup restore", "verify the backup and replace the databases, the server must be stopped", runBackupRestore},
+}
+
 func main() {
-	app, err := application.Get()
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		usage()
+		return
+	}
 	if err != nil {
-		logger.Error.Fatal(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if len(args) == 0 {
+		args = []string{commands[0].name}
+	}
+	cmd, rest := findCommand(args)
+	if cmd == nil {
+		usage()
+		os.Exit(2)
+	}
+	err = cmd.run(cfg, rest)
+	if errors.Is(err, flag.ErrHelp) {
+		return
 	}
-	logger.Info.Println("Server listen on", app.Cfg.Port)
-	err = app.Run()
 	if err != nil {
-		logger.Error.Fatal(err)
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd.name, err)
+		os.Exit(1)
+	}
+}
+
+// findCommand matches the words of the command name, the rest are arguments of the command.
+func findCommand(args []string) (*command, []string) {
+	for i, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) < len(words) || strings.Join(args[:len(words)], " ") != cmd.name {
+			continue
+		}
+		return &commands[i], args[len(words):]
+	}
+	return nil, nil
+}
+func usage() {
+	fmt.Fprint(os.Stderr, "Usage: blog_engine [flags] [command] [command flags]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-22s %s\n", cmd.name, cmd.usage)
 	}
+	fmt.Fprint(os.Stderr, "\nServe is the default. Every setting can be set by a flag, the config file or the environment,\n"+
+		"run with -h to list the flags. Run a command with -h to list its flags.\n"+
+		"user reset-password, user ban, session purge and backup create open the sessions DB of SESSION_STORE=boltdb,\n"+
+		"which is locked while the server is running. Other commands work next to the running server.\n")
 }
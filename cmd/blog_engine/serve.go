@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/application"
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/db"
+	"github.com/HardDie/blog_engine/internal/logger"
+)
+
+func runServe(cfg *config.Config, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v", args)
+	}
+
+	// The restore takes the same lock, so it can't replace the DB under the running server
+	if cfg.DBDriver == db.DriverSQLite && cfg.DBPath != db.MemoryPath {
+		lock, err := db.Lock(cfg.DBPath)
+		if err != nil {
+			return fmt.Errorf("lock the DB, is another server running? %w", err)
+		}
+		defer lock.Unlock()
+	}
+
+	app, err := application.Get(cfg)
+	if err != nil {
+		return fmt.Errorf("init application: %w", err)
+	}
+	go func() {
+		slog.Info("Server listen on", "port", app.Cfg.Port)
+		err := app.Run()
+		if err != nil {
+			logger.Fatal("server", "error", err)
+		}
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range signals {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		err = app.Reload()
+		if err != nil {
+			slog.Error("Config reload failed, the current config is kept", "error", err)
+			continue
+		}
+		slog.Info("Config reloaded")
+	}
+
+	slog.Info("Shutting down, waiting for in-flight requests and background jobs")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(app.Cfg.ShutdownTimeout)*time.Second)
+	defer cancel()
+	err = app.Shutdown(ctx)
+	if err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	return nil
+}
@@ -1,119 +1,473 @@
 package application
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
 	"time"
 
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/gorilla/mux"
 
 	"github.com/HardDie/blog_engine/internal/boltdb"
+	"github.com/HardDie/blog_engine/internal/broker"
 	"github.com/HardDie/blog_engine/internal/config"
 	"github.com/HardDie/blog_engine/internal/db"
+	"github.com/HardDie/blog_engine/internal/job"
+	"github.com/HardDie/blog_engine/internal/logger"
+	"github.com/HardDie/blog_engine/internal/mailer"
+	"github.com/HardDie/blog_engine/internal/metrics"
 	"github.com/HardDie/blog_engine/internal/middleware"
 	"github.com/HardDie/blog_engine/internal/migration"
-	repositorySession "github.com/HardDie/blog_engine/internal/repository/boltdb/session"
-	repositoryInvite "github.com/HardDie/blog_engine/internal/repository/sqlite/invite"
-	repositoryPassword "github.com/HardDie/blog_engine/internal/repository/sqlite/password"
-	repositoryPost "github.com/HardDie/blog_engine/internal/repository/sqlite/post"
-	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+	"github.com/HardDie/blog_engine/internal/ratelimit"
+	boltdbSession "github.com/HardDie/blog_engine/internal/repository/boltdb/session"
+	memorySession "github.com/HardDie/blog_engine/internal/repository/memory/session"
+	repositorySession "github.com/HardDie/blog_engine/internal/repository/session"
+	sqliteSession "github.com/HardDie/blog_engine/internal/repository/sqlite/session"
 	"github.com/HardDie/blog_engine/internal/server"
 	serviceAuth "github.com/HardDie/blog_engine/internal/service/auth"
+	serviceBackup "github.com/HardDie/blog_engine/internal/service/backup"
 	serviceInvite "github.com/HardDie/blog_engine/internal/service/invite"
+	serviceNotification "github.com/HardDie/blog_engine/internal/service/notification"
 	servicePost "github.com/HardDie/blog_engine/internal/service/post"
+	serviceReaction "github.com/HardDie/blog_engine/internal/service/reaction"
+	serviceReadingList "github.com/HardDie/blog_engine/internal/service/readinglist"
+	serviceSeries "github.com/HardDie/blog_engine/internal/service/series"
 	serviceUser "github.com/HardDie/blog_engine/internal/service/user"
+	serviceWebhook "github.com/HardDie/blog_engine/internal/service/webhook"
+	"github.com/HardDie/blog_engine/internal/tracing"
+	"github.com/HardDie/blog_engine/internal/transaction"
+)
+
+// Route groups with their own rate limits
+const (
+	rateLimitGroupAuth    = "auth"
+	rateLimitGroupInvites = "invites"
+	rateLimitGroupPosts   = "posts"
 )
 
 type Application struct {
-	Cfg    *config.Config
-	DB     *db.DB
-	Router *mux.Router
+	Cfg *config.Config
+	DB  *db.DB
+	// BoltDB is opened for SESSION_STORE=boltdb or by SessionStore, otherwise it is nil
+	BoltDB   *boltdb.DB
+	Migrate  *migration.Migrate
+	Sessions repositorySession.Store
+	Services *Services
+	Router   *mux.Router
+	Server   *http.Server
+
+	repos       *repositories
+	broker      *broker.Broker
+	cors        *middleware.Cors
+	limiter     *ratelimit.Limiter
+	queue       *job.Queue
+	stopTracing func(ctx context.Context) error
 }
 
-func Get() (*Application, error) {
+// Services are shared by the HTTP servers and the CLI, so both follow the same business rules.
+type Services struct {
+	Auth         *serviceAuth.Auth
+	Backup       *serviceBackup.Backup
+	Invite       *serviceInvite.Invite
+	Notification *serviceNotification.Notification
+	Post         *servicePost.Post
+	Reaction     *serviceReaction.Reaction
+	ReadingList  *serviceReadingList.ReadingList
+	Series       *serviceSeries.Series
+	User         *serviceUser.User
+	Webhook      *serviceWebhook.Webhook
+}
+
+// Open connects the DB and builds the services, nothing is started and migrations are not applied.
+// The CLI uses it directly, the server is built on top of it by Get. The session store is opened only
+// with sessions, because the server holds the lock of BoltDB. Without it Sessions is nil and methods
+// of Auth, which create or delete sessions, must not be called.
+func Open(cfg *config.Config, sessions bool) (*Application, error) {
+	app, err := open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = app.initServices(context.Background(), sessions)
+	if err != nil {
+		return nil, errors.Join(err, app.Close())
+	}
+	return app, nil
+}
+func open(cfg *config.Config) (*Application, error) {
 	app := &Application{
-		Cfg:    config.Get(),
-		Router: mux.NewRouter(),
+		Cfg: cfg,
 	}
-	app.Router.Use(
-		middleware.CorsMiddleware,
-		chiMiddleware.RequestID,
-		chiMiddleware.RealIP,
-		chiMiddleware.Logger,
-		chiMiddleware.Recoverer,
-	)
-	app.Router.MethodNotAllowedHandler = http.HandlerFunc(notAllowed)
 
 	// Init DB
-	newDB, err := db.Get(app.Cfg.DBPath)
+	var newDB *db.DB
+	var err error
+	switch app.Cfg.DBDriver {
+	case db.DriverPostgres:
+		newDB, err = db.GetPostgres(app.Cfg.DBURL, app.Cfg.DBMaxConns)
+	default:
+		newDB, err = db.Get(app.Cfg.DBPath, app.Cfg.DBReadConns, app.Cfg.DBBusyTimeout)
+	}
 	if err != nil {
 		return nil, err
 	}
 	app.DB = newDB
-	boltDB, err := boltdb.Get(app.Cfg.SessionsDBPath)
+	app.Migrate = migration.NewMigrate(app.DB)
+	return app, nil
+}
+
+// initServices builds repositories and services. Statements are prepared only for the current schema,
+// commands like "migrate up" open the DB before the migration and get plain repositories.
+func (app *Application) initServices(ctx context.Context, sessions bool) error {
+	status, err := app.Migrate.Status()
+	if err != nil {
+		return err
+	}
+	repos, err := newRepositories(&preparer{
+		ctx:     ctx,
+		enabled: status.Pending == 0,
+	}, app.DB)
+	if err != nil {
+		return err
+	}
+	app.repos = repos
+	if sessions {
+		app.Sessions, err = app.SessionStore(app.Cfg.SessionStore)
+		if err != nil {
+			return err
+		}
+	}
+	transactionManager := transaction.New(app.DB, repos.transaction)
+
+	// Init job queue, handlers must be registered before the start
+	app.queue = job.New(app.Cfg, repos.job)
+	mailerInstance := mailer.New(app.Cfg)
+	job.Register(app.queue, mailer.JobSend, func(ctx context.Context, m *mailer.Message) error {
+		return mailerInstance.Send(ctx, m.To, m.Subject, m.Body)
+	})
+
+	// Init services
+	app.broker = broker.New(app.Cfg.SSEHistory)
+	webhookService := serviceWebhook.New(app.Cfg, repos.webhook, app.queue)
+	job.Register(app.queue, serviceWebhook.JobDeliver, func(ctx context.Context, j *serviceWebhook.DeliverJob) error {
+		return webhookService.Deliver(ctx, j.DeliveryID)
+	})
+	notificationService := serviceNotification.New(repos.notification, repos.user, app.queue, app.broker)
+	app.Services = &Services{
+		Auth:         serviceAuth.New(app.Cfg, repos.user, repos.password, app.Sessions, repos.invite, transactionManager, notificationService, webhookService),
+		Backup:       serviceBackup.New(app.Cfg, app.DB, app.BoltDB, app.Migrate),
+		Invite:       serviceInvite.New(repos.invite),
+		Notification: notificationService,
+		Post:         servicePost.New(repos.post, repos.user, repos.series, repos.reaction, repos.readingList, webhookService, app.broker),
+		Reaction:     serviceReaction.New(app.Cfg, repos.reaction, repos.post, notificationService),
+		ReadingList:  serviceReadingList.New(repos.readingList, repos.post),
+		Series:       serviceSeries.New(repos.series, repos.post, repos.user, transactionManager),
+		User:         serviceUser.New(repos.user, repos.password, repos.follow, notificationService),
+		Webhook:      webhookService,
+	}
+
+	authService := app.Services.Auth
+	app.queue.RegisterRaw(serviceAuth.JobPurgeLoginFailures, func(ctx context.Context, _ []byte) error {
+		_, err := authService.PurgeLoginFailures(ctx)
+		return err
+	})
+	app.queue.Every(serviceAuth.JobPurgeLoginFailures, time.Hour)
+
+	backupService := app.Services.Backup
+	app.queue.RegisterRaw(serviceBackup.JobCreate, func(ctx context.Context, _ []byte) error {
+		_, err := backupService.Create(ctx)
+		return err
+	})
+	// PostgreSQL is backed up by its own tools
+	if app.Cfg.BackupInterval > 0 && app.DB.Driver == db.DriverSQLite {
+		app.queue.Every(serviceBackup.JobCreate, time.Duration(app.Cfg.BackupInterval)*time.Hour)
+	}
+	return nil
+}
+
+// SessionStore returns the store by its name of SESSION_STORE. BoltDB is opened if the application doesn't use it yet,
+// so the CLI can reach both stores to move sessions between them.
+func (app *Application) SessionStore(name string) (repositorySession.Store, error) {
+	switch name {
+	case repositorySession.StoreBoltDB:
+		if app.BoltDB == nil {
+			boltDB, err := boltdb.Get(app.Cfg.SessionsDBPath)
+			if err != nil {
+				return nil, err
+			}
+			app.BoltDB = boltDB
+		}
+		return boltdbSession.New(app.BoltDB), nil
+	case repositorySession.StoreDB:
+		return sqliteSession.NewStore(app.repos.session), nil
+	case repositorySession.StoreMemory:
+		return memorySession.New(), nil
+	}
+	return nil, fmt.Errorf("unknown session store %q", name)
+}
+
+// Get builds the server: applies migrations, registers routes and starts background workers.
+// If a step fails, the databases and tracing are closed, workers are started only after all other steps.
+func Get(cfg *config.Config) (_ *Application, err error) {
+	err = logger.Init(cfg.LogLevel, cfg.LogFormat, os.Stdout)
 	if err != nil {
 		return nil, err
 	}
 
+	// Init tracing
+	stopTracing, err := tracing.Init(context.Background(), cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, stopTracing(context.Background()))
+		}
+	}()
+
+	app, err := open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	app.stopTracing = stopTracing
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, app.Close())
+		}
+	}()
+
 	// Init migrations
-	err = migration.NewMigrate(app.DB).Up()
+	err = app.Migrate.Up()
 	if err != nil {
 		return nil, err
 	}
+	err = app.initServices(context.Background(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	app.Router = mux.NewRouter()
+	app.cors = middleware.NewCors(app.Cfg)
+
+	app.Router.Use(
+		metrics.Middleware,
+		tracing.Middleware,
+		middleware.AccessLogMiddleware,
+		middleware.RecovererMiddleware,
+		middleware.NewCSRF(app.cors, app.Cfg.CSRFEnabled).Middleware,
+	)
+	app.Router.NotFoundHandler = http.HandlerFunc(middleware.NotFoundHandler)
+	app.Router.MethodNotAllowedHandler = http.HandlerFunc(middleware.MethodNotAllowedHandler)
 
 	// Prepare router
 	apiRouter := app.Router.PathPrefix("/api").Subrouter()
 	v1Router := apiRouter.PathPrefix("/v1").Subrouter()
 
-	// Init repositories
-	userRepository := repositoryUser.New(app.DB)
-	passwordRepository := repositoryPassword.New(app.DB)
-	sessionRepository := repositorySession.New(boltDB)
-	inviteRepository := repositoryInvite.New(app.DB)
-	postRepository := repositoryPost.New(app.DB)
-
-	// Init services
-	authService := serviceAuth.New(app.Cfg, userRepository, passwordRepository, sessionRepository, inviteRepository)
-	inviteService := serviceInvite.New(inviteRepository)
-	postService := servicePost.New(postRepository, userRepository)
-	userService := serviceUser.New(userRepository, passwordRepository)
+	services := app.Services
+	metrics.SetActiveSessions(services.Auth.CountActiveSessions)
+	err = services.Auth.InitSetup(context.Background())
+	if err != nil {
+		return nil, err
+	}
 
 	// Middleware
-	authMiddleware := middleware.NewAuthMiddleware(authService)
-	timeoutMiddleware := chiMiddleware.Timeout(time.Duration(app.Cfg.RequestTimeout) * time.Second)
+	authMiddleware := middleware.NewAuthMiddleware(services.Auth)
+	timeoutMiddleware := middleware.TimeoutMiddleware(time.Duration(app.Cfg.RequestTimeout) * time.Second)
+	limiter, err := newLimiter(app.Cfg)
+	if err != nil {
+		return nil, err
+	}
+	app.limiter = limiter
 
 	// Register servers
+	healthServer := server.NewHealth(app.DB, app.BoltDB, app.Migrate)
+	healthServer.RegisterPublicRouter(app.Router)
+	if app.Cfg.MetricsPublic {
+		healthServer.RegisterMetricsRouter(app.Router)
+	} else {
+		healthServer.RegisterMetricsRouter(app.Router, middleware.AdminMiddleware(app.Cfg))
+	}
+
 	authRouter := v1Router.PathPrefix("/auth").Subrouter()
-	authServer := server.NewAuth(app.Cfg, authService)
-	authServer.RegisterPublicRouter(authRouter)
-	authServer.RegisterPrivateRouter(authRouter, timeoutMiddleware, authMiddleware.RequestMiddleware)
+	authServer := server.NewAuth(app.Cfg, services.Auth, limiter)
+	authServer.RegisterPublicRouter(authRouter, limiter.Middleware)
+	authServer.RegisterPrivateRouter(authRouter, timeoutMiddleware, authMiddleware.RequestMiddleware, limiter.Middleware)
 
 	inviteRouter := v1Router.PathPrefix("/invites").Subrouter()
-	inviteServer := server.NewInvite(inviteService)
-	inviteServer.RegisterPrivateRouter(inviteRouter, timeoutMiddleware, authMiddleware.RequestMiddleware)
+	inviteServer := server.NewInvite(services.Invite)
+	inviteServer.RegisterPrivateRouter(inviteRouter, timeoutMiddleware, authMiddleware.RequestMiddleware, limiter.Middleware)
 
 	postsRouter := v1Router.PathPrefix("/posts").Subrouter()
-	postServer := server.NewPost(postService)
-	postServer.RegisterPublicRouter(postsRouter, timeoutMiddleware)
-	postServer.RegisterPrivateRouter(postsRouter, timeoutMiddleware, authMiddleware.RequestMiddleware)
+	postServer := server.NewPost(services.Post)
+	postServer.RegisterPublicRouter(postsRouter, timeoutMiddleware, authMiddleware.OptionalRequestMiddleware, limiter.Middleware)
+	postServer.RegisterPrivateRouter(postsRouter, timeoutMiddleware, authMiddleware.RequestMiddleware, limiter.Middleware)
+
+	reactionServer := server.NewReaction(services.Reaction)
+	reactionServer.RegisterPublicRouter(postsRouter, timeoutMiddleware, limiter.Middleware)
+	reactionServer.RegisterPrivateRouter(postsRouter, timeoutMiddleware, authMiddleware.RequestMiddleware, limiter.Middleware)
+
+	seriesRouter := v1Router.PathPrefix("/series").Subrouter()
+	seriesServer := server.NewSeries(services.Series)
+	seriesServer.RegisterPublicRouter(seriesRouter, timeoutMiddleware, limiter.Middleware)
+	seriesServer.RegisterPrivateRouter(seriesRouter, timeoutMiddleware, authMiddleware.RequestMiddleware, limiter.Middleware)
+
+	listRouter := v1Router.PathPrefix("/lists").Subrouter()
+	listServer := server.NewReadingList(services.ReadingList)
+	listServer.RegisterPublicRouter(listRouter, timeoutMiddleware, authMiddleware.OptionalRequestMiddleware, limiter.Middleware)
+	listServer.RegisterPrivateRouter(listRouter, timeoutMiddleware, authMiddleware.RequestMiddleware, limiter.Middleware)
+
+	notificationRouter := v1Router.PathPrefix("/notifications").Subrouter()
+	notificationServer := server.NewNotification(services.Notification)
+	notificationServer.RegisterPrivateRouter(notificationRouter, timeoutMiddleware, authMiddleware.RequestMiddleware, limiter.Middleware)
+
+	webhookRouter := v1Router.PathPrefix("/webhooks").Subrouter()
+	webhookServer := server.NewWebhook(services.Webhook)
+	webhookServer.RegisterPrivateRouter(webhookRouter, timeoutMiddleware, authMiddleware.RequestMiddleware, limiter.Middleware)
+
+	eventRouter := v1Router.PathPrefix("/events").Subrouter()
+	eventServer := server.NewEvent(app.Cfg, app.broker)
+	eventServer.RegisterPublicRouter(eventRouter, authMiddleware.OptionalRequestMiddleware, limiter.Middleware)
 
 	userRouter := v1Router.PathPrefix("/user").Subrouter()
-	userServer := server.NewUser(userService)
-	userServer.RegisterPublicRouter(userRouter, timeoutMiddleware)
-	userServer.RegisterPrivateRouter(userRouter, timeoutMiddleware, authMiddleware.RequestMiddleware)
+	userServer := server.NewUser(services.User)
+	userServer.RegisterPublicRouter(userRouter, timeoutMiddleware, authMiddleware.OptionalRequestMiddleware, limiter.Middleware)
+	userServer.RegisterPrivateRouter(userRouter, timeoutMiddleware, authMiddleware.RequestMiddleware, limiter.Middleware)
+
+	adminRouter := v1Router.PathPrefix("/admin").Subrouter()
+	backupServer := server.NewBackup(services.Backup)
+	backupServer.RegisterAdminRouter(adminRouter.PathPrefix("/backups").Subrouter(), middleware.AdminMiddleware(app.Cfg), limiter.Middleware)
+
+	// These middlewares run before routing, so they apply to preflight requests and unknown routes too
+	var handler http.Handler = app.Router
+	handler = middleware.SecurityHeadersMiddleware(app.Cfg)(handler)
+	handler = app.cors.Handler(handler)
+	handler = middleware.RealIP(app.Cfg)(handler)
+	handler = chiMiddleware.RequestID(handler)
+	app.Server = &http.Server{
+		Addr:           app.Cfg.Port,
+		Handler:        handler,
+		ReadTimeout:    time.Duration(app.Cfg.HTTPReadTimeout) * time.Second,
+		WriteTimeout:   time.Duration(app.Cfg.HTTPWriteTimeout) * time.Second,
+		IdleTimeout:    time.Duration(app.Cfg.HTTPIdleTimeout) * time.Second,
+		MaxHeaderBytes: app.Cfg.HTTPMaxHeaderBytes,
+	}
+	app.Server.RegisterOnShutdown(eventServer.Close)
+
+	// Background workers
+	err = app.queue.Start(context.Background())
+	if err != nil {
+		return nil, err
+	}
 
 	return app, nil
 }
 
+// Run blocks until the server is stopped by Shutdown.
 func (app *Application) Run() error {
-	return http.ListenAndServe(app.Cfg.Port, app.Router)
+	err := app.Server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Reload re-reads the config and applies the settings marked as reloadable: the log level, CORS and rate limits.
+// Other changed settings are reported and keep the current value until a restart.
+func (app *Application) Reload() error {
+	newCfg, err := app.Cfg.Reload()
+	if err != nil {
+		return fmt.Errorf("reload config: %w", err)
+	}
+	cfg, restart := app.Cfg.Merge(newCfg)
+	if len(restart) > 0 {
+		slog.Warn("Changed settings require a restart", "keys", restart)
+	}
+
+	err = logger.SetLevel(cfg.LogLevel)
+	if err != nil {
+		return err
+	}
+	app.cors.Update(cfg)
+	err = setLimits(app.limiter, cfg)
+	if err != nil {
+		return err
+	}
+	app.Cfg = cfg
+	return nil
+}
+
+// Shutdown waits for in-flight requests and running jobs until the context expires, then closes databases.
+func (app *Application) Shutdown(ctx context.Context) error {
+	var errs []error
+
+	err := app.Server.Shutdown(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("server shutdown: %w", err))
+	}
+
+	err = app.queue.Stop(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	err = app.Close()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	err = app.stopTracing(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("stop tracing: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the databases, the server must be stopped first by Shutdown.
+func (app *Application) Close() error {
+	var errs []error
+	err := app.DB.Close()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("close db: %w", err))
+	}
+	if app.BoltDB != nil {
+		err = app.BoltDB.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("close boltdb: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newLimiter assigns the expensive or abusable routes to their own groups, the rest shares the default limit.
+func newLimiter(cfg *config.Config) (*ratelimit.Limiter, error) {
+	limiter := ratelimit.New(ratelimit.NewMemory())
+	err := setLimits(limiter, cfg)
+	if err != nil {
+		return nil, err
+	}
+	limiter.Route(http.MethodPost, "/api/v1/auth/login", rateLimitGroupAuth)
+	limiter.Route(http.MethodPost, "/api/v1/auth/register", rateLimitGroupAuth)
+	limiter.Route(http.MethodPost, "/api/v1/auth/setup", rateLimitGroupAuth)
+	limiter.Route(http.MethodGet, "/api/v1/invites/generate", rateLimitGroupInvites)
+	limiter.Route(http.MethodPost, "/api/v1/posts", rateLimitGroupPosts)
+	return limiter, nil
 }
+func setLimits(limiter *ratelimit.Limiter, cfg *config.Config) error {
+	for group, value := range map[string]string{
+		ratelimit.GroupDefault: cfg.RateLimitDefault,
+		rateLimitGroupAuth:     cfg.RateLimitAuth,
+		rateLimitGroupInvites:  cfg.RateLimitInvites,
+		rateLimitGroupPosts:    cfg.RateLimitPosts,
 
-func notAllowed(w http.ResponseWriter, r *http.Request) {
-	if (*r).Method == http.MethodOptions {
-		middleware.SetupCors(w, r)
-		return
+		ratelimit.GroupLoginFailures: cfg.RateLimitLoginFailures,
+	} {
+		limit, err := ratelimit.ParseLimit(value)
+		if err != nil {
+			return fmt.Errorf("setLimits() group %s: %w", group, err)
+		}
+		limiter.Group(group, limit)
 	}
-	w.WriteHeader(http.StatusMethodNotAllowed)
-	return
+	return nil
 }
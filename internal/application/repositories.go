@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/HardDie/blog_engine/internal/db"
+	postgresFollow "github.com/HardDie/blog_engine/internal/repository/postgres/follow"
+	postgresInvite "github.com/HardDie/blog_engine/internal/repository/postgres/invite"
+	postgresJob "github.com/HardDie/blog_engine/internal/repository/postgres/job"
+	postgresNotification "github.com/HardDie/blog_engine/internal/repository/postgres/notification"
+	postgresPassword "github.com/HardDie/blog_engine/internal/repository/postgres/password"
+	postgresPost "github.com/HardDie/blog_engine/internal/repository/postgres/post"
+	postgresReaction "github.com/HardDie/blog_engine/internal/repository/postgres/reaction"
+	postgresReadingList "github.com/HardDie/blog_engine/internal/repository/postgres/readinglist"
+	postgresSeries "github.com/HardDie/blog_engine/internal/repository/postgres/series"
+	postgresSession "github.com/HardDie/blog_engine/internal/repository/postgres/session"
+	postgresUser "github.com/HardDie/blog_engine/internal/repository/postgres/user"
+	postgresWebhook "github.com/HardDie/blog_engine/internal/repository/postgres/webhook"
+	repositoryFollow "github.com/HardDie/blog_engine/internal/repository/sqlite/follow"
+	repositoryInvite "github.com/HardDie/blog_engine/internal/repository/sqlite/invite"
+	repositoryJob "github.com/HardDie/blog_engine/internal/repository/sqlite/job"
+	repositoryNotification "github.com/HardDie/blog_engine/internal/repository/sqlite/notification"
+	repositoryPassword "github.com/HardDie/blog_engine/internal/repository/sqlite/password"
+	repositoryPost "github.com/HardDie/blog_engine/internal/repository/sqlite/post"
+	repositoryReaction "github.com/HardDie/blog_engine/internal/repository/sqlite/reaction"
+	repositoryReadingList "github.com/HardDie/blog_engine/internal/repository/sqlite/readinglist"
+	repositorySeries "github.com/HardDie/blog_engine/internal/repository/sqlite/series"
+	repositorySession "github.com/HardDie/blog_engine/internal/repository/sqlite/session"
+	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+	repositoryWebhook "github.com/HardDie/blog_engine/internal/repository/sqlite/webhook"
+	"github.com/HardDie/blog_engine/internal/transaction"
+)
+
+// repositories of the driver, services only depend on the Querier interfaces.
+type repositories struct {
+	user         repositoryUser.Querier
+	password     repositoryPassword.Querier
+	invite       repositoryInvite.Querier
+	post         repositoryPost.Querier
+	series       repositorySeries.Querier
+	reaction     repositoryReaction.Querier
+	readingList  repositoryReadingList.Querier
+	follow       repositoryFollow.Querier
+	notification repositoryNotification.Querier
+	webhook      repositoryWebhook.Querier
+	job          repositoryJob.Querier
+	session      repositorySession.Querier
+	transaction  transaction.Bind
+}
+
+func newRepositories(p *preparer, database *db.DB) (*repositories, error) {
+	var repos *repositories
+	switch database.Driver {
+	case db.DriverSQLite:
+		repos = sqliteRepositories(p, database)
+	case db.DriverPostgres:
+		repos = postgresRepositories(p, database)
+	default:
+		return nil, fmt.Errorf("unknown DB driver %q", database.Driver)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return repos, nil
+}
+
+func sqliteRepositories(p *preparer, database *db.DB) *repositories {
+	p.conn = database
+	repos := &repositories{
+		user:         prepareRepository(p, repositoryUser.New, repositoryUser.Prepare),
+		password:     prepareRepository(p, repositoryPassword.New, repositoryPassword.Prepare),
+		invite:       prepareRepository(p, repositoryInvite.New, repositoryInvite.Prepare),
+		post:         prepareRepository(p, repositoryPost.New, repositoryPost.Prepare),
+		series:       prepareRepository(p, repositorySeries.New, repositorySeries.Prepare),
+		reaction:     prepareRepository(p, repositoryReaction.New, repositoryReaction.Prepare),
+		readingList:  prepareRepository(p, repositoryReadingList.New, repositoryReadingList.Prepare),
+		follow:       prepareRepository(p, repositoryFollow.New, repositoryFollow.Prepare),
+		notification: prepareRepository(p, repositoryNotification.New, repositoryNotification.Prepare),
+		webhook:      prepareRepository(p, repositoryWebhook.New, repositoryWebhook.Prepare),
+		job:          prepareRepository(p, repositoryJob.New, repositoryJob.Prepare),
+		session:      prepareRepository(p, repositorySession.New, repositorySession.Prepare),
+	}
+
+	// Statements of transactions must belong to the writer, WithTx can't use the ones prepared on the readers
+	p.conn = database.Write
+	invite := prepareRepository(p, repositoryInvite.New, repositoryInvite.Prepare)
+	password := prepareRepository(p, repositoryPassword.New, repositoryPassword.Prepare)
+	series := prepareRepository(p, repositorySeries.New, repositorySeries.Prepare)
+	user := prepareRepository(p, repositoryUser.New, repositoryUser.Prepare)
+	repos.transaction = func(tx *sql.Tx) *transaction.Repositories {
+		return &transaction.Repositories{
+			Invite:   invite.WithTx(tx),
+			Password: password.WithTx(tx),
+			Series:   series.WithTx(tx),
+			User:     user.WithTx(tx),
+		}
+	}
+	return repos
+}
+
+// postgresRepositories share one pool with transactions, so the same statements are used for both.
+func postgresRepositories(p *preparer, database *db.DB) *repositories {
+	p.conn = database
+	invite := postgresInvite.NewAdapter(prepareRepository(p, postgresInvite.New, postgresInvite.Prepare))
+	password := postgresPassword.NewAdapter(prepareRepository(p, postgresPassword.New, postgresPassword.Prepare))
+	series := postgresSeries.NewAdapter(prepareRepository(p, postgresSeries.New, postgresSeries.Prepare))
+	user := postgresUser.NewAdapter(prepareRepository(p, postgresUser.New, postgresUser.Prepare))
+	return &repositories{
+		user:         user,
+		password:     password,
+		invite:       invite,
+		post:         postgresPost.NewAdapter(prepareRepository(p, postgresPost.New, postgresPost.Prepare)),
+		series:       series,
+		reaction:     postgresReaction.NewAdapter(prepareRepository(p, postgresReaction.New, postgresReaction.Prepare)),
+		readingList:  postgresReadingList.NewAdapter(prepareRepository(p, postgresReadingList.New, postgresReadingList.Prepare)),
+		follow:       postgresFollow.NewAdapter(prepareRepository(p, postgresFollow.New, postgresFollow.Prepare)),
+		notification: postgresNotification.NewAdapter(prepareRepository(p, postgresNotification.New, postgresNotification.Prepare)),
+		webhook:      postgresWebhook.NewAdapter(prepareRepository(p, postgresWebhook.New, postgresWebhook.Prepare)),
+		job:          postgresJob.NewAdapter(prepareRepository(p, postgresJob.New, postgresJob.Prepare)),
+		session:      postgresSession.NewAdapter(prepareRepository(p, postgresSession.New, postgresSession.Prepare)),
+		transaction: func(tx *sql.Tx) *transaction.Repositories {
+			return &transaction.Repositories{
+				Invite:   invite.WithTx(tx),
+				Password: password.WithTx(tx),
+				Series:   series.WithTx(tx),
+				User:     user.WithTx(tx),
+			}
+		},
+	}
+}
+
+// preparer keeps the first error of prepareRepository, so repositories are built in a row without checks after each one.
+type preparer struct {
+	ctx context.Context
+	// conn is *db.DB or one of its pools
+	conn    any
+	enabled bool
+	err     error
+}
+
+// prepareRepository builds the sqlc repository by its Prepare function, or by New if preparing is disabled or failed.
+func prepareRepository[D any, Q any](p *preparer, newFn func(D) Q, prepareFn func(context.Context, D) (Q, error)) Q {
+	conn := p.conn.(D)
+	if !p.enabled || p.err != nil {
+		return newFn(conn)
+	}
+	q, err := prepareFn(p.ctx, conn)
+	if err != nil {
+		p.err = fmt.Errorf("prepare statements: %w", err)
+		return newFn(conn)
+	}
+	return q
+}
@@ -0,0 +1,270 @@
+// Package apptest builds the whole application for HTTP-level tests: the SQLite DB in memory, sessions
+// in a temporary BoltDB file, migrations applied, fixtures seeded and the router served by httptest.
+//
+// Cases of one harness share the state, so they run in order and each one sees the changes of the previous ones.
+// The harness must not be used by parallel tests, goose keeps the state of migrations in globals.
+package apptest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/application"
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/db"
+	"github.com/HardDie/blog_engine/internal/problem"
+	repositorySession "github.com/HardDie/blog_engine/internal/repository/session"
+)
+
+const (
+	// Password of every user of the fixtures
+	Password = "password"
+	// AdminToken authorizes the admin endpoints of the harness
+	AdminToken = "admin-token-of-the-tests"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+type Harness struct {
+	App    *application.Application
+	Server *httptest.Server
+	// Fixtures are nil for NewEmpty
+	Fixtures *Fixtures
+}
+
+// New builds the application with the fixtures. Flags override the settings of the harness, e.g. "-reactions=👍".
+func New(t testing.TB, flags ...string) *Harness {
+	t.Helper()
+
+	h := NewEmpty(t, flags...)
+	h.Fixtures = seed(t, h.App)
+	return h
+}
+
+// NewEmpty builds the application without users, so the setup of the first account is enabled.
+func NewEmpty(t testing.TB, flags ...string) *Harness {
+	t.Helper()
+
+	dir := t.TempDir()
+	args := append([]string{
+		"-db-driver=" + db.DriverSQLite,
+		"-db-path=" + db.MemoryPath,
+		"-session-store=" + repositorySession.StoreBoltDB,
+		"-sessions-db-path=" + filepath.Join(dir, "sessions.db"),
+		"-backup-dir=" + filepath.Join(dir, "backups"),
+		"-backup-interval=0",
+		"-admin-token=" + AdminToken,
+		"-trace-exporter=none",
+		"-log-level=error",
+		// Limits are tested by the ratelimit package and TestAuthRateLimit, other cases must not depend on their order
+		"-rate-limit-default=10000/1m",
+		"-rate-limit-auth=10000/1m",
+		"-rate-limit-invites=10000/1m",
+		"-rate-limit-posts=10000/1m",
+		"-rate-limit-login-failures=10000/1m",
+	}, flags...)
+	cfg, rest, err := config.Load(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) > 0 {
+		t.Fatalf("unexpected arguments: %v", rest)
+	}
+
+	app, err := application.Get(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cookies of sessions are secure, so they are sent only over TLS
+	srv := httptest.NewTLSServer(app.Server.Handler)
+	t.Cleanup(func() {
+		srv.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		err := app.Shutdown(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	return &Harness{
+		App:    app,
+		Server: srv,
+	}
+}
+
+// Client returns an anonymous client with its own cookies.
+func (h *Harness) Client(t testing.TB) *Client {
+	t.Helper()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The server returns the same client each time, the copy gets its own cookies
+	httpClient := *h.Server.Client()
+	httpClient.Jar = jar
+	return &Client{
+		HTTP:   &httpClient,
+		base:   h.Server.URL,
+		header: make(http.Header),
+	}
+}
+
+// Login returns a client with the session of the user.
+func (h *Harness) Login(t testing.TB, username string) *Client {
+	t.Helper()
+
+	c := h.Client(t)
+	res := c.Do(t, http.MethodPost, "/api/v1/auth/login", map[string]string{
+		"username": username,
+		"password": Password,
+	})
+	res.Expect(t, http.StatusOK, "")
+	return c
+}
+
+// Admin returns a client authorized by the admin token.
+func (h *Harness) Admin(t testing.TB) *Client {
+	t.Helper()
+
+	c := h.Client(t)
+	c.header.Set("Authorization", "Bearer "+AdminToken)
+	return c
+}
+
+// Client keeps cookies between requests like a browser.
+type Client struct {
+	// HTTP is the underlying client, e.g. for streams, which Do would read forever
+	HTTP   *http.Client
+	base   string
+	header http.Header
+}
+
+// Header is sent with every request of the client, e.g. X-Forwarded-For.
+func (c *Client) Header() http.Header {
+	return c.header
+}
+
+// URL of the path on the test server.
+func (c *Client) URL(path string) string {
+	return c.base + path
+}
+
+// Do sends the request and reads the whole response. The body is sent as is if it is a string, otherwise as JSON.
+func (c *Client) Do(t testing.TB, method, path string, body any) *Response {
+	t.Helper()
+
+	var reader io.Reader
+	switch body := body.(type) {
+	case nil:
+	case string:
+		reader = bytes.NewBufferString(body)
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewBuffer(data)
+	}
+	req, err := http.NewRequest(method, c.URL(path), reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       data,
+	}
+}
+
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Expect checks the status and, for errors, the code of the problem. An empty code isn't checked.
+func (r *Response) Expect(t testing.TB, status int, code string) {
+	t.Helper()
+
+	if r.StatusCode != status {
+		t.Fatalf("expected status %d, got %d: %s", status, r.StatusCode, r.Body)
+	}
+	if code == "" {
+		return
+	}
+	p := &problem.Problem{}
+	err := json.Unmarshal(r.Body, p)
+	if err != nil {
+		t.Fatalf("the response is not a problem: %v: %s", err, r.Body)
+	}
+	if p.Code != code {
+		t.Fatalf("expected code %q, got %q: %s", code, p.Code, r.Body)
+	}
+}
+
+// Data decodes the data field of the response into v.
+func (r *Response) Data(t testing.TB, v any) {
+	t.Helper()
+
+	err := json.Unmarshal(r.Body, &struct {
+		Data any `json:"data"`
+	}{Data: v})
+	if err != nil {
+		t.Fatalf("decode the response: %v: %s", err, r.Body)
+	}
+}
+
+// Case is a request of the table-driven tests with the expected result.
+type Case struct {
+	Name   string
+	Client *Client
+	Method string
+	Path   string
+	Body   any
+	Status int
+	// Code of the problem, checked if not empty
+	Code string
+	// Check runs additional assertions on the response
+	Check func(t *testing.T, res *Response)
+}
+
+// Run sends requests of the cases in order, so a case can depend on the previous ones.
+func Run(t *testing.T, cases []Case) {
+	t.Helper()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			res := tc.Client.Do(t, tc.Method, tc.Path, tc.Body)
+			res.Expect(t, tc.Status, tc.Code)
+			if tc.Check != nil {
+				tc.Check(t, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package apptest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/application"
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+)
+
+// Fixtures are created through the services, so they pass the same rules as the data created by the API.
+type Fixtures struct {
+	Alice *entity.User
+	Bob   *entity.User
+	Carol *entity.User
+
+	// Invite is the unused invite of Alice
+	Invite string
+
+	// AlicePost and BobPost are published, AliceDraft is seen only by Alice
+	AlicePost  *entity.Post
+	AliceDraft *entity.Post
+	BobPost    *entity.Post
+}
+
+func seed(t testing.TB, app *application.Application) *Fixtures {
+	t.Helper()
+
+	ctx := context.Background()
+	services := app.Services
+	f := &Fixtures{}
+
+	for _, el := range []struct {
+		user     **entity.User
+		username string
+		name     string
+	}{
+		{&f.Alice, "alice", "Alice"},
+		{&f.Bob, "bob", "Bob"},
+		{&f.Carol, "carol", "Carol"},
+	} {
+		user, err := services.Auth.Create(ctx, &dto.CreateUserDTO{
+			Username:      el.username,
+			Password:      Password,
+			DisplayedName: el.name,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		*el.user = user
+	}
+
+	invite, err := services.Invite.Generate(ctx, f.Alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Invite = invite
+
+	for _, el := range []struct {
+		post        **entity.Post
+		userID      int64
+		title       string
+		isPublished bool
+	}{
+		{&f.AlicePost, f.Alice.ID, "Alice writes about Go", true},
+		{&f.AliceDraft, f.Alice.ID, "Unfinished draft", false},
+		{&f.BobPost, f.Bob.ID, "Bob writes about SQL", true},
+	} {
+		post, err := services.Post.Create(ctx, &dto.CreatePostDTO{
+			Title:       el.title,
+			Short:       "Short of " + el.title,
+			Body:        "Body of " + el.title,
+			Tags:        []string{"test"},
+			IsPublished: el.isPublished,
+		}, el.userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		*el.post = post
+	}
+	return f
+}
@@ -1,13 +1,19 @@
 package boltdb
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/boltdb/bolt"
 )
 
-const BucketSessions = "sessions"
+const (
+	BucketSessions = "sessions"
+	// BucketHealth keeps the time of the last health check, which proves the DB is writable
+	BucketHealth = "health"
+)
 
 type DB struct {
 	*bolt.DB
@@ -15,17 +21,61 @@ type DB struct {
 
 func Get(dbpath string) (*DB, error) {
 	db, err := bolt.Open(dbpath, 0644, &bolt.Options{Timeout: 1 * time.Second})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("error init boltdb: %s: %w: %w", dbpath, ErrorLocked, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error init boltdb: %w", err)
 	}
-	err = db.Update(func(tx *bolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists([]byte(BucketSessions))
-		return err
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error creating bucket %q: %w", BucketSessions, err)
+	for _, bucket := range []string{BucketSessions, BucketHealth} {
+		err = db.Update(func(tx *bolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists([]byte(bucket))
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error creating bucket %q: %w", bucket, err)
+		}
 	}
 	return &DB{
 		DB: db,
 	}, nil
 }
+
+// CheckWritable writes the current time to the health bucket.
+func (db *DB) CheckWritable() error {
+	return db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(BucketHealth))
+		if b == nil {
+			return fmt.Errorf("bucket %q not found", BucketHealth)
+		}
+		return b.Put([]byte("checked_at"), []byte(time.Now().UTC().Format(time.RFC3339)))
+	})
+}
+
+// Backup writes a snapshot of the DB inside a read transaction, so writers are not blocked.
+func (db *DB) Backup(w io.Writer) error {
+	return db.View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(w)
+		return err
+	})
+}
+
+// Verify opens the file read-only and checks the consistency of all pages.
+func Verify(path string) error {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+	defer db.Close()
+	return db.View(func(tx *bolt.Tx) error {
+		for err := range tx.Check() {
+			return err
+		}
+		return nil
+	})
+}
+
+var (
+	// ErrorLocked the file is open by another process, usually by the running server
+	ErrorLocked = errors.New("locked by another process")
+)
@@ -0,0 +1,129 @@
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+const (
+	EventPostPublished       = "post.published"
+	EventPostUpdated         = "post.updated"
+	EventNotificationCreated = "notification.created"
+
+	subscriptionBuffer = 64
+)
+
+type Event struct {
+	ID   uint64
+	Type string
+	// UserID the only recipient of the event, 0 for public events
+	UserID int64
+	Data   []byte
+}
+
+type IBroker interface {
+	Publish(eventType string, userID int64, data interface{}) error
+	Subscribe(userID int64, lastEventID uint64) (*Subscription, []*Event)
+	Unsubscribe(sub *Subscription)
+}
+
+// Broker is an in-process pub/sub, which keeps the last events to let clients resume the stream.
+type Broker struct {
+	mutex       sync.Mutex
+	lastID      uint64
+	history     []*Event
+	historySize int
+	subscribers map[*Subscription]struct{}
+}
+
+type Subscription struct {
+	userID int64
+	events chan *Event
+}
+
+func New(historySize int) *Broker {
+	return &Broker{
+		history:     make([]*Event, 0, historySize),
+		historySize: historySize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Events channel is closed when the subscriber is too slow to receive events.
+func (s *Subscription) Events() <-chan *Event {
+	return s.events
+}
+
+func (b *Broker) Publish(eventType string, userID int64, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("Broker.Publish() Marshal: %w", err)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.lastID++
+	event := &Event{
+		ID:     b.lastID,
+		Type:   eventType,
+		UserID: userID,
+		Data:   payload,
+	}
+	if b.historySize > 0 {
+		if len(b.history) == b.historySize {
+			b.history = append(b.history[:0], b.history[1:]...)
+		}
+		b.history = append(b.history, event)
+	}
+
+	for sub := range b.subscribers {
+		if !sub.accepts(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// The client will reconnect and get the missed events from the history
+			delete(b.subscribers, sub)
+			close(sub.events)
+		}
+	}
+	return nil
+}
+
+// Subscribe returns the subscription and the events published after lastEventID which are still in the history.
+func (b *Broker) Subscribe(userID int64, lastEventID uint64) (*Subscription, []*Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	sub := &Subscription{
+		userID: userID,
+		events: make(chan *Event, subscriptionBuffer),
+	}
+	b.subscribers[sub] = struct{}{}
+
+	var missed []*Event
+	if lastEventID > 0 {
+		for _, event := range b.history {
+			if event.ID > lastEventID && sub.accepts(event) {
+				missed = append(missed, event)
+			}
+		}
+	}
+	return sub, missed
+}
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (s *Subscription) accepts(event *Event) bool {
+	return event.UserID == 0 || event.UserID == s.userID
+}
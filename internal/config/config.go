@@ -1,50 +1,447 @@
 package config
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"os"
+	"reflect"
 	"strconv"
+	"strings"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 
-	"github.com/HardDie/blog_engine/internal/logger"
+	"github.com/HardDie/blog_engine/internal/ratelimit"
 )
 
+// Sources of values, each one overrides the previous
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+const (
+	// EnvConfigFile is used when the -config flag is not set
+	EnvConfigFile = "CONFIG_FILE"
+
+	redacted = "[REDACTED]"
+)
+
+// Config every field is described by tags:
+//   - env: name of the environment variable, the file key and the flag are derived from it, e.g. db_path and -db-path
+//   - default: value used when no source sets it, lists are comma separated
+//   - validate: rules checked after all sources are applied
+//   - secret: the value is redacted by Print
+//   - reload: the value is applied on SIGHUP, other fields require a restart
 type Config struct {
-	DBPath         string
-	SessionsDBPath string
-	Port           string
-	PwdMaxAttempts int
-	PwdBlockTime   int
-	RequestTimeout int
+	DBDriver       string   `env:"DB_DRIVER" default:"sqlite" validate:"oneof=sqlite postgres"`
+	DBPath         string   `env:"DB_PATH" default:"blog.db" validate:"required"`
+	DBURL          string   `env:"DB_URL" validate:"required_if=DBDriver postgres" secret:"true"`
+	DBMaxConns     int      `env:"DB_MAX_CONNECTIONS" default:"10" validate:"min=1"`
+	SessionStore   string   `env:"SESSION_STORE" default:"boltdb" validate:"oneof=boltdb db memory"`
+	SessionsDBPath string   `env:"SESSIONS_DB_PATH" default:"blog_sessions.db" validate:"required"`
+	DBReadConns    int      `env:"DB_READ_CONNECTIONS" default:"4" validate:"min=1"`
+	DBBusyTimeout  int      `env:"DB_BUSY_TIMEOUT" default:"5000" validate:"min=0"`
+	Port           string   `env:"PORT" default:":8080" validate:"hostname_port"`
+	TrustedProxies []string `env:"TRUSTED_PROXIES" validate:"dive,cidr"`
+	PwdMaxAttempts int      `env:"PWD_MAX_ATTEMPTS" default:"5" validate:"min=0"`
+	PwdBlockTime   int      `env:"PWD_BLOCK_TIME" default:"24" validate:"min=0"`
+	SetupToken     string   `env:"SETUP_TOKEN" validate:"omitempty,min=16" secret:"true"`
+	RequestTimeout int      `env:"REQUEST_TIMEOUT" default:"3" validate:"min=1"`
+	Reactions      []string `env:"REACTIONS" default:"👍,❤️,😂,😮,😢,🔥" validate:"min=1"`
+	SMTPHost       string   `env:"SMTP_HOST"`
+	SMTPPort       int      `env:"SMTP_PORT" default:"587" validate:"min=1,max=65535"`
+	SMTPUsername   string   `env:"SMTP_USERNAME"`
+	SMTPPassword   string   `env:"SMTP_PASSWORD" secret:"true"`
+	SMTPFrom       string   `env:"SMTP_FROM" default:"blog@localhost" validate:"required"`
+	SSEHeartbeat   int      `env:"SSE_HEARTBEAT" default:"15" validate:"min=1"`
+	SSEHistory     int      `env:"SSE_HISTORY" default:"100" validate:"min=0"`
+
+	WebhookMaxAttempts  int  `env:"WEBHOOK_MAX_ATTEMPTS" default:"8" validate:"min=1"`
+	WebhookBackoff      int  `env:"WEBHOOK_BACKOFF" default:"30" validate:"min=1"`
+	WebhookTimeout      int  `env:"WEBHOOK_TIMEOUT" default:"10" validate:"min=1"`
+	WebhookAllowPrivate bool `env:"WEBHOOK_ALLOW_PRIVATE" default:"false"`
+
+	JobWorkers     int `env:"JOB_WORKERS" default:"2" validate:"min=1"`
+	JobMaxAttempts int `env:"JOB_MAX_ATTEMPTS" default:"5" validate:"min=1"`
+	JobBackoff     int `env:"JOB_BACKOFF" default:"10" validate:"min=1"`
+	JobTimeout     int `env:"JOB_TIMEOUT" default:"60" validate:"min=1"`
+
+	HTTPReadTimeout    int `env:"HTTP_READ_TIMEOUT" default:"10" validate:"min=0"`
+	HTTPWriteTimeout   int `env:"HTTP_WRITE_TIMEOUT" default:"30" validate:"min=0"`
+	HTTPIdleTimeout    int `env:"HTTP_IDLE_TIMEOUT" default:"120" validate:"min=0"`
+	HTTPMaxHeaderBytes int `env:"HTTP_MAX_HEADER_BYTES" default:"1048576" validate:"min=0"`
+	ShutdownTimeout    int `env:"SHUTDOWN_TIMEOUT" default:"30" validate:"min=1"`
+
+	TraceExporter string `env:"TRACE_EXPORTER" default:"none" validate:"oneof=none otlp stdout file"`
+	TraceFile     string `env:"TRACE_FILE" default:"traces.json" validate:"required_if=TraceExporter file"`
+
+	LogLevel  string `env:"LOG_LEVEL" default:"info" validate:"oneof=debug info warn error" reload:"true"`
+	LogFormat string `env:"LOG_FORMAT" default:"text" validate:"oneof=text json"`
+
+	CORSAllowedOrigins []string `env:"CORS_ALLOWED_ORIGINS" reload:"true"`
+	CORSAllowedMethods []string `env:"CORS_ALLOWED_METHODS" default:"GET,POST,PUT,PATCH,DELETE,OPTIONS" reload:"true"`
+	CORSAllowedHeaders []string `env:"CORS_ALLOWED_HEADERS" default:"Content-Type,X-CSRF-Token,Authorization,Last-Event-ID" reload:"true"`
+	CORSExposedHeaders []string `env:"CORS_EXPOSED_HEADERS" default:"X-Trace-Id,RateLimit-Policy,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,Retry-After" reload:"true"`
+	CORSMaxAge         int      `env:"CORS_MAX_AGE" default:"600" validate:"min=0" reload:"true"`
+	CSRFEnabled        bool     `env:"CSRF_ENABLED" default:"true"`
+
+	SecurityCSP            string `env:"SECURITY_CSP" default:"default-src 'none'; frame-ancestors 'none'"`
+	SecurityHSTS           string `env:"SECURITY_HSTS" default:"max-age=63072000; includeSubDomains"`
+	SecurityReferrerPolicy string `env:"SECURITY_REFERRER_POLICY" default:"strict-origin-when-cross-origin"`
+	SecurityFrameOptions   string `env:"SECURITY_FRAME_OPTIONS" default:"DENY"`
+
+	RateLimitDefault string `env:"RATE_LIMIT_DEFAULT" default:"300/1m" validate:"ratelimit" reload:"true"`
+	RateLimitAuth    string `env:"RATE_LIMIT_AUTH" default:"10/1m" validate:"ratelimit" reload:"true"`
+	RateLimitInvites string `env:"RATE_LIMIT_INVITES" default:"10/1h" validate:"ratelimit" reload:"true"`
+	RateLimitPosts   string `env:"RATE_LIMIT_POSTS" default:"30/1h" validate:"ratelimit" reload:"true"`
+
+	RateLimitLoginFailures string `env:"RATE_LIMIT_LOGIN_FAILURES" default:"5/15m" validate:"ratelimit" reload:"true"`
+
+	AdminToken     string `env:"ADMIN_TOKEN" validate:"omitempty,min=16" secret:"true"`
+	MetricsPublic  bool   `env:"METRICS_PUBLIC" default:"false"`
+	BackupDir      string `env:"BACKUP_DIR" default:"backups" validate:"required"`
+	BackupInterval int    `env:"BACKUP_INTERVAL" default:"24" validate:"min=0"`
+	BackupKeep     int    `env:"BACKUP_KEEP" default:"7" validate:"min=1"`
+
+	// File is the path of the loaded config file, empty if there is none
+	File string `env:"-"`
+
+	// args are kept to repeat the load on reload
+	args []string
+	// environ are names of the variables set in the process before .env is read, .env doesn't override them
+	environ map[string]bool
+	// sources of each value by the env name
+	sources map[string]string
+}
+
+// Load applies the layers: defaults, the YAML file, the environment (including .env) and the flags.
+// The file is set by the -config flag or CONFIG_FILE. Unknown file keys, values which don't parse
+// and values which fail validation are errors. The arguments left after the flags are returned.
+func Load(args []string) (*Config, []string, error) {
+	environ := make(map[string]bool)
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		environ[key] = true
+	}
+	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("config.Load() .env: %w", err)
+	}
+	return load(args, environ)
 }
+func load(args []string, environ map[string]bool) (*Config, []string, error) {
+	cfg := &Config{
+		args:    args,
+		environ: environ,
+		sources: make(map[string]string),
+	}
+	fields := cfg.fields()
+
+	// Defaults
+	for _, f := range fields {
+		err := f.set(f.def, SourceDefault)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Flags are parsed first, because they choose the file, but they are applied last
+	flags := flag.NewFlagSet("blog_engine", flag.ContinueOnError)
+	file := flags.String("config", os.Getenv(EnvConfigFile), "path of the YAML config file")
+	values := make(map[string]*string)
+	for _, f := range fields {
+		usage := "overrides " + f.env
+		values[f.env] = flags.String(f.flag(), f.def, usage)
+	}
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// File
+	if *file != "" {
+		err = cfg.loadFile(*file, fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.File = *file
+	}
+
+	// Environment
+	for _, f := range fields {
+		value, ok := os.LookupEnv(f.env)
+		if !ok {
+			continue
+		}
+		err = f.set(value, SourceEnv)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
-func Get() *Config {
-	if err := godotenv.Load(); err != nil {
-		if check := os.IsNotExist(err); !check {
-			logger.Error.Printf("failed to load env vars: %s", err)
+	// Flags
+	flags.Visit(func(fl *flag.Flag) {
+		for _, f := range fields {
+			if fl.Name == f.flag() && err == nil {
+				err = f.set(*values[f.env], SourceFlag)
+			}
 		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = cfg.validate()
+	if err != nil {
+		return nil, nil, err
 	}
+	return cfg, flags.Args(), nil
+}
 
-	return &Config{
-		DBPath:         getEnv("DB_PATH", "blog.db"),
-		SessionsDBPath: getEnv("SESSIONS_DB_PATH", "blog_sessions.db"),
-		Port:           getEnv("PORT", ":8080"),
-		PwdMaxAttempts: getEnvAsInt("PWD_MAX_ATTEMPTS", 5),
-		PwdBlockTime:   getEnvAsInt("PWD_BLOCK_TIME", 24),
-		RequestTimeout: getEnvAsInt("REQUEST_TIMEOUT", 3),
+// Reload repeats Load with the same arguments, so changes of the config file and .env are picked up.
+// godotenv.Load doesn't override variables, which are already set, so the variables of the config are set
+// from .env here, the ones removed from it are unset. Variables of the process still take precedence.
+func (c *Config) Reload() (*Config, error) {
+	values, err := godotenv.Read()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("config.Reload() .env: %w", err)
+	}
+	keys := []string{EnvConfigFile}
+	for _, f := range c.fields() {
+		keys = append(keys, f.env)
+	}
+	for _, key := range keys {
+		if c.environ[key] {
+			continue
+		}
+		value, ok := values[key]
+		if !ok {
+			err = os.Unsetenv(key)
+		} else {
+			err = os.Setenv(key, value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("config.Reload() %s: %w", key, err)
+		}
 	}
+
+	cfg, _, err := load(c.args, c.environ)
+	return cfg, err
 }
 
-func getEnv(key string, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
+// Merge returns a copy of the config with the reloadable values of the other one.
+// Names of the changed values, which require a restart, are returned too.
+func (c *Config) Merge(other *Config) (*Config, []string) {
+	merged := *c
+	merged.sources = make(map[string]string)
+	for key, value := range c.sources {
+		merged.sources[key] = value
+	}
+
+	var restart []string
+	mergedFields := merged.fields()
+	otherFields := other.fields()
+	for i, f := range mergedFields {
+		o := otherFields[i]
+		if reflect.DeepEqual(f.value.Interface(), o.value.Interface()) {
+			continue
+		}
+		if !f.reload {
+			restart = append(restart, f.env)
+			continue
+		}
+		f.value.Set(o.value)
+		merged.sources[f.env] = other.sources[f.env]
+	}
+	return &merged, restart
+}
+
+// Print writes the effective values in the .env format with their sources, secrets are redacted.
+func (c *Config) Print(w io.Writer) error {
+	if c.File != "" {
+		if _, err := fmt.Fprintf(w, "# config file: %s\n", c.File); err != nil {
+			return err
+		}
+	}
+	for _, f := range c.fields() {
+		value := f.String()
+		if f.secret && value != "" {
+			value = redacted
+		}
+		_, err := fmt.Fprintf(w, "%s=%s # %s\n", f.env, value, c.sources[f.env])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Config) loadFile(path string, fields []field) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config.Load() ReadFile: %w", err)
+	}
+	values := make(map[string]any)
+	err = yaml.Unmarshal(data, &values)
+	if err != nil {
+		return fmt.Errorf("config.Load() %s: %w", path, err)
+	}
+
+	byKey := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byKey[f.key()] = f
+	}
+	for key, value := range values {
+		f, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("config.Load() %s: unknown key %q", path, key)
+		}
+		err = f.set(fileValue(value), SourceFile)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileValue converts YAML values to the format of environment variables.
+func fileValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, el := range v {
+			list = append(list, fmt.Sprint(el))
+		}
+		return strings.Join(list, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+var errValidation = errors.New("invalid config")
+
+func (c *Config) validate() error {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("env")
+	})
+	err := v.RegisterValidation("ratelimit", func(fl validator.FieldLevel) bool {
+		_, err := ratelimit.ParseLimit(fl.Field().String())
+		return err == nil
+	})
+	if err != nil {
+		return fmt.Errorf("config.validate() RegisterValidation: %w", err)
+	}
+
+	err = v.Struct(c)
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+	messages := make([]string, 0, len(errs))
+	for _, el := range errs {
+		rule := el.Tag()
+		if el.Param() != "" {
+			rule += "=" + el.Param()
+		}
+		messages = append(messages, fmt.Sprintf("%s=%q (%s) fails %s", el.Field(), fmt.Sprint(el.Value()), c.sources[el.Field()], rule))
+	}
+	return fmt.Errorf("%w: %s", errValidation, strings.Join(messages, "; "))
+}
+
+/*
+ * Fields
+ */
+
+type field struct {
+	env    string
+	def    string
+	secret bool
+	reload bool
+	value  reflect.Value
+
+	sources map[string]string
+}
+
+func (c *Config) fields() []field {
+	rv := reflect.ValueOf(c).Elem()
+	rt := rv.Type()
+	var res []field
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		env := sf.Tag.Get("env")
+		if env == "" || env == "-" {
+			continue
+		}
+		res = append(res, field{
+			env:     env,
+			def:     sf.Tag.Get("default"),
+			secret:  sf.Tag.Get("secret") == "true",
+			reload:  sf.Tag.Get("reload") == "true",
+			value:   rv.Field(i),
+			sources: c.sources,
+		})
+	}
+	return res
+}
+
+// key in the config file
+func (f field) key() string {
+	return strings.ToLower(f.env)
+}
+func (f field) flag() string {
+	return strings.ReplaceAll(f.key(), "_", "-")
+}
+
+// set parses the value by the type of the field. Empty values of numbers and booleans are skipped,
+// so a variable left empty in .env keeps the value of the previous layer. An empty list is a value,
+// e.g. CORS_ALLOWED_ORIGINS= clears the origins of the file.
+func (f field) set(value, source string) error {
+	switch f.value.Kind() {
+	case reflect.String:
+		f.value.SetString(value)
+	case reflect.Int:
+		if value == "" {
+			return nil
+		}
+		v, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("config: %s=%q from %s is not an integer", f.env, value, source)
+		}
+		f.value.SetInt(int64(v))
+	case reflect.Bool:
+		if value == "" {
+			return nil
+		}
+		v, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("config: %s=%q from %s is not a boolean", f.env, value, source)
+		}
+		f.value.SetBool(v)
+	case reflect.Slice:
+		var list []string
+		for _, v := range strings.Split(value, ",") {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				list = append(list, v)
+			}
+		}
+		f.value.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("config: %s has unsupported type %s", f.env, f.value.Type())
 	}
-	return defaultValue
+	f.sources[f.env] = source
+	return nil
 }
-func getEnvAsInt(key string, defaultValue int) int {
-	value := getEnv(key, "")
-	if v, e := strconv.Atoi(value); e == nil {
-		return v
+func (f field) String() string {
+	if f.value.Kind() == reflect.Slice {
+		return strings.Join(f.value.Interface().([]string), ",")
 	}
-	return defaultValue
+	return fmt.Sprint(f.value.Interface())
 }
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReloadDotenv(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	// Variables set from .env are restored after the test
+	for _, key := range []string{"LOG_LEVEL", "RATE_LIMIT_POSTS"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	t.Setenv("RATE_LIMIT_AUTH", "7/1m")
+
+	writeDotenv := func(data string) {
+		err := os.WriteFile(".env", []byte(data), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeDotenv("LOG_LEVEL=debug\nRATE_LIMIT_POSTS=1/1h\nRATE_LIMIT_AUTH=8/1m\n")
+
+	cfg, _, err := Load(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.LogLevel != "debug" || cfg.RateLimitPosts != "1/1h" || cfg.RateLimitAuth != "7/1m" {
+		t.Fatalf("unexpected config: LOG_LEVEL=%s RATE_LIMIT_POSTS=%s RATE_LIMIT_AUTH=%s",
+			cfg.LogLevel, cfg.RateLimitPosts, cfg.RateLimitAuth)
+	}
+
+	// Changed values are applied, removed ones fall back to the default, the process environment still wins
+	writeDotenv("LOG_LEVEL=warn\nRATE_LIMIT_AUTH=9/1m\n")
+	cfg, err = cfg.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.LogLevel != "warn" || cfg.RateLimitPosts != "30/1h" || cfg.RateLimitAuth != "7/1m" {
+		t.Fatalf("unexpected reloaded config: LOG_LEVEL=%s RATE_LIMIT_POSTS=%s RATE_LIMIT_AUTH=%s",
+			cfg.LogLevel, cfg.RateLimitPosts, cfg.RateLimitAuth)
+	}
+	if cfg.sources["LOG_LEVEL"] != SourceEnv || cfg.sources["RATE_LIMIT_POSTS"] != SourceDefault {
+		t.Fatalf("unexpected sources %v", cfg.sources)
+	}
+}
@@ -1,26 +1,266 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"os"
 	"strings"
+	"sync/atomic"
 
-	_ "github.com/glebarez/go-sqlite"
+	sqlite "github.com/glebarez/go-sqlite"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/HardDie/blog_engine/internal/metrics"
+	"github.com/HardDie/blog_engine/internal/tracing"
+)
+
+// Drivers of DB_DRIVER, the names match the directories of migrations
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
 )
 
+// MemoryPath as DB_PATH keeps the SQLite DB in memory, it is lost when the DB is closed. It is meant for tests.
+const MemoryPath = ":memory:"
+
+// lockSuffix is the file next to DB_PATH, which is locked by Lock
+const lockSuffix = ".lock"
+
+// FileLock is held by Lock, the file itself is never removed, only the lock on it matters.
+type FileLock struct {
+	file *os.File
+}
+
+// memoryDBs names in-memory DBs, each Get opens its own one
+var memoryDBs atomic.Int64
+
 type DB struct {
-	*sql.DB
+	// Driver is one of the constants above, PostgreSQL has one pool for both Write and Read
+	Driver string
+	// Write has a single connection, so writers wait for each other in the pool instead of failing with "database is locked"
+	Write *sql.DB
+	// Read serves SELECT statements, in the WAL mode readers don't block the writer and each other
+	Read *sql.DB
+
+	// memory is set for MemoryPath, the DB lives in the memdb VFS
+	memory bool
 }
 
-func Get(dbpath string) (*DB, error) {
+// Get opens both pools of the DB in the WAL mode. busyTimeout in milliseconds is the wait for the lock
+// held by another process, e.g. the CLI running next to the server.
+func Get(dbpath string, readConnections, busyTimeout int) (*DB, error) {
+	memory := dbpath == MemoryPath
+	if memory {
+		// The memdb VFS shares the DB between connections of both pools, unlike the plain ":memory:".
+		// It doesn't support WAL and falls back to the in-memory journal, readers and the writer wait for each other.
+		dbpath = fmt.Sprintf("file:/blog-%d?vfs=memdb", memoryDBs.Add(1))
+	}
 	flags := []string{
 		"_pragma=foreign_keys(1)",
+		fmt.Sprintf("_pragma=busy_timeout(%d)", busyTimeout),
+		// WAL can lose the last transactions on a power loss, but never corrupts the DB
+		"_pragma=synchronous(NORMAL)",
+	}
+
+	write := open(dbpath, append(flags, "_pragma=journal_mode(WAL)", "_txlock=immediate"))
+	write.SetMaxOpenConns(1)
+	// Switch to WAL before readers connect, the mode is stored in the file
+	err := write.Ping()
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("open %s: %w", dbpath, err), write.Close())
 	}
 
-	db, err := sql.Open("sqlite", dbpath+"?"+strings.Join(flags, "&"))
+	read := open(dbpath, append(flags, "_pragma=query_only(1)"))
+	read.SetMaxOpenConns(readConnections)
+	read.SetMaxIdleConns(readConnections)
+	return &DB{
+		Driver: DriverSQLite,
+		Write:  write,
+		Read:   read,
+		memory: memory,
+	}, nil
+}
+
+// GetReadOnly opens the file without changing it, e.g. to verify a backup. Both pools share one connection.
+func GetReadOnly(dbpath string) (*DB, error) {
+	db := open("file:"+dbpath, []string{"mode=ro"})
+	db.SetMaxOpenConns(1)
+	err := db.Ping()
 	if err != nil {
-		return nil, err
+		return nil, errors.Join(fmt.Errorf("open %s: %w", dbpath, err), db.Close())
 	}
 	return &DB{
-		DB: db,
+		Driver: DriverSQLite,
+		Write:  db,
+		Read:   db,
 	}, nil
 }
+func open(dsn string, flags []string) *sql.DB {
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	return sql.OpenDB(&connector{
+		dsn:    dsn + separator + strings.Join(flags, "&"),
+		driver: &sqlite.Driver{},
+		system: semconv.DBSystemSqlite,
+	})
+}
+
+// Close closes the read pool first, so the writer is the last connection and checkpoints the WAL into the file.
+func (db *DB) Close() error {
+	if db.Read == db.Write {
+		return db.Write.Close()
+	}
+	return errors.Join(db.Read.Close(), db.Write.Close())
+}
+
+// Check pings both pools and runs a trivial query.
+func (db *DB) Check(ctx context.Context) error {
+	for name, pool := range map[string]*sql.DB{"write": db.Write, "read": db.Read} {
+		err := pool.PingContext(ctx)
+		if err != nil {
+			return fmt.Errorf("ping %s: %w", name, err)
+		}
+		var one int
+		err = pool.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+		if err != nil {
+			return fmt.Errorf("select %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Backup writes a consistent copy of the DB to the path, the file must not exist.
+// The copy is made by a reader, so writers are not blocked in the WAL mode.
+// PostgreSQL is backed up by its own tools, e.g. pg_dump.
+func (db *DB) Backup(ctx context.Context, path string) error {
+	if db.Driver != DriverSQLite {
+		return ErrorNotSupported
+	}
+	conn, err := db.Read.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("conn: %w", err)
+	}
+	defer conn.Close()
+	// The connection is discarded afterwards, so the pool doesn't get a writable reader
+	defer conn.Raw(func(any) error { return driver.ErrBadConn })
+
+	_, err = conn.ExecContext(ctx, "PRAGMA query_only(0)")
+	if err != nil {
+		return fmt.Errorf("query only: %w", err)
+	}
+	if db.memory {
+		// The copy is written by the VFS of the source, the file must be put on the disk explicitly
+		path = "file:" + path + "?vfs=unix"
+	}
+	_, err = conn.ExecContext(ctx, "VACUUM INTO ?", path)
+	if err != nil {
+		return fmt.Errorf("vacuum into: %w", err)
+	}
+	return nil
+}
+
+// IntegrityCheck runs the full consistency check of SQLite.
+func (db *DB) IntegrityCheck(ctx context.Context) error {
+	rows, err := db.Read.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return fmt.Errorf("integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		err = rows.Scan(&line)
+		if err != nil {
+			return fmt.Errorf("integrity check scan: %w", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("integrity check rows: %w", err)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("integrity check failed: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Methods below are used by sqlc repositories. Statements are routed by the first keyword:
+// SELECT goes to the read pool, the rest (including INSERT ... RETURNING) to the writer.
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.pool(query).ExecContext(ctx, query, args...)
+}
+func (db *DB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return db.pool(query).PrepareContext(ctx, query)
+}
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return db.pool(query).QueryContext(ctx, query, args...)
+}
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return db.pool(query).QueryRowContext(ctx, query, args...)
+}
+
+// BeginTx starts the transaction on the writer, so reads of the transaction see its own writes.
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return db.Write.BeginTx(ctx, opts)
+}
+
+func (db *DB) pool(query string) *sql.DB {
+	if isRead(query) {
+		return db.Read
+	}
+	return db.Write
+}
+
+// isRead skips the "-- name:" comment of sqlc and checks the first keyword.
+func isRead(query string) bool {
+	for strings.HasPrefix(query, "--") {
+		i := strings.IndexByte(query, '\n')
+		if i < 0 {
+			return false
+		}
+		query = strings.TrimSpace(query[i+1:])
+	}
+	query = strings.TrimSpace(query)
+	return len(query) >= 6 && strings.EqualFold(query[:6], "SELECT")
+}
+
+// startQuery creates the span only inside a traced operation, so polling of background workers doesn't produce root spans.
+func startQuery(ctx context.Context, system attribute.KeyValue, query string) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.SpanContext().IsValid() {
+		return ctx, parent
+	}
+	name := metrics.QueryName(query)
+	return tracing.Start(ctx, "db."+name,
+		system,
+		semconv.DBOperation(name),
+	)
+}
+
+// IsUniqueViolation reports whether the statement failed on a UNIQUE constraint or index.
+func IsUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+	}
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
+
+var (
+	ErrorNotSupported = errors.New("not supported by the driver of the DB")
+	ErrorLocked       = errors.New("the DB is locked by another process")
+)
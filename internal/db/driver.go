@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/HardDie/blog_engine/internal/metrics"
+	"github.com/HardDie/blog_engine/internal/tracing"
+)
+
+// connector opens connections of the SQL driver wrapped by conn.
+// Prepared statements and transactions of sqlc bypass the methods of DB, so queries are traced and measured
+// on the level of the driver connection.
+type connector struct {
+	dsn    string
+	driver driver.Driver
+	// system is the db.system attribute of spans
+	system attribute.KeyValue
+}
+
+func (c *connector) Connect(_ context.Context) (driver.Conn, error) {
+	inner, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{
+		Conn:   inner,
+		system: c.system,
+	}, nil
+}
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// conn relies on the driver connection implementing the context versions of the driver interfaces.
+type conn struct {
+	driver.Conn
+	system attribute.KeyValue
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	inner, err := c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{
+		Stmt:   inner,
+		query:  query,
+		system: c.system,
+	}, nil
+}
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (res driver.Result, err error) {
+	ctx, done := observe(ctx, c.system, query)
+	defer func() { done(err) }()
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (rows driver.Rows, err error) {
+	ctx, done := observe(ctx, c.system, query)
+	defer func() { done(err) }()
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+}
+
+// CheckNamedValue and ResetSession are optional, the wrapper would hide them from database/sql.
+// The PostgreSQL driver binds its own types and drops broken connections by them.
+func (c *conn) CheckNamedValue(value *driver.NamedValue) error {
+	checker, ok := c.Conn.(driver.NamedValueChecker)
+	if !ok {
+		return driver.ErrSkip
+	}
+	return checker.CheckNamedValue(value)
+}
+func (c *conn) ResetSession(ctx context.Context) error {
+	resetter, ok := c.Conn.(driver.SessionResetter)
+	if !ok {
+		return nil
+	}
+	return resetter.ResetSession(ctx)
+}
+
+type stmt struct {
+	driver.Stmt
+	query  string
+	system attribute.KeyValue
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (res driver.Result, err error) {
+	ctx, done := observe(ctx, s.system, s.query)
+	defer func() { done(err) }()
+	return s.Stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+}
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (rows driver.Rows, err error) {
+	ctx, done := observe(ctx, s.system, s.query)
+	defer func() { done(err) }()
+	return s.Stmt.(driver.StmtQueryContext).QueryContext(ctx, args)
+}
+
+// observe traces and measures sqlc queries, queries written by hand (pragmas, migrations, health checks) are skipped.
+func observe(ctx context.Context, system attribute.KeyValue, query string) (context.Context, func(err error)) {
+	if metrics.QueryName(query) == metrics.UnknownQuery {
+		return ctx, func(error) {}
+	}
+	start := time.Now()
+	ctx, span := startQuery(ctx, system, query)
+	return ctx, func(err error) {
+		if err != nil {
+			tracing.Error(span, err)
+		}
+		span.End()
+		metrics.ObserveQuery(query, start)
+	}
+}
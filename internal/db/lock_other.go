@@ -0,0 +1,12 @@
+//go:build !unix
+
+package db
+
+// Lock is a no-op where flock is not available, stopping the server before the restore is up to the admin.
+func Lock(dbpath string) (*FileLock, error) {
+	return &FileLock{}, nil
+}
+
+func (l *FileLock) Unlock() error {
+	return nil
+}
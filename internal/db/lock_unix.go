@@ -0,0 +1,33 @@
+//go:build unix
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// Lock takes the exclusive lock of the SQLite DB, the server holds it while running and the restore takes it
+// before replacing the files. The lock is released by Unlock or when the process exits.
+func Lock(dbpath string) (*FileLock, error) {
+	path := dbpath + lockSuffix
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if err != nil {
+		file.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, fmt.Errorf("%s: %w", path, ErrorLocked)
+		}
+		return nil, fmt.Errorf("flock %s: %w", path, err)
+	}
+	return &FileLock{file: file}, nil
+}
+
+func (l *FileLock) Unlock() error {
+	return l.file.Close()
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// pgUniqueViolation is the SQLSTATE of unique_violation
+const pgUniqueViolation = "23505"
+
+// GetPostgres opens one pool for reads and writes, the server handles concurrent writers itself.
+// dsn is a URL or key=value connection string of PostgreSQL.
+func GetPostgres(dsn string, maxConnections int) (*DB, error) {
+	pool := sql.OpenDB(&connector{
+		dsn:    dsn,
+		driver: stdlib.GetDefaultDriver(),
+		system: semconv.DBSystemPostgreSQL,
+	})
+	pool.SetMaxOpenConns(maxConnections)
+	pool.SetMaxIdleConns(maxConnections)
+	err := pool.Ping()
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("open postgres: %w", err), pool.Close())
+	}
+	return &DB{
+		Driver: DriverPostgres,
+		Write:  pool,
+		Read:   pool,
+	}, nil
+}
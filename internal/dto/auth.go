@@ -7,6 +7,22 @@ type RegisterDTO struct {
 	Invite        string `json:"invite" validate:"required,uuid"`
 }
 
+// SetupDTO creates the first account of a fresh instance
+type SetupDTO struct {
+	// One-time token from the log or SETUP_TOKEN
+	Token         string `json:"token" validate:"required"`
+	Username      string `json:"username" validate:"required"`
+	Password      string `json:"password" validate:"required"`
+	DisplayedName string `json:"displayedName" validate:"required"`
+}
+
+// CreateUserDTO is used by the CLI, the user is created without an invite
+type CreateUserDTO struct {
+	Username      string `json:"username" validate:"required"`
+	Password      string `json:"password" validate:"required"`
+	DisplayedName string `json:"displayedName" validate:"required"`
+}
+
 type LoginDTO struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
@@ -0,0 +1,21 @@
+package dto
+
+type ListNotificationDTO struct {
+	Limit  int32 `json:"limit" validate:"omitempty,gt=0"`
+	Page   int32 `json:"page" validate:"omitempty,gt=0"`
+	Unread bool  `json:"unread"`
+}
+
+type MarkReadNotificationDTO struct {
+	IDs []int64 `json:"ids" validate:"required,min=1,dive,gt=0"`
+}
+
+type NotificationPreferenceDTO struct {
+	Type  string `json:"type" validate:"oneof=reaction follow invite_used"`
+	InApp bool   `json:"inApp"`
+	Email bool   `json:"email"`
+}
+
+type UpdateNotificationPreferencesDTO struct {
+	Preferences []*NotificationPreferenceDTO `json:"preferences" validate:"required,min=1,dive"`
+}
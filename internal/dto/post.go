@@ -6,6 +6,7 @@ type CreatePostDTO struct {
 	Body        string   `json:"body" validate:"required"`
 	Tags        []string `json:"tags" validate:"omitempty,dive,alphanum"`
 	IsPublished bool     `json:"isPublished"`
+	PostMetaDTO
 }
 
 type FeedPostDTO struct {
@@ -14,6 +15,11 @@ type FeedPostDTO struct {
 	Query string `json:"query"`
 }
 
+type FeedFollowingPostDTO struct {
+	Limit int32 `json:"limit" validate:"omitempty,gt=0"`
+	Page  int32 `json:"page" validate:"omitempty,gt=0"`
+}
+
 type PublicGetDTO struct {
 	ID int64 `json:"id" validate:"gt=0"`
 }
@@ -25,6 +31,7 @@ type EditPostDTO struct {
 	Body        string   `json:"body" validate:"required"`
 	Tags        []string `json:"tags"`
 	IsPublished bool     `json:"isPublished"`
+	PostMetaDTO
 }
 
 type ListPostDTO struct {
@@ -33,6 +40,21 @@ type ListPostDTO struct {
 	Query string `json:"query"`
 }
 
+// PostMetaDTO optional cover image, SEO, OpenGraph and Twitter card fields of a post.
+type PostMetaDTO struct {
+	CoverImage         *string `json:"coverImage" validate:"omitempty,url"`
+	MetaDescription    *string `json:"metaDescription" validate:"omitempty,max=300"`
+	CanonicalURL       *string `json:"canonicalUrl" validate:"omitempty,url"`
+	NoIndex            bool    `json:"noIndex"`
+	OGTitle            *string `json:"ogTitle" validate:"omitempty,max=200"`
+	OGDescription      *string `json:"ogDescription" validate:"omitempty,max=300"`
+	OGImage            *string `json:"ogImage" validate:"omitempty,url"`
+	TwitterCard        *string `json:"twitterCard" validate:"omitempty,oneof=summary summary_large_image"`
+	TwitterTitle       *string `json:"twitterTitle" validate:"omitempty,max=70"`
+	TwitterDescription *string `json:"twitterDescription" validate:"omitempty,max=200"`
+	TwitterImage       *string `json:"twitterImage" validate:"omitempty,url"`
+}
+
 /*
  * internal
  */
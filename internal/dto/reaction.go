@@ -0,0 +1,6 @@
+package dto
+
+type ReactPostDTO struct {
+	ID       int64  `json:"-" validate:"gt=0"`
+	Reaction string `json:"reaction" validate:"required"`
+}
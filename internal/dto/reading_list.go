@@ -0,0 +1,42 @@
+package dto
+
+type CreateReadingListDTO struct {
+	Title    string `json:"title" validate:"required"`
+	IsPublic bool   `json:"isPublic"`
+}
+
+type EditReadingListDTO struct {
+	ID       int64  `json:"-" validate:"gt=0"`
+	Title    string `json:"title" validate:"required"`
+	IsPublic bool   `json:"isPublic"`
+}
+
+type GetReadingListDTO struct {
+	ID int64 `json:"id" validate:"gt=0"`
+}
+
+type ListReadingListDTO struct {
+	Limit int32 `json:"limit" validate:"omitempty,gt=0"`
+	Page  int32 `json:"page" validate:"omitempty,gt=0"`
+}
+
+type AddReadingListItemDTO struct {
+	ID     int64   `json:"-" validate:"gt=0"`
+	PostID int64   `json:"postId" validate:"gt=0"`
+	Note   *string `json:"note" validate:"omitempty,max=1000"`
+}
+
+type RemoveReadingListItemDTO struct {
+	ID     int64 `json:"id" validate:"gt=0"`
+	PostID int64 `json:"postId" validate:"gt=0"`
+}
+
+type ReorderReadingListDTO struct {
+	ID      int64   `json:"-" validate:"gt=0"`
+	PostIDs []int64 `json:"postIds" validate:"required,unique,dive,gt=0"`
+}
+
+type ExportReadingListDTO struct {
+	ID     int64  `json:"id" validate:"gt=0"`
+	Format string `json:"format" validate:"oneof=json markdown csv"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+type CreateSeriesDTO struct {
+	Title       string  `json:"title" validate:"required"`
+	Description *string `json:"description"`
+	PostIDs     []int64 `json:"postIds" validate:"omitempty,unique,dive,gt=0"`
+}
+
+type ReorderSeriesDTO struct {
+	ID      int64   `json:"-" validate:"gt=0"`
+	PostIDs []int64 `json:"postIds" validate:"unique,dive,gt=0"`
+}
+
+type PublicGetSeriesDTO struct {
+	ID int64 `json:"id" validate:"gt=0"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+type CreateWebhookDTO struct {
+	URL    string   `json:"url" validate:"required,url,startswith=http"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=post.published post.updated post.deleted user.registered"`
+}
+
+type GetWebhookDTO struct {
+	ID int64 `json:"id" validate:"gt=0"`
+}
+
+type ListWebhookDeliveriesDTO struct {
+	ID    int64 `json:"-" validate:"gt=0"`
+	Limit int32 `json:"limit" validate:"omitempty,gt=0"`
+	Page  int32 `json:"page" validate:"omitempty,gt=0"`
+}
+
+type RedeliverWebhookDTO struct {
+	ID         int64 `json:"-" validate:"gt=0"`
+	DeliveryID int64 `json:"-" validate:"gt=0"`
+}
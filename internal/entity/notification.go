@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+const (
+	NotificationTypeReaction   = "reaction"
+	NotificationTypeFollow     = "follow"
+	NotificationTypeInviteUsed = "invite_used"
+)
+
+// NotificationTypes all kinds of events the user can be notified about.
+var NotificationTypes = []string{
+	NotificationTypeReaction,
+	NotificationTypeFollow,
+	NotificationTypeInviteUsed,
+}
+
+type Notification struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"userId"`
+	Type      string     `json:"type"`
+	ActorID   int64      `json:"actorId"`
+	Actor     *User      `json:"actor,omitempty"`
+	PostID    *int64     `json:"postId"`
+	ReadAt    *time.Time `json:"readAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// NotificationPreference channels through which the user receives notifications of the type.
+type NotificationPreference struct {
+	Type  string `json:"type"`
+	InApp bool   `json:"inApp"`
+	Email bool   `json:"email"`
+}
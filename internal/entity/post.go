@@ -3,15 +3,39 @@ package entity
 import "time"
 
 type Post struct {
-	ID          int64      `json:"id"`
-	UserID      int64      `json:"userId"`
-	User        *User      `json:"user,omitempty"`
-	Title       string     `json:"title"`
-	Short       string     `json:"short"`
-	Body        string     `json:"body"`
-	Tags        []string   `json:"tags"`
-	IsPublished bool       `json:"isPublished"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   time.Time  `json:"updatedAt"`
-	DeletedAt   *time.Time `json:"deletedAt"`
+	ID                 int64            `json:"id"`
+	UserID             int64            `json:"userId"`
+	User               *User            `json:"user,omitempty"`
+	Title              string           `json:"title"`
+	Short              string           `json:"short"`
+	Body               string           `json:"body"`
+	Tags               []string         `json:"tags"`
+	IsPublished        bool             `json:"isPublished"`
+	CoverImage         *string          `json:"coverImage"`
+	MetaDescription    *string          `json:"metaDescription"`
+	CanonicalURL       *string          `json:"canonicalUrl"`
+	NoIndex            bool             `json:"noIndex"`
+	OGTitle            *string          `json:"ogTitle"`
+	OGDescription      *string          `json:"ogDescription"`
+	OGImage            *string          `json:"ogImage"`
+	TwitterCard        *string          `json:"twitterCard"`
+	TwitterTitle       *string          `json:"twitterTitle"`
+	TwitterDescription *string          `json:"twitterDescription"`
+	TwitterImage       *string          `json:"twitterImage"`
+	Series             *PostSeries      `json:"series,omitempty"`
+	Reactions          map[string]int64 `json:"reactions"`
+	UserReactions      []string         `json:"userReactions"`
+	IsSaved            bool             `json:"isSaved"`
+	CreatedAt          time.Time        `json:"createdAt"`
+	UpdatedAt          time.Time        `json:"updatedAt"`
+	DeletedAt          *time.Time       `json:"deletedAt"`
+}
+
+// MetaTag is a single tag in the <head> of a post page.
+// Exactly one of Name, Property or Rel is set.
+type MetaTag struct {
+	Name     string `json:"name,omitempty"`
+	Property string `json:"property,omitempty"`
+	Rel      string `json:"rel,omitempty"`
+	Content  string `json:"content"`
 }
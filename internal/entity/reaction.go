@@ -0,0 +1,8 @@
+package entity
+
+// PostReactions aggregated reactions of a post and the reactions of the current user.
+type PostReactions struct {
+	PostID        int64            `json:"postId"`
+	Reactions     map[string]int64 `json:"reactions"`
+	UserReactions []string         `json:"userReactions"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+type ReadingList struct {
+	ID         int64              `json:"id"`
+	UserID     int64              `json:"userId"`
+	Title      string             `json:"title"`
+	IsPublic   bool               `json:"isPublic"`
+	ItemsCount int64              `json:"itemsCount"`
+	Items      []*ReadingListItem `json:"items,omitempty"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+	DeletedAt  *time.Time         `json:"deletedAt"`
+}
+
+type ReadingListItem struct {
+	PostID    int64     `json:"postId"`
+	Post      *Post     `json:"post,omitempty"`
+	Note      *string   `json:"note"`
+	Position  int64     `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+type Series struct {
+	ID          int64      `json:"id"`
+	UserID      int64      `json:"userId"`
+	User        *User      `json:"user,omitempty"`
+	Title       string     `json:"title"`
+	Description *string    `json:"description"`
+	Posts       []*Post    `json:"posts"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	DeletedAt   *time.Time `json:"deletedAt"`
+}
+
+// PostSeries position of a post inside its series with navigation to the neighbouring parts.
+type PostSeries struct {
+	ID       int64     `json:"id"`
+	Title    string    `json:"title"`
+	Position int       `json:"position"`
+	Total    int       `json:"total"`
+	Prev     *PostLink `json:"prev"`
+	Next     *PostLink `json:"next"`
+}
+
+type PostLink struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
@@ -8,6 +8,9 @@ type User struct {
 	DisplayedName   string     `json:"displayedName"`
 	Email           *string    `json:"email,omitempty"`
 	InvitedByUserID int64      `json:"invitedByUserId"`
+	FollowersCount  *int64     `json:"followersCount,omitempty"`
+	FollowingCount  *int64     `json:"followingCount,omitempty"`
+	IsFollowed      *bool      `json:"isFollowed,omitempty"`
 	CreatedAt       time.Time  `json:"createdAt"`
 	UpdatedAt       time.Time  `json:"updatedAt"`
 	DeletedAt       *time.Time `json:"deletedAt"`
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	WebhookEventPostPublished  = "post.published"
+	WebhookEventPostUpdated    = "post.updated"
+	WebhookEventPostDeleted    = "post.deleted"
+	WebhookEventUserRegistered = "user.registered"
+
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+type Webhook struct {
+	ID     int64  `json:"id"`
+	UserID int64  `json:"userId"`
+	URL    string `json:"url"`
+	// Secret is returned only once, when the webhook is created
+	Secret    string     `json:"secret,omitempty"`
+	Events    []string   `json:"events"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhookId"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int64           `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"nextAttemptAt"`
+	ResponseStatus *int64          `json:"responseStatus"`
+	Error          *string         `json:"error"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+// WebhookPayload body of the request sent to the webhook URL.
+type WebhookPayload struct {
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
@@ -0,0 +1,330 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/HardDie/blog_engine/internal/config"
+	repositoryJob "github.com/HardDie/blog_engine/internal/repository/sqlite/job"
+	"github.com/HardDie/blog_engine/internal/tracing"
+)
+
+const (
+	pollInterval = time.Second
+	maxBackoff   = time.Hour
+
+	// TypePurgeDead periodic job, which removes old dead jobs
+	TypePurgeDead = "job.purge_dead"
+	purgeInterval = 24 * time.Hour
+	purgeAge      = 30 * 24 * time.Hour
+)
+
+// Handler processes the raw payload of the job, use Register to get a typed payload.
+type Handler func(ctx context.Context, payload []byte) error
+
+type IQueue interface {
+	// Enqueue adds the job to run as soon as possible
+	Enqueue(ctx context.Context, jobType string, payload interface{}) error
+	// EnqueueIn adds the job to run after the delay
+	EnqueueIn(ctx context.Context, jobType string, payload interface{}, delay time.Duration) error
+}
+
+// Queue runs jobs stored in SQLite by a pool of workers.
+// Failed jobs are retried with exponential backoff, and are marked dead when attempts are exhausted.
+type Queue struct {
+	jobRepository repositoryJob.Querier
+
+	cfg      *config.Config
+	handlers map[string]Handler
+	periodic map[string]time.Duration
+	wakeup   chan struct{}
+
+	stop   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(cfg *config.Config, job repositoryJob.Querier) *Queue {
+	q := &Queue{
+		jobRepository: job,
+		cfg:           cfg,
+		handlers:      make(map[string]Handler),
+		periodic:      make(map[string]time.Duration),
+		wakeup:        make(chan struct{}, 1),
+	}
+	q.RegisterRaw(TypePurgeDead, q.purgeDead)
+	q.Every(TypePurgeDead, purgeInterval)
+	return q
+}
+
+// Register adds a handler with the payload decoded from JSON into T.
+// Handlers must be registered before Start.
+func Register[T any](q *Queue, jobType string, handler func(ctx context.Context, payload *T) error) {
+	q.RegisterRaw(jobType, func(ctx context.Context, raw []byte) error {
+		payload := new(T)
+		err := json.Unmarshal(raw, payload)
+		if err != nil {
+			return fmt.Errorf("bad payload: %w", err)
+		}
+		return handler(ctx, payload)
+	})
+}
+func (q *Queue) RegisterRaw(jobType string, handler Handler) {
+	q.handlers[jobType] = handler
+}
+
+// Every makes the job periodic: the next run is scheduled after the interval once the previous one is finished.
+func (q *Queue) Every(jobType string, interval time.Duration) {
+	q.periodic[jobType] = interval
+}
+
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	return q.EnqueueIn(ctx, jobType, payload, 0)
+}
+func (q *Queue) EnqueueIn(ctx context.Context, jobType string, payload interface{}, delay time.Duration) error {
+	if _, ok := q.handlers[jobType]; !ok {
+		return fmt.Errorf("Queue.Enqueue() unknown job type %q", jobType)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("Queue.Enqueue() Marshal: %w", err)
+	}
+	_, err = q.jobRepository.Create(ctx, repositoryJob.CreateParams{
+		Type:        jobType,
+		Payload:     string(data),
+		MaxAttempts: int64(q.cfg.JobMaxAttempts),
+		Delay:       sqlDelay(delay),
+	})
+	if err != nil {
+		return fmt.Errorf("Queue.Enqueue() Create: %w", err)
+	}
+	if delay == 0 {
+		q.wake()
+	}
+	return nil
+}
+
+// Start returns jobs interrupted by the previous shutdown to the queue and starts workers.
+func (q *Queue) Start(ctx context.Context) error {
+	rows, err := q.jobRepository.ResetRunning(ctx)
+	if err != nil {
+		return fmt.Errorf("Queue.Start() ResetRunning: %w", err)
+	}
+	if rows > 0 {
+		slog.InfoContext(ctx, "Queue.Start() interrupted jobs are returned to the queue", "count", rows)
+	}
+	for jobType := range q.periodic {
+		err = q.schedule(ctx, jobType, 0)
+		if err != nil {
+			return fmt.Errorf("Queue.Start() %w", err)
+		}
+	}
+
+	var workerCtx context.Context
+	workerCtx, q.cancel = context.WithCancel(context.Background())
+	q.stop = make(chan struct{})
+	for i := 0; i < q.cfg.JobWorkers; i++ {
+		q.wg.Add(1)
+		go q.worker(workerCtx)
+	}
+	return nil
+}
+
+// Stop stops taking new jobs and waits for the running ones.
+// If the context expires first, running jobs are canceled and will be retried after the restart.
+func (q *Queue) Stop(ctx context.Context) error {
+	if q.stop == nil {
+		return nil
+	}
+	close(q.stop)
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		<-done
+		return fmt.Errorf("Queue.Stop() drain: %w", ctx.Err())
+	}
+}
+
+func (q *Queue) worker(ctx context.Context) {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		// Take jobs one by one until the queue is empty
+		for {
+			select {
+			case <-q.stop:
+				return
+			default:
+			}
+			ok, err := q.runNext(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "Queue.worker()", "error", err)
+				break
+			}
+			if !ok {
+				break
+			}
+		}
+
+		select {
+		case <-q.stop:
+			return
+		case <-ticker.C:
+		case <-q.wakeup:
+		}
+	}
+}
+func (q *Queue) runNext(ctx context.Context) (bool, error) {
+	job, err := q.jobRepository.Claim(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Claim: %w", err)
+	}
+
+	handler, ok := q.handlers[job.Type]
+	if !ok {
+		err = q.jobRepository.MarkDead(ctx, repositoryJob.MarkDeadParams{
+			LastError: sql.NullString{String: "no handler for the job type", Valid: true},
+			ID:        job.ID,
+		})
+		if err != nil {
+			return false, fmt.Errorf("MarkDead: %w", err)
+		}
+		return true, nil
+	}
+
+	jobCtx, span := tracing.Start(ctx, "job "+job.Type,
+		attribute.Int64("job.id", job.ID),
+		attribute.Int64("job.attempt", job.Attempts),
+	)
+	jobCtx, cancel := context.WithTimeout(jobCtx, time.Duration(q.cfg.JobTimeout)*time.Second)
+	jobErr := q.safeRun(jobCtx, handler, job)
+	cancel()
+	if jobErr != nil {
+		tracing.Error(span, jobErr)
+	}
+	span.End()
+	if ctx.Err() != nil {
+		// Canceled by shutdown, the job stays running and will be reset on the next start
+		return false, nil
+	}
+
+	if jobErr == nil {
+		err = q.jobRepository.Delete(ctx, job.ID)
+		if err != nil {
+			return false, fmt.Errorf("Delete: %w", err)
+		}
+		return true, q.reschedule(ctx, job.Type)
+	}
+
+	lastError := sql.NullString{String: jobErr.Error(), Valid: true}
+	if job.Attempts >= job.MaxAttempts {
+		slog.ErrorContext(ctx, "Queue.runNext() job is dead", "type", job.Type, "job_id", job.ID, "attempts", job.Attempts, "error", jobErr)
+		err = q.jobRepository.MarkDead(ctx, repositoryJob.MarkDeadParams{
+			LastError: lastError,
+			ID:        job.ID,
+		})
+		if err != nil {
+			return false, fmt.Errorf("MarkDead: %w", err)
+		}
+		return true, q.reschedule(ctx, job.Type)
+	}
+
+	err = q.jobRepository.Retry(ctx, repositoryJob.RetryParams{
+		LastError: lastError,
+		Delay:     sqlDelay(q.backoff(job.Attempts)),
+		ID:        job.ID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("Retry: %w", err)
+	}
+	return true, nil
+}
+
+// safeRun turns a panic of the handler into an error, so a broken job can't stop the worker.
+func (q *Queue) safeRun(ctx context.Context, handler Handler, job *repositoryJob.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, []byte(job.Payload))
+}
+func (q *Queue) reschedule(ctx context.Context, jobType string) error {
+	interval, ok := q.periodic[jobType]
+	if !ok {
+		return nil
+	}
+	return q.schedule(ctx, jobType, interval)
+}
+
+// schedule adds the periodic job, unless it is already in the queue.
+func (q *Queue) schedule(ctx context.Context, jobType string, delay time.Duration) error {
+	count, err := q.jobRepository.CountPendingByType(ctx, jobType)
+	if err != nil {
+		return fmt.Errorf("CountPendingByType: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	err = q.EnqueueIn(ctx, jobType, struct{}{}, delay)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (q *Queue) purgeDead(ctx context.Context, _ []byte) error {
+	rows, err := q.jobRepository.PurgeDead(ctx, sqlDelay(-purgeAge))
+	if err != nil {
+		return fmt.Errorf("PurgeDead: %w", err)
+	}
+	if rows > 0 {
+		slog.InfoContext(ctx, "Queue.purgeDead() dead jobs removed", "count", rows)
+	}
+	return nil
+}
+
+// backoff doubles the delay after each failed attempt.
+func (q *Queue) backoff(attempts int64) time.Duration {
+	delay := time.Duration(q.cfg.JobBackoff) * time.Second
+	for i := int64(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
+func (q *Queue) wake() {
+	select {
+	case q.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// sqlDelay formats the duration as a modifier of the SQLite datetime function, PostgreSQL parses it as an interval.
+func sqlDelay(delay time.Duration) string {
+	return fmt.Sprintf("%+d seconds", int64(delay/time.Second))
+}
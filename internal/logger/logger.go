@@ -1,13 +1,184 @@
 package logger
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+
+	redacted = "[REDACTED]"
 )
 
 var (
-	Debug = log.New(os.Stdout, "DEBUG\t", log.Ldate|log.Ltime)
-	Info  = log.New(os.Stdout, "INFO\t", log.Ldate|log.Ltime)
-	Warn  = log.New(os.Stdout, "WARN\t", log.Ldate|log.Ltime|log.Lshortfile)
-	Error = log.New(os.Stderr, "ERROR\t", log.Ldate|log.Ltime|log.Lshortfile)
+	// sensitiveWords attributes containing any of these words are never written to the log
+	sensitiveWords = []string{"password", "session", "token", "cookie", "secret", "authorization"}
+	// sensitiveKeys attributes with exactly these names are never written to the log
+	sensitiveKeys = []string{"invite", "invite_hash"}
+
+	// level is shared by all handlers, so it can be changed on config reload
+	level slog.LevelVar
 )
+
+// Init replaces the default slog logger, so the slog package functions can be used everywhere.
+func Init(lvl, format string, w io.Writer) error {
+	err := SetLevel(lvl)
+	if err != nil {
+		return err
+	}
+
+	opts := &slog.HandlerOptions{
+		Level:       &level,
+		ReplaceAttr: redact,
+	}
+	var handler slog.Handler
+	switch format {
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return fmt.Errorf("logger.Init() unknown format %q", format)
+	}
+	slog.SetDefault(slog.New(&contextHandler{Handler: handler}))
+	return nil
+}
+
+// SetLevel changes the minimal level of the default logger.
+func SetLevel(lvl string) error {
+	var value slog.Level
+	err := value.UnmarshalText([]byte(lvl))
+	if err != nil {
+		return fmt.Errorf("logger.SetLevel() bad level %q: %w", lvl, err)
+	}
+	level.Set(value)
+	return nil
+}
+
+// Fatal logs the error and exits, it is used only during the start.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// IsSensitive reports whether the value of the key must be hidden.
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, el := range sensitiveWords {
+		if strings.Contains(key, el) {
+			return true
+		}
+	}
+	for _, el := range sensitiveKeys {
+		if key == el {
+			return true
+		}
+	}
+	return false
+}
+func redact(_ []string, attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() != slog.KindGroup && IsSensitive(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+	return attr
+}
+
+/*
+ * Request correlation
+ */
+
+type fieldsKey struct{}
+
+// fields are filled by middlewares as the request goes deeper, so the pointer is shared by all derived contexts.
+type fields struct {
+	mutex     sync.Mutex
+	requestID string
+	route     string
+	userID    int64
+}
+
+// WithRequest attaches the request ID and the route template to all logs written with the context.
+func WithRequest(ctx context.Context, requestID, route string) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, &fields{
+		requestID: requestID,
+		route:     route,
+	})
+}
+
+// SetUserID is called by the auth middleware after the session is validated.
+func SetUserID(ctx context.Context, userID int64) {
+	f, ok := ctx.Value(fieldsKey{}).(*fields)
+	if !ok {
+		return
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.userID = userID
+}
+
+// Attrs returns correlation attributes of the request and the trace ID.
+func Attrs(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+
+	var attrs []slog.Attr
+	if f, ok := ctx.Value(fieldsKey{}).(*fields); ok {
+		f.mutex.Lock()
+		attrs = append(attrs,
+			slog.String("request_id", f.requestID),
+			slog.String("route", f.route),
+		)
+		if f.userID != 0 {
+			attrs = append(attrs, slog.Int64("user_id", f.userID))
+		}
+		f.mutex.Unlock()
+	}
+	if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
+		attrs = append(attrs, slog.String("trace_id", span.TraceID().String()))
+	}
+	return attrs
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	record.AddAttrs(Attrs(ctx)...)
+	return h.Handler.Handle(ctx, record)
+}
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// GooseLogger writes migration logs with the default logger.
+type GooseLogger struct{}
+
+func (GooseLogger) Print(v ...interface{}) {
+	slog.Info(strings.TrimSpace(fmt.Sprint(v...)))
+}
+func (GooseLogger) Println(v ...interface{}) {
+	slog.Info(strings.TrimSpace(fmt.Sprintln(v...)))
+}
+func (GooseLogger) Printf(format string, v ...interface{}) {
+	slog.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+func (GooseLogger) Fatal(v ...interface{}) {
+	Fatal(strings.TrimSpace(fmt.Sprint(v...)))
+}
+func (GooseLogger) Fatalf(format string, v ...interface{}) {
+	Fatal(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
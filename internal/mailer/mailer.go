@@ -0,0 +1,76 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/smtp"
+	"strings"
+
+	"github.com/HardDie/blog_engine/internal/config"
+)
+
+// JobSend background job, which sends the Message
+const JobSend = "email.send"
+
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer delivers email messages, implementations are chosen by the configuration.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// New returns the SMTP mailer if the SMTP host is configured, otherwise emails are only logged.
+func New(cfg *config.Config) Mailer {
+	if cfg.SMTPHost == "" {
+		return &Noop{}
+	}
+	return &SMTP{
+		addr: fmt.Sprintf("%s:%d", cfg.SMTPHost, cfg.SMTPPort),
+		host: cfg.SMTPHost,
+		from: cfg.SMTPFrom,
+		user: cfg.SMTPUsername,
+		pass: cfg.SMTPPassword,
+	}
+}
+
+type Noop struct{}
+
+func (m *Noop) Send(ctx context.Context, to, subject, _ string) error {
+	slog.DebugContext(ctx, "Mailer.Send() skip email, SMTP is not configured", "subject", subject, "to", to)
+	return nil
+}
+
+type SMTP struct {
+	addr string
+	host string
+	from string
+	user string
+	pass string
+}
+
+func (m *SMTP) Send(_ context.Context, to, subject, body string) error {
+	var auth smtp.Auth
+	if m.user != "" {
+		auth = smtp.PlainAuth("", m.user, m.pass, m.host)
+	}
+
+	msg := strings.Join([]string{
+		"From: " + m.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		body,
+	}, "\r\n")
+	err := smtp.SendMail(m.addr, auth, m.from, []string{to}, []byte(msg))
+	if err != nil {
+		return fmt.Errorf("Mailer.Send() SendMail: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "blog"
+
+	LoginFailureUnknownUser     = "unknown_user"
+	LoginFailureInvalidPassword = "invalid_password"
+	LoginFailureBlocked         = "blocked"
+	LoginFailureBanned          = "banned"
+)
+
+var (
+	Registry = prometheus.NewRegistry()
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of handled HTTP requests.",
+	}, []string{"method", "route", "status"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of HTTP requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of SQL queries by the sqlc query name.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"query"})
+	loginFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_failures_total",
+		Help:      "Number of failed login attempts by the reason.",
+	}, []string{"reason"})
+	lockouts = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_lockouts_total",
+		Help:      "Number of accounts locked after too many failed login attempts.",
+	})
+
+	activeSessionsMutex sync.Mutex
+	activeSessionsCount func(ctx context.Context) (int64, error)
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		dbQueryDuration,
+		loginFailures,
+		lockouts,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "active_sessions",
+			Help:      "Number of not expired sessions.",
+		}, activeSessions),
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// Middleware records requests by the route template, so paths with IDs don't create new series.
+// It must be registered with Router.Use, where the matched route is known.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := "unknown"
+		if current := mux.CurrentRoute(r); current != nil {
+			if tpl, err := current.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+
+		ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		httpRequests.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+		httpDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+// ObserveQuery records the duration of the query, the name is taken from the "-- name:" comment of sqlc.
+func ObserveQuery(query string, start time.Time) {
+	dbQueryDuration.WithLabelValues(QueryName(query)).Observe(time.Since(start).Seconds())
+}
+
+// UnknownQuery is the name of queries written by hand
+const UnknownQuery = "unknown"
+
+// QueryName returns the sqlc query name, or UnknownQuery for queries written by hand.
+func QueryName(query string) string {
+	const prefix = "-- name: "
+	if !strings.HasPrefix(query, prefix) {
+		return UnknownQuery
+	}
+	name := query[len(prefix):]
+	if i := strings.IndexAny(name, " \n"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func LoginFailure(reason string) {
+	loginFailures.WithLabelValues(reason).Inc()
+}
+func Lockout() {
+	lockouts.Inc()
+}
+
+// SetActiveSessions sets the function, which counts sessions on every scrape.
+func SetActiveSessions(count func(ctx context.Context) (int64, error)) {
+	activeSessionsMutex.Lock()
+	defer activeSessionsMutex.Unlock()
+	activeSessionsCount = count
+}
+func activeSessions() float64 {
+	activeSessionsMutex.Lock()
+	count := activeSessionsCount
+	activeSessionsMutex.Unlock()
+	if count == nil {
+		return 0
+	}
+	res, err := count(context.Background())
+	if err != nil {
+		slog.Error("metrics.activeSessions()", "error", err)
+		return 0
+	}
+	return float64(res)
+}
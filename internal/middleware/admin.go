@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/problem"
+)
+
+// AdminMiddleware authorizes the admin endpoints by the ADMIN_TOKEN bearer token.
+// While the token is not configured, the endpoints don't exist for clients.
+func AdminMiddleware(cfg *config.Config) func(next http.Handler) http.Handler {
+	token := []byte(cfg.AdminToken)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(token) == 0 {
+				NotFoundHandler(w, r)
+				return
+			}
+			header, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(header), token) != 1 {
+				problem.Write(w, r, problem.New(http.StatusUnauthorized, problem.CodeAdminToken, "Invalid admin token"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/HardDie/blog_engine/internal/logger"
+	"github.com/HardDie/blog_engine/internal/problem"
 	"github.com/HardDie/blog_engine/internal/service/auth"
 	"github.com/HardDie/blog_engine/internal/utils"
 )
@@ -24,7 +26,7 @@ func (m *AuthMiddleware) RequestMiddleware(next http.Handler) http.Handler {
 
 		// If we got no cookie
 		if cookie == nil || cookie.Value == "" {
-			http.Error(w, "Session not found in cookie", http.StatusUnauthorized)
+			problem.Write(w, r, problem.New(http.StatusUnauthorized, problem.CodeSessionMissing, "Session not found in cookie"))
 			return
 		}
 
@@ -34,18 +36,42 @@ func (m *AuthMiddleware) RequestMiddleware(next http.Handler) http.Handler {
 		if err != nil || session == nil {
 			switch {
 			case errors.Is(err, auth.ErrorSessionNotFound):
-				http.Error(w, "Session not found", http.StatusUnauthorized)
+				problem.Write(w, r, problem.New(http.StatusUnauthorized, problem.CodeSessionNotFound, "Session not found"))
 				return
 			case errors.Is(err, auth.ErrorSessionHasExpired):
-				http.Error(w, "Session has expired", http.StatusUnauthorized)
+				problem.Write(w, r, problem.New(http.StatusUnauthorized, problem.CodeSessionExpired, "Session has expired"))
 				return
 			}
-			http.Error(w, "Invalid session", http.StatusUnauthorized)
+			problem.Write(w, r, problem.New(http.StatusUnauthorized, problem.CodeSessionInvalid, "Invalid session"))
 			return
 		}
 
 		ctx = context.WithValue(ctx, "userID", session.UserID)
 		ctx = context.WithValue(ctx, "session", session)
+		logger.SetUserID(ctx, session.UserID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// OptionalRequestMiddleware same as RequestMiddleware, but lets anonymous requests through.
+func (m *AuthMiddleware) OptionalRequestMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(utils.SessionCookieName)
+		if err != nil || cookie.Value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		session, err := m.authService.ValidateCookie(ctx, cookie.Value)
+		if err != nil || session == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx = context.WithValue(ctx, "userID", session.UserID)
+		ctx = context.WithValue(ctx, "session", session)
+		logger.SetUserID(ctx, session.UserID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -2,22 +2,96 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/HardDie/blog_engine/internal/config"
 )
 
-// CorsMiddleware CORS Headers middleware.
-func CorsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		SetupCors(w, r)
-		next.ServeHTTP(w, r)
-	})
+type Cors struct {
+	policy atomic.Pointer[corsPolicy]
+}
+
+type corsPolicy struct {
+	origins   map[string]struct{}
+	anyOrigin bool
+	methods   string
+	headers   string
+	exposed   string
+	maxAge    string
+}
+
+func NewCors(cfg *config.Config) *Cors {
+	c := &Cors{}
+	c.Update(cfg)
+	return c
 }
 
-func SetupCors(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "https://mindposter.online")
-	w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PATCH,UPDATE,DELETE,OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-CSRF-Token, Authorization")
-	w.Header().Set("Access-Control-Allow-Credentials", "true")
-	if r.Method == http.MethodOptions {
-		return
+// Update replaces the policy on config reload, requests in flight keep the previous one.
+func (c *Cors) Update(cfg *config.Config) {
+	p := &corsPolicy{
+		origins: make(map[string]struct{}),
+		methods: strings.Join(cfg.CORSAllowedMethods, ", "),
+		headers: strings.Join(cfg.CORSAllowedHeaders, ", "),
+		exposed: strings.Join(cfg.CORSExposedHeaders, ", "),
+		maxAge:  strconv.Itoa(cfg.CORSMaxAge),
 	}
+	for _, origin := range cfg.CORSAllowedOrigins {
+		if origin == "*" {
+			p.anyOrigin = true
+			continue
+		}
+		p.origins[strings.ToLower(origin)] = struct{}{}
+	}
+	c.policy.Store(p)
+}
+
+// IsAllowed reports whether the origin is listed in the config. The wildcard doesn't count,
+// it lets any site read responses only without credentials.
+func (c *Cors) IsAllowed(origin string) bool {
+	return c.policy.Load().isListed(origin)
+}
+func (p *corsPolicy) isListed(origin string) bool {
+	_, ok := p.origins[strings.ToLower(origin)]
+	return ok
+}
+
+// Handler wraps the whole router, because mux doesn't run middlewares for preflight requests of unknown methods.
+func (c *Cors) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+		p := c.policy.Load()
+		origin := r.Header.Get("Origin")
+		listed := p.isListed(origin)
+		if origin == "" || (!listed && !p.anyOrigin) {
+			// Not a CORS request, or the browser will block the response because of missing headers
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if listed {
+			// The origin is reflected, because the wildcard is not allowed together with credentials
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		} else {
+			// Browsers don't expose responses of requests with cookies to other origins under the wildcard
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", p.methods)
+			w.Header().Set("Access-Control-Allow-Headers", p.headers)
+			w.Header().Set("Access-Control-Max-Age", p.maxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if p.exposed != "" {
+			w.Header().Set("Access-Control-Expose-Headers", p.exposed)
+		}
+		next.ServeHTTP(w, r)
+	})
 }
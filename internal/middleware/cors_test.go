@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/middleware"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+func newCors(origins ...string) *middleware.Cors {
+	return middleware.NewCors(&config.Config{
+		CORSAllowedOrigins: origins,
+		CORSAllowedMethods: []string{http.MethodGet, http.MethodPost},
+		CORSAllowedHeaders: []string{"Content-Type"},
+		CORSMaxAge:         600,
+	})
+}
+
+func TestCors(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, tc := range []struct {
+		name        string
+		origins     []string
+		origin      string
+		allowOrigin string
+		credentials bool
+	}{
+		{name: "listed", origins: []string{"https://blog.example"}, origin: "https://blog.example",
+			allowOrigin: "https://blog.example", credentials: true},
+		{name: "not listed", origins: []string{"https://blog.example"}, origin: "https://evil.example"},
+		{name: "wildcard", origins: []string{"*"}, origin: "https://evil.example", allowOrigin: "*"},
+		{name: "listed with wildcard", origins: []string{"*", "https://blog.example"}, origin: "https://blog.example",
+			allowOrigin: "https://blog.example", credentials: true},
+		{name: "not a cors request", origins: []string{"*"}},
+		{name: "no origins", origin: "https://blog.example"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, method := range []string{http.MethodGet, http.MethodOptions} {
+				r := httptest.NewRequest(method, "/api/v1/posts/feed", nil)
+				if tc.origin != "" {
+					r.Header.Set("Origin", tc.origin)
+				}
+				if method == http.MethodOptions {
+					r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+				}
+				w := httptest.NewRecorder()
+				newCors(tc.origins...).Handler(ok).ServeHTTP(w, r)
+
+				if got := w.Header().Get("Access-Control-Allow-Origin"); got != tc.allowOrigin {
+					t.Fatalf("%s: Access-Control-Allow-Origin = %q, expected %q", method, got, tc.allowOrigin)
+				}
+				if got := w.Header().Get("Access-Control-Allow-Credentials") == "true"; got != tc.credentials {
+					t.Fatalf("%s: Access-Control-Allow-Credentials = %t, expected %t", method, got, tc.credentials)
+				}
+			}
+		})
+	}
+}
+
+func TestCSRFWildcard(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	for origin, status := range map[string]int{
+		"https://blog.example": http.StatusOK,
+		"https://evil.example": http.StatusForbidden,
+	} {
+		csrf := middleware.NewCSRF(newCors("*", "https://blog.example"), true)
+		r := httptest.NewRequest(http.MethodPost, "https://api.example/api/v1/posts", nil)
+		r.AddCookie(&http.Cookie{Name: utils.SessionCookieName, Value: "session"})
+		r.Header.Set("Origin", origin)
+		r.Header.Set("Sec-Fetch-Site", "cross-site")
+		w := httptest.NewRecorder()
+		csrf.Middleware(ok).ServeHTTP(w, r)
+
+		if w.Code != status {
+			t.Errorf("%s: expected status %d, got %d", origin, status, w.Code)
+		}
+	}
+}
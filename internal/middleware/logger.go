@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/gorilla/mux"
+
+	"github.com/HardDie/blog_engine/internal/logger"
+)
+
+// AccessLogMiddleware writes a line per request with the same handler as the rest of logs.
+// It must be registered with Router.Use after chi RequestID, where the matched route is known.
+func AccessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := "unknown"
+		if current := mux.CurrentRoute(r); current != nil {
+			if tpl, err := current.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+		ctx := logger.WithRequest(r.Context(), chiMiddleware.GetReqID(r.Context()), route)
+
+		ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.LogAttrs(ctx, level, "request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("query", redactQuery(r.URL.Query())),
+			slog.Int("status", status),
+			slog.Int("bytes", ww.BytesWritten()),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("remote", r.RemoteAddr),
+		)
+	})
+}
+
+func redactQuery(query url.Values) string {
+	for key := range query {
+		if logger.IsSensitive(key) {
+			query.Set(key, "[REDACTED]")
+		}
+	}
+	res, err := url.QueryUnescape(query.Encode())
+	if err != nil {
+		return query.Encode()
+	}
+	return res
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/HardDie/blog_engine/internal/problem"
+)
+
+// RecovererMiddleware replaces chi Recoverer, so a panic is answered with problem+json and logged with the request.
+func RecovererMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				// Used by net/http to abort the response, it must not be recovered
+				panic(rec)
+			}
+			slog.ErrorContext(r.Context(), "panic", "error", fmt.Sprint(rec), "stack", string(debug.Stack()))
+			problem.Write(w, r, problem.Internal())
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// TimeoutMiddleware replaces chi Timeout, it answers with problem+json if the handler has not written anything before the deadline.
+func TimeoutMiddleware(timeout time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && ww.Status() == 0 {
+				problem.Write(w, r, problem.New(http.StatusGatewayTimeout, problem.CodeTimeout, "Request timed out"))
+			}
+		})
+	}
+}
+
+// NotFoundHandler is used for unknown routes.
+func NotFoundHandler(w http.ResponseWriter, r *http.Request) {
+	problem.Write(w, r, problem.New(http.StatusNotFound, problem.CodeNotFound, "Route not found"))
+}
+
+// MethodNotAllowedHandler is used for known routes with unsupported methods.
+func MethodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	problem.Write(w, r, problem.New(http.StatusMethodNotAllowed, problem.CodeMethodNotAllowed, "Method not allowed"))
+}
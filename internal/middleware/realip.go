@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/HardDie/blog_engine/internal/config"
+)
+
+// RealIP replaces RemoteAddr by the address of the client from X-Forwarded-For or X-Real-IP.
+// The headers are set by anyone, so they are honored only if RemoteAddr is one of TRUSTED_PROXIES.
+// X-Forwarded-For is read from the right, addresses of the trusted proxies are skipped.
+func RealIP(cfg *config.Config) func(next http.Handler) http.Handler {
+	trusted := make([]netip.Prefix, 0, len(cfg.TrustedProxies))
+	for _, el := range cfg.TrustedProxies {
+		// The config is validated, invalid prefixes can't get here
+		prefix, err := netip.ParsePrefix(el)
+		if err == nil {
+			trusted = append(trusted, prefix.Masked())
+		}
+	}
+	isTrusted := func(ip netip.Addr) bool {
+		ip = ip.Unmap()
+		for _, prefix := range trusted {
+			if prefix.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(trusted) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			host, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				host = r.RemoteAddr
+			}
+			remote, err := netip.ParseAddr(host)
+			if err != nil || !isTrusted(remote) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if ip := forwardedFor(r.Header.Values("X-Forwarded-For"), isTrusted); ip.IsValid() {
+				r.RemoteAddr = ip.String()
+			} else if ip, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+				r.RemoteAddr = ip.String()
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedFor returns the rightmost address, which is not a trusted proxy. Addresses to the left of it are set by the client.
+func forwardedFor(headers []string, isTrusted func(netip.Addr) bool) netip.Addr {
+	var list []string
+	for _, header := range headers {
+		list = append(list, strings.Split(header, ",")...)
+	}
+	var ip netip.Addr
+	for i := len(list) - 1; i >= 0; i-- {
+		var err error
+		ip, err = netip.ParseAddr(strings.TrimSpace(list[i]))
+		if err != nil {
+			return netip.Addr{}
+		}
+		if !isTrusted(ip) {
+			return ip
+		}
+	}
+	// The client is a trusted address itself
+	return ip
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/HardDie/blog_engine/internal/config"
+)
+
+// SecurityHeadersMiddleware sets the headers configured by SECURITY_* variables, empty values are skipped.
+func SecurityHeadersMiddleware(cfg *config.Config) func(next http.Handler) http.Handler {
+	headers := map[string]string{
+		"Content-Security-Policy":   cfg.SecurityCSP,
+		"Strict-Transport-Security": cfg.SecurityHSTS,
+		"Referrer-Policy":           cfg.SecurityReferrerPolicy,
+		"X-Frame-Options":           cfg.SecurityFrameOptions,
+		"X-Content-Type-Options":    "nosniff",
+	}
+	for key, value := range headers {
+		if value == "" {
+			delete(headers, key)
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for key, value := range headers {
+				w.Header().Set(key, value)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
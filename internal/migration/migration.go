@@ -14,25 +14,76 @@ const (
 	MigrationTable = "migrations"
 )
 
+type Status struct {
+	Current int64 `json:"current"`
+	Latest  int64 `json:"latest"`
+	Pending int   `json:"pending"`
+}
+
+// dialects of goose by the driver of the DB
+var dialects = map[string]string{
+	db.DriverSQLite:   "sqlite3",
+	db.DriverPostgres: "postgres",
+}
+
 type Migrate struct {
 	db *db.DB
+	// dir of the embedded migrations, each driver has its own
+	dir string
 }
 
 func NewMigrate(db *db.DB) *Migrate {
 	goose.SetBaseFS(migrations.Migrations)
 	goose.SetTableName(MigrationTable)
+	goose.SetLogger(logger.GooseLogger{})
 
-	if err := goose.SetDialect("sqlite3"); err != nil {
-		logger.Error.Fatal(err)
+	if err := goose.SetDialect(dialects[db.Driver]); err != nil {
+		logger.Fatal("migration dialect", "error", err)
 	}
 
-	return &Migrate{db: db}
+	return &Migrate{
+		db:  db,
+		dir: db.Driver,
+	}
 }
 
 func (m *Migrate) Up() error {
-	err := goose.Up(m.db.DB, ".")
+	err := goose.Up(m.db.Write, m.dir)
 	if err != nil {
 		return fmt.Errorf("migrations failed: %w", err)
 	}
 	return nil
 }
+
+// Down rolls back the last applied migration.
+func (m *Migrate) Down() error {
+	err := goose.Down(m.db.Write, m.dir)
+	if err != nil {
+		return fmt.Errorf("migration rollback failed: %w", err)
+	}
+	return nil
+}
+
+// Status compares the version of the DB with the embedded migrations.
+func (m *Migrate) Status() (*Status, error) {
+	current, err := goose.GetDBVersion(m.db.Write)
+	if err != nil {
+		return nil, fmt.Errorf("get db version: %w", err)
+	}
+	all, err := goose.CollectMigrations(m.dir, 0, goose.MaxVersion)
+	if err != nil {
+		return nil, fmt.Errorf("collect migrations: %w", err)
+	}
+	status := &Status{
+		Current: current,
+	}
+	for _, el := range all {
+		if el.Version > current {
+			status.Pending++
+		}
+		if el.Version > status.Latest {
+			status.Latest = el.Version
+		}
+	}
+	return status, nil
+}
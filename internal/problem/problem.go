@@ -0,0 +1,133 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+)
+
+// ContentType of error responses, see RFC 7807
+const ContentType = "application/problem+json"
+
+// Stable codes of errors, clients must branch on them instead of the detail text
+const (
+	CodeInternal         = "internal_error"
+	CodeTimeout          = "timeout"
+	CodeNotFound         = "not_found"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeInvalidBody      = "invalid_body"
+	CodeInvalidParameter = "invalid_parameter"
+	CodeValidation       = "validation_failed"
+
+	CodeSessionMissing  = "session_missing"
+	CodeSessionNotFound = "session_not_found"
+	CodeSessionExpired  = "session_expired"
+	CodeSessionInvalid  = "session_invalid"
+	CodeCSRFFailed      = "csrf_failed"
+	CodeRateLimited     = "rate_limited"
+	CodeAdminToken      = "admin_token_invalid"
+)
+
+type FieldError struct {
+	// Path of the field in the request, as it is named in JSON
+	Field string `json:"field"`
+	// Name of the failed validation rule
+	Rule string `json:"rule"`
+	// Parameter of the rule, e.g. the maximum length
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Problem is the only model of error responses.
+// Type is always about:blank, so Title is the HTTP status text and Code identifies the error.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Code      string       `json:"code"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"requestId,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+func New(status int, code, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+// Internal hides the cause, which must be logged by the caller.
+func Internal() *Problem {
+	return New(http.StatusInternalServerError, CodeInternal, "")
+}
+
+// Validation lists the failed rules of validator.ValidationErrors, other errors are reported as the invalid body.
+func Validation(err error) *Problem {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return New(http.StatusBadRequest, CodeInvalidBody, err.Error())
+	}
+	p := New(http.StatusBadRequest, CodeValidation, "Request validation failed")
+	p.Errors = make([]FieldError, 0, len(errs))
+	for _, el := range errs {
+		p.Errors = append(p.Errors, FieldError{
+			Field:   fieldPath(el.Namespace()),
+			Rule:    el.Tag(),
+			Param:   el.Param(),
+			Message: fieldMessage(el),
+		})
+	}
+	return p
+}
+
+func Write(w http.ResponseWriter, r *http.Request, p *Problem) {
+	p.Instance = r.URL.Path
+	p.RequestID = chiMiddleware.GetReqID(r.Context())
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	err := json.NewEncoder(w).Encode(p)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "problem.Write() Encode", "error", err)
+	}
+}
+
+// fieldPath removes the name of the root struct: "RegisterDTO.password" -> "password".
+func fieldPath(namespace string) string {
+	_, path, ok := strings.Cut(namespace, ".")
+	if !ok {
+		return namespace
+	}
+	return path
+}
+func fieldMessage(err validator.FieldError) string {
+	switch err.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return fmt.Sprintf("must be at least %s", err.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", err.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", err.Param())
+	case "url":
+		return "must be a valid URL"
+	case "email":
+		return "must be a valid email"
+	case "uuid":
+		return "must be a valid UUID"
+	}
+	return fmt.Sprintf("failed on the %q rule", err.Tag())
+}
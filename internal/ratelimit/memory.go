@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	sweepInterval = time.Minute
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	period  time.Duration
+}
+
+// Memory is a Store of token buckets in the process memory.
+type Memory struct {
+	mutex     sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (m *Memory) Take(_ context.Context, key string, limit Limit) (*Result, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.result(key, limit, true), nil
+}
+func (m *Memory) Peek(_ context.Context, key string, limit Limit) (*Result, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.result(key, limit, false), nil
+}
+
+// result refills the bucket of the key and takes a token if it's allowed.
+func (m *Memory) result(key string, limit Limit, take bool) *Result {
+	now := m.now()
+	m.sweep(now)
+
+	capacity := float64(limit.Requests)
+	rate := capacity / limit.Period.Seconds()
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		m.buckets[key] = b
+	}
+	b.period = limit.Period
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	res := &Result{}
+	if b.tokens >= 1 {
+		if take {
+			b.tokens--
+		}
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+	res.Remaining = int(b.tokens)
+	res.Reset = seconds((capacity - b.tokens) / rate)
+	return res
+}
+
+// sweep removes buckets, which are full again, so memory doesn't grow with the number of clients.
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+	for key, b := range m.buckets {
+		if now.Sub(b.updated) >= b.period {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(math.Ceil(value * float64(time.Second)))
+}
@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// clock is the time of the store, tests move it by hand
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newTestMemory() (*Memory, *clock) {
+	c := &clock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := NewMemory()
+	m.now = c.Now
+	return m, c
+}
+
+func take(t *testing.T, m *Memory, key string, limit Limit) *Result {
+	t.Helper()
+
+	res, err := m.Take(context.Background(), key, limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestMemoryTake(t *testing.T) {
+	m, c := newTestMemory()
+	limit := Limit{Requests: 2, Period: 10 * time.Second}
+
+	for i, remaining := range []int{1, 0} {
+		res := take(t, m, "a", limit)
+		if !res.Allowed || res.Remaining != remaining {
+			t.Fatalf("request %d: unexpected result %+v", i, res)
+		}
+	}
+	res := take(t, m, "a", limit)
+	if res.Allowed || res.RetryAfter != 5*time.Second || res.Reset != 10*time.Second {
+		t.Fatalf("unexpected result of the empty bucket %+v", res)
+	}
+	// Buckets of other keys are not affected
+	res = take(t, m, "b", limit)
+	if !res.Allowed {
+		t.Fatalf("the bucket of another key is empty %+v", res)
+	}
+
+	// One token is back in 5 seconds
+	c.now = c.now.Add(4 * time.Second)
+	res = take(t, m, "a", limit)
+	if res.Allowed || res.RetryAfter != time.Second {
+		t.Fatalf("unexpected result before the refill %+v", res)
+	}
+	c.now = c.now.Add(time.Second)
+	res = take(t, m, "a", limit)
+	if !res.Allowed || res.Remaining != 0 {
+		t.Fatalf("unexpected result after the refill %+v", res)
+	}
+
+	// The bucket doesn't grow over the capacity
+	c.now = c.now.Add(time.Hour)
+	take(t, m, "a", limit)
+	res = take(t, m, "a", limit)
+	if !res.Allowed || res.Remaining != 0 {
+		t.Fatalf("unexpected result of the full bucket %+v", res)
+	}
+}
+
+func TestMemoryPeek(t *testing.T) {
+	m, _ := newTestMemory()
+	limit := Limit{Requests: 1, Period: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		res, err := m.Peek(context.Background(), "a", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Allowed || res.Remaining != 1 {
+			t.Fatalf("peek %d takes a token %+v", i, res)
+		}
+	}
+	take(t, m, "a", limit)
+	res, err := m.Peek(context.Background(), "a", limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Allowed || res.RetryAfter != time.Minute {
+		t.Fatalf("unexpected peek of the empty bucket %+v", res)
+	}
+}
+
+func TestMemorySweep(t *testing.T) {
+	m, c := newTestMemory()
+	short := Limit{Requests: 1, Period: time.Minute}
+	long := Limit{Requests: 1, Period: time.Hour}
+
+	take(t, m, "short", short)
+	take(t, m, "long", long)
+	c.now = c.now.Add(sweepInterval)
+	take(t, m, "other", short)
+
+	// The short bucket is full again, the long one is not
+	if _, ok := m.buckets["short"]; ok {
+		t.Fatalf("the full bucket is kept")
+	}
+	if _, ok := m.buckets["long"]; !ok {
+		t.Fatalf("the bucket is removed before it's full")
+	}
+
+	// Sweeps are not run more often than sweepInterval
+	take(t, m, "short", short)
+	c.now = c.now.Add(sweepInterval - time.Second)
+	take(t, m, "other", short)
+	if _, ok := m.buckets["short"]; !ok {
+		t.Fatalf("the sweep is run before the interval")
+	}
+}
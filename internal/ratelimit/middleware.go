@@ -0,0 +1,164 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/HardDie/blog_engine/internal/problem"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+const (
+	// GroupDefault is used for routes without their own group
+	GroupDefault = "default"
+	// GroupLoginFailures limits failed logins per username and IP, see Allow
+	GroupLoginFailures = "login_failures"
+)
+
+type route struct {
+	method   string
+	template string
+}
+
+// Limiter applies limits of route groups. Authenticated requests are limited per user, anonymous ones per IP.
+type Limiter struct {
+	store  Store
+	routes map[route]string
+
+	// limits are replaced on config reload
+	mutex  sync.RWMutex
+	limits map[string]Limit
+}
+
+func New(store Store) *Limiter {
+	return &Limiter{
+		store:  store,
+		limits: make(map[string]Limit),
+		routes: make(map[route]string),
+	}
+}
+
+// Group sets the limit of the group, the zero limit disables it.
+func (l *Limiter) Group(name string, limit Limit) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.limits[name] = limit
+}
+
+// Route assigns the route to the group, the template must be the full path template of mux.
+func (l *Limiter) Route(method, template, group string) {
+	l.routes[route{method: method, template: template}] = group
+}
+
+// Middleware must be registered after the auth middleware, so the user is known.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		group := l.group(r)
+		l.mutex.RLock()
+		limit := l.limits[group]
+		l.mutex.RUnlock()
+		if limit.Disabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := group + ":ip:" + ClientIP(r)
+		if userID := utils.GetOptionalUserIDFromContext(r.Context()); userID != 0 {
+			key = group + ":user:" + strconv.FormatInt(userID, 10)
+		}
+		res, err := l.store.Take(r.Context(), key, limit)
+		if err != nil {
+			// The limiter must not take the service down
+			slog.ErrorContext(r.Context(), "Limiter.Middleware() Take", "error", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !write(w, r, limit, res) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Allow checks the bucket of failures of the key without taking a token. If the bucket is empty,
+// the 429 response is written and false is returned. Tokens are taken by Fail, so only failed attempts are limited.
+// The key is chosen by the caller, e.g. failed logins are counted per username and IP.
+func (l *Limiter) Allow(w http.ResponseWriter, r *http.Request, group, key string) bool {
+	l.mutex.RLock()
+	limit := l.limits[group]
+	l.mutex.RUnlock()
+	if limit.Disabled() {
+		return true
+	}
+
+	res, err := l.store.Peek(r.Context(), group+":"+key, limit)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Limiter.Allow() Peek", "error", err)
+		return true
+	}
+	return write(w, r, limit, res)
+}
+
+// Fail takes a token from the bucket of failures of the key.
+func (l *Limiter) Fail(ctx context.Context, group, key string) {
+	l.mutex.RLock()
+	limit := l.limits[group]
+	l.mutex.RUnlock()
+	if limit.Disabled() {
+		return
+	}
+
+	_, err := l.store.Take(ctx, group+":"+key, limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "Limiter.Fail() Take", "error", err)
+	}
+}
+
+func (l *Limiter) group(r *http.Request) string {
+	current := mux.CurrentRoute(r)
+	if current == nil {
+		return GroupDefault
+	}
+	template, err := current.GetPathTemplate()
+	if err != nil {
+		return GroupDefault
+	}
+	if group, ok := l.routes[route{method: r.Method, template: template}]; ok {
+		return group
+	}
+	return GroupDefault
+}
+
+// write sets the RateLimit headers and writes the 429 response if the request is not allowed.
+func write(w http.ResponseWriter, r *http.Request, limit Limit, res *Result) bool {
+	w.Header().Set("RateLimit-Policy", limit.Policy())
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	w.Header().Set("RateLimit-Reset", ceilSeconds(res.Reset))
+	if !res.Allowed {
+		w.Header().Set("Retry-After", ceilSeconds(res.RetryAfter))
+		problem.Write(w, r, problem.New(http.StatusTooManyRequests, problem.CodeRateLimited, "Too many requests, retry later"))
+		return false
+	}
+	return true
+}
+
+// ClientIP is the address of RemoteAddr without the port, it is replaced by middleware.RealIP for requests from trusted proxies.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
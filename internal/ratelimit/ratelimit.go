@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit allows Requests per Period, with bursts up to Requests.
+// The zero Limit disables limiting.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseLimit parses "requests/period", e.g. "10/1m". An empty string or "0" disables the limit.
+func ParseLimit(value string) (Limit, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return Limit{}, nil
+	}
+	requestsStr, periodStr, ok := strings.Cut(value, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("ratelimit.ParseLimit() bad format %q, expected requests/period", value)
+	}
+	requests, err := strconv.Atoi(requestsStr)
+	if err != nil || requests < 0 {
+		return Limit{}, fmt.Errorf("ratelimit.ParseLimit() bad requests %q", requestsStr)
+	}
+	period, err := time.ParseDuration(periodStr)
+	if err != nil || period <= 0 {
+		return Limit{}, fmt.Errorf("ratelimit.ParseLimit() bad period %q", periodStr)
+	}
+	return Limit{Requests: requests, Period: period}, nil
+}
+
+func (l Limit) Disabled() bool {
+	return l.Requests == 0
+}
+
+// Policy formats the limit for the RateLimit-Policy header.
+func (l Limit) Policy() string {
+	return fmt.Sprintf("%d;w=%d", l.Requests, int64(l.Period/time.Second))
+}
+
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next token, if the request is not allowed
+	RetryAfter time.Duration
+}
+
+// Store keeps token buckets. The memory store is used by a single instance,
+// a shared backend must implement the same interface to limit a cluster.
+type Store interface {
+	// Take removes a token from the bucket of the key
+	Take(ctx context.Context, key string, limit Limit) (*Result, error)
+	// Peek reports the state of the bucket without taking a token
+	Peek(ctx context.Context, key string, limit Limit) (*Result, error)
+}
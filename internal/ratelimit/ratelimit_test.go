@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	for value, expected := range map[string]Limit{
+		"":       {},
+		"0":      {},
+		" 10/1m": {Requests: 10, Period: time.Minute},
+		"5/15m":  {Requests: 5, Period: 15 * time.Minute},
+		"0/1s":   {Period: time.Second},
+	} {
+		limit, err := ParseLimit(value)
+		if err != nil {
+			t.Errorf("%q: %v", value, err)
+			continue
+		}
+		if limit != expected {
+			t.Errorf("%q: expected %+v, got %+v", value, expected, limit)
+		}
+	}
+
+	for _, value := range []string{"10", "a/1m", "-1/1m", "10/", "10/1", "10/0s", "10/-1m"} {
+		_, err := ParseLimit(value)
+		if err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
+
+func TestLimitPolicy(t *testing.T) {
+	limit := Limit{Requests: 10, Period: time.Minute}
+	if limit.Disabled() {
+		t.Fatalf("the limit is disabled")
+	}
+	if policy := limit.Policy(); policy != "10;w=60" {
+		t.Fatalf("unexpected policy %q", policy)
+	}
+	if !(Limit{}).Disabled() {
+		t.Fatalf("the zero limit is enabled")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/gob"
-	"errors"
 	"fmt"
 	"time"
 
@@ -12,16 +11,15 @@ import (
 
 	"github.com/HardDie/blog_engine/internal/boltdb"
 	"github.com/HardDie/blog_engine/internal/models"
-)
-
-var (
-	ErrorNotFound = errors.New("session not found")
+	repositorySession "github.com/HardDie/blog_engine/internal/repository/session"
 )
 
 type Session struct {
 	db *boltdb.DB
 }
 
+var _ repositorySession.Store = (*Session)(nil)
+
 func New(db *boltdb.DB) *Session {
 	return &Session{
 		db: db,
@@ -34,31 +32,17 @@ func (s *Session) CreateOrUpdate(_ context.Context, userID int64, sessionHash st
 		SessionHash: sessionHash,
 		CreatedAt:   time.Now(),
 	}
-
-	var buf bytes.Buffer
-	err := gob.NewEncoder(&buf).Encode(ses)
-	if err != nil {
-		return nil, fmt.Errorf("Session.CreateOrUpdate() Encode: %w", err)
-	}
-
-	err = s.db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte(boltdb.BucketSessions))
-		if b == nil {
-			return fmt.Errorf("Session.CreateOrUpdate() Bucket: b == nil")
-		}
-		err := b.Put([]byte(sessionHash), buf.Bytes())
-		if err != nil {
-			return fmt.Errorf("Session.CreateOrUpdate() Put: %w", err)
-		}
-		return nil
-	})
+	err := s.put("Session.CreateOrUpdate()", &ses)
 	if err != nil {
 		return nil, err
 	}
-
 	return &ses, nil
 }
 
+func (s *Session) Put(_ context.Context, ses *models.Session) error {
+	return s.put("Session.Put()", ses)
+}
+
 func (s *Session) DeleteBySessionHash(_ context.Context, sessionHash string) error {
 	err := s.db.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(boltdb.BucketSessions))
@@ -86,7 +70,7 @@ func (s *Session) GetBySessionHash(_ context.Context, sessionHash string) (*mode
 		}
 		data := b.Get([]byte(sessionHash))
 		if data == nil {
-			return ErrorNotFound
+			return repositorySession.ErrorNotFound
 		}
 		err := gob.NewDecoder(bytes.NewReader(data)).Decode(&ses)
 		if err != nil {
@@ -99,3 +83,122 @@ func (s *Session) GetBySessionHash(_ context.Context, sessionHash string) (*mode
 	}
 	return &ses, nil
 }
+
+func (s *Session) CountCreatedAfter(_ context.Context, createdAfter time.Time) (int64, error) {
+	var count int64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(boltdb.BucketSessions))
+		if b == nil {
+			return fmt.Errorf("Session.CountCreatedAfter() Bucket: b == nil")
+		}
+		return b.ForEach(func(_, data []byte) error {
+			var ses models.Session
+			err := gob.NewDecoder(bytes.NewReader(data)).Decode(&ses)
+			if err != nil {
+				return fmt.Errorf("Session.CountCreatedAfter() Decode: %w", err)
+			}
+			if ses.CreatedAt.After(createdAfter) {
+				count++
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *Session) List(_ context.Context) ([]*models.Session, error) {
+	var list []*models.Session
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(boltdb.BucketSessions))
+		if b == nil {
+			return fmt.Errorf("Session.List() Bucket: b == nil")
+		}
+		return b.ForEach(func(_, data []byte) error {
+			var ses models.Session
+			err := gob.NewDecoder(bytes.NewReader(data)).Decode(&ses)
+			if err != nil {
+				return fmt.Errorf("Session.List() Decode: %w", err)
+			}
+			list = append(list, &ses)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *Session) DeleteByUserID(_ context.Context, userID int64) (int64, error) {
+	return s.deleteWhere("Session.DeleteByUserID()", func(ses *models.Session) bool {
+		return ses.UserID == userID
+	})
+}
+
+func (s *Session) DeleteCreatedBefore(_ context.Context, createdBefore time.Time) (int64, error) {
+	return s.deleteWhere("Session.DeleteCreatedBefore()", func(ses *models.Session) bool {
+		return ses.CreatedAt.Before(createdBefore)
+	})
+}
+
+func (s *Session) put(method string, ses *models.Session) error {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(ses)
+	if err != nil {
+		return fmt.Errorf("%s Encode: %w", method, err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(boltdb.BucketSessions))
+		if b == nil {
+			return fmt.Errorf("%s Bucket: b == nil", method)
+		}
+		err := b.Put([]byte(ses.SessionHash), buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("%s Put: %w", method, err)
+		}
+		return nil
+	})
+}
+
+// deleteWhere scans all sessions, because they are keyed only by the hash.
+func (s *Session) deleteWhere(method string, match func(ses *models.Session) bool) (int64, error) {
+	var count int64
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(boltdb.BucketSessions))
+		if b == nil {
+			return fmt.Errorf("%s Bucket: b == nil", method)
+		}
+		var keys [][]byte
+		err := b.ForEach(func(key, data []byte) error {
+			var ses models.Session
+			err := gob.NewDecoder(bytes.NewReader(data)).Decode(&ses)
+			if err != nil {
+				return fmt.Errorf("%s Decode: %w", method, err)
+			}
+			if match(&ses) {
+				// The key is valid only during the transaction, and the bucket can't be changed inside ForEach
+				keys = append(keys, append([]byte(nil), key...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			err = b.Delete(key)
+			if err != nil {
+				return fmt.Errorf("%s Delete: %w", method, err)
+			}
+		}
+		count = int64(len(keys))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,109 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/models"
+	repositorySession "github.com/HardDie/blog_engine/internal/repository/session"
+)
+
+// Session keeps sessions in a map of the process, they are lost on restart. It is meant for tests.
+type Session struct {
+	mu       sync.RWMutex
+	sessions map[string]models.Session
+}
+
+var _ repositorySession.Store = (*Session)(nil)
+
+func New() *Session {
+	return &Session{
+		sessions: make(map[string]models.Session),
+	}
+}
+
+func (s *Session) CreateOrUpdate(_ context.Context, userID int64, sessionHash string) (*models.Session, error) {
+	ses := models.Session{
+		UserID:      userID,
+		SessionHash: sessionHash,
+		CreatedAt:   time.Now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[sessionHash] = ses
+	return &ses, nil
+}
+
+func (s *Session) DeleteBySessionHash(_ context.Context, sessionHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionHash)
+	return nil
+}
+
+func (s *Session) GetBySessionHash(_ context.Context, sessionHash string) (*models.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ses, ok := s.sessions[sessionHash]
+	if !ok {
+		return nil, repositorySession.ErrorNotFound
+	}
+	return &ses, nil
+}
+
+func (s *Session) CountCreatedAfter(_ context.Context, createdAfter time.Time) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var count int64
+	for _, ses := range s.sessions {
+		if ses.CreatedAt.After(createdAfter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *Session) DeleteByUserID(_ context.Context, userID int64) (int64, error) {
+	return s.deleteWhere(func(ses *models.Session) bool {
+		return ses.UserID == userID
+	}), nil
+}
+
+func (s *Session) DeleteCreatedBefore(_ context.Context, createdBefore time.Time) (int64, error) {
+	return s.deleteWhere(func(ses *models.Session) bool {
+		return ses.CreatedAt.Before(createdBefore)
+	}), nil
+}
+
+func (s *Session) List(_ context.Context) ([]*models.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]*models.Session, 0, len(s.sessions))
+	for _, ses := range s.sessions {
+		ses := ses
+		list = append(list, &ses)
+	}
+	return list, nil
+}
+
+func (s *Session) Put(_ context.Context, ses *models.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[ses.SessionHash] = *ses
+	return nil
+}
+
+func (s *Session) deleteWhere(match func(ses *models.Session) bool) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var count int64
+	for hash, ses := range s.sessions {
+		if match(&ses) {
+			delete(s.sessions, hash)
+			count++
+		}
+	}
+	return count
+}
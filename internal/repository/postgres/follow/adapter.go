@@ -0,0 +1,44 @@
+package follow
+
+import (
+	"context"
+	"database/sql"
+
+	repositoryFollow "github.com/HardDie/blog_engine/internal/repository/sqlite/follow"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositoryFollow.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) Counts(ctx context.Context, userID int64) (*repositoryFollow.CountsRow, error) {
+	row, err := a.q.Counts(ctx, userID)
+	return (*repositoryFollow.CountsRow)(row), err
+}
+func (a *Adapter) Follow(ctx context.Context, arg repositoryFollow.FollowParams) (int64, error) {
+	return a.q.Follow(ctx, FollowParams(arg))
+}
+
+// IsFollowing returns 1 like EXISTS of SQLite.
+func (a *Adapter) IsFollowing(ctx context.Context, arg repositoryFollow.IsFollowingParams) (int64, error) {
+	exists, err := a.q.IsFollowing(ctx, IsFollowingParams(arg))
+	if err != nil || !exists {
+		return 0, err
+	}
+	return 1, nil
+}
+func (a *Adapter) Unfollow(ctx context.Context, arg repositoryFollow.UnfollowParams) (int64, error) {
+	return a.q.Unfollow(ctx, UnfollowParams(arg))
+}
@@ -0,0 +1,118 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package follow
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.countsStmt, err = db.PrepareContext(ctx, counts); err != nil {
+		return nil, fmt.Errorf("error preparing query Counts: %w", err)
+	}
+	if q.followStmt, err = db.PrepareContext(ctx, follow); err != nil {
+		return nil, fmt.Errorf("error preparing query Follow: %w", err)
+	}
+	if q.isFollowingStmt, err = db.PrepareContext(ctx, isFollowing); err != nil {
+		return nil, fmt.Errorf("error preparing query IsFollowing: %w", err)
+	}
+	if q.unfollowStmt, err = db.PrepareContext(ctx, unfollow); err != nil {
+		return nil, fmt.Errorf("error preparing query Unfollow: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.countsStmt != nil {
+		if cerr := q.countsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countsStmt: %w", cerr)
+		}
+	}
+	if q.followStmt != nil {
+		if cerr := q.followStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing followStmt: %w", cerr)
+		}
+	}
+	if q.isFollowingStmt != nil {
+		if cerr := q.isFollowingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isFollowingStmt: %w", cerr)
+		}
+	}
+	if q.unfollowStmt != nil {
+		if cerr := q.unfollowStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing unfollowStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db              DBTX
+	tx              *sql.Tx
+	countsStmt      *sql.Stmt
+	followStmt      *sql.Stmt
+	isFollowingStmt *sql.Stmt
+	unfollowStmt    *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:              tx,
+		tx:              tx,
+		countsStmt:      q.countsStmt,
+		followStmt:      q.followStmt,
+		isFollowingStmt: q.isFollowingStmt,
+		unfollowStmt:    q.unfollowStmt,
+	}
+}
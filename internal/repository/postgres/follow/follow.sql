@@ -0,0 +1,21 @@
+-- name: Follow :execrows
+INSERT INTO follows (follower_id, followee_id)
+VALUES ($1, $2)
+ON CONFLICT (follower_id, followee_id) DO NOTHING;
+
+-- name: Unfollow :execrows
+DELETE FROM follows
+WHERE follower_id = $1
+  AND followee_id = $2;
+
+-- name: Counts :one
+SELECT (SELECT count(*) FROM follows f JOIN users u ON u.id = f.follower_id
+        WHERE f.followee_id = sqlc.arg(user_id) AND u.deleted_at IS NULL) AS followers_count,
+       (SELECT count(*) FROM follows f JOIN users u ON u.id = f.followee_id
+        WHERE f.follower_id = sqlc.arg(user_id) AND u.deleted_at IS NULL) AS following_count;
+
+-- name: IsFollowing :one
+SELECT EXISTS(SELECT 1
+              FROM follows
+              WHERE follower_id = $1
+                AND followee_id = $2);
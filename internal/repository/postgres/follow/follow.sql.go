@@ -0,0 +1,108 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: follow.sql
+
+package follow
+
+import (
+	"context"
+)
+
+const counts = `-- name: Counts :one
+SELECT (SELECT count(*) FROM follows f JOIN users u ON u.id = f.follower_id
+        WHERE f.followee_id = $1 AND u.deleted_at IS NULL) AS followers_count,
+       (SELECT count(*) FROM follows f JOIN users u ON u.id = f.followee_id
+        WHERE f.follower_id = $1 AND u.deleted_at IS NULL) AS following_count
+`
+
+type CountsRow struct {
+	FollowersCount int64 `json:"followersCount"`
+	FollowingCount int64 `json:"followingCount"`
+}
+
+// Counts
+//
+//	SELECT (SELECT count(*) FROM follows f JOIN users u ON u.id = f.follower_id
+//	        WHERE f.followee_id = $1 AND u.deleted_at IS NULL) AS followers_count,
+//	       (SELECT count(*) FROM follows f JOIN users u ON u.id = f.followee_id
+//	        WHERE f.follower_id = $1 AND u.deleted_at IS NULL) AS following_count
+func (q *Queries) Counts(ctx context.Context, userID int64) (*CountsRow, error) {
+	row := q.queryRow(ctx, q.countsStmt, counts, userID)
+	var i CountsRow
+	err := row.Scan(&i.FollowersCount, &i.FollowingCount)
+	return &i, err
+}
+
+const follow = `-- name: Follow :execrows
+INSERT INTO follows (follower_id, followee_id)
+VALUES ($1, $2)
+ON CONFLICT (follower_id, followee_id) DO NOTHING
+`
+
+type FollowParams struct {
+	FollowerID int64 `json:"followerId"`
+	FolloweeID int64 `json:"followeeId"`
+}
+
+// Follow
+//
+//	INSERT INTO follows (follower_id, followee_id)
+//	VALUES ($1, $2)
+//	ON CONFLICT (follower_id, followee_id) DO NOTHING
+func (q *Queries) Follow(ctx context.Context, arg FollowParams) (int64, error) {
+	result, err := q.exec(ctx, q.followStmt, follow, arg.FollowerID, arg.FolloweeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const isFollowing = `-- name: IsFollowing :one
+SELECT EXISTS(SELECT 1
+              FROM follows
+              WHERE follower_id = $1
+                AND followee_id = $2)
+`
+
+type IsFollowingParams struct {
+	FollowerID int64 `json:"followerId"`
+	FolloweeID int64 `json:"followeeId"`
+}
+
+// IsFollowing
+//
+//	SELECT EXISTS(SELECT 1
+//	              FROM follows
+//	              WHERE follower_id = $1
+//	                AND followee_id = $2)
+func (q *Queries) IsFollowing(ctx context.Context, arg IsFollowingParams) (bool, error) {
+	row := q.queryRow(ctx, q.isFollowingStmt, isFollowing, arg.FollowerID, arg.FolloweeID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const unfollow = `-- name: Unfollow :execrows
+DELETE FROM follows
+WHERE follower_id = $1
+  AND followee_id = $2
+`
+
+type UnfollowParams struct {
+	FollowerID int64 `json:"followerId"`
+	FolloweeID int64 `json:"followeeId"`
+}
+
+// Unfollow
+//
+//	DELETE FROM follows
+//	WHERE follower_id = $1
+//	  AND followee_id = $2
+func (q *Queries) Unfollow(ctx context.Context, arg UnfollowParams) (int64, error) {
+	result, err := q.exec(ctx, q.unfollowStmt, unfollow, arg.FollowerID, arg.FolloweeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
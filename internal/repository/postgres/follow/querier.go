@@ -0,0 +1,40 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package follow
+
+import (
+	"context"
+)
+
+type Querier interface {
+	//Counts
+	//
+	//  SELECT (SELECT count(*) FROM follows f JOIN users u ON u.id = f.follower_id
+	//          WHERE f.followee_id = $1 AND u.deleted_at IS NULL) AS followers_count,
+	//         (SELECT count(*) FROM follows f JOIN users u ON u.id = f.followee_id
+	//          WHERE f.follower_id = $1 AND u.deleted_at IS NULL) AS following_count
+	Counts(ctx context.Context, userID int64) (*CountsRow, error)
+	//Follow
+	//
+	//  INSERT INTO follows (follower_id, followee_id)
+	//  VALUES ($1, $2)
+	//  ON CONFLICT (follower_id, followee_id) DO NOTHING
+	Follow(ctx context.Context, arg FollowParams) (int64, error)
+	//IsFollowing
+	//
+	//  SELECT EXISTS(SELECT 1
+	//                FROM follows
+	//                WHERE follower_id = $1
+	//                  AND followee_id = $2)
+	IsFollowing(ctx context.Context, arg IsFollowingParams) (bool, error)
+	//Unfollow
+	//
+	//  DELETE FROM follows
+	//  WHERE follower_id = $1
+	//    AND followee_id = $2
+	Unfollow(ctx context.Context, arg UnfollowParams) (int64, error)
+}
+
+var _ Querier = (*Queries)(nil)
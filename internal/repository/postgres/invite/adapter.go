@@ -0,0 +1,59 @@
+package invite
+
+import (
+	"context"
+	"database/sql"
+
+	repositoryInvite "github.com/HardDie/blog_engine/internal/repository/sqlite/invite"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositoryInvite.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) Activate(ctx context.Context, id int64) (*repositoryInvite.Invite, error) {
+	row, err := a.q.Activate(ctx, id)
+	return (*repositoryInvite.Invite)(row), err
+}
+func (a *Adapter) CreateOrUpdate(ctx context.Context, arg repositoryInvite.CreateOrUpdateParams) (*repositoryInvite.Invite, error) {
+	row, err := a.q.CreateOrUpdate(ctx, CreateOrUpdateParams(arg))
+	return (*repositoryInvite.Invite)(row), err
+}
+func (a *Adapter) Delete(ctx context.Context, id int64) error {
+	return a.q.Delete(ctx, id)
+}
+func (a *Adapter) GetActiveByUserID(ctx context.Context, id int64) (*repositoryInvite.Invite, error) {
+	row, err := a.q.GetActiveByUserID(ctx, id)
+	return (*repositoryInvite.Invite)(row), err
+}
+func (a *Adapter) GetAllByUserID(ctx context.Context, userID int64) ([]*repositoryInvite.Invite, error) {
+	rows, err := a.q.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositoryInvite.Invite, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, (*repositoryInvite.Invite)(row))
+	}
+	return result, nil
+}
+func (a *Adapter) GetByID(ctx context.Context, id int64) (*repositoryInvite.Invite, error) {
+	row, err := a.q.GetByID(ctx, id)
+	return (*repositoryInvite.Invite)(row), err
+}
+func (a *Adapter) GetByInviteHash(ctx context.Context, inviteHash string) (*repositoryInvite.Invite, error) {
+	row, err := a.q.GetByInviteHash(ctx, inviteHash)
+	return (*repositoryInvite.Invite)(row), err
+}
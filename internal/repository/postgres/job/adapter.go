@@ -0,0 +1,51 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+
+	repositoryJob "github.com/HardDie/blog_engine/internal/repository/sqlite/job"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositoryJob.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) Claim(ctx context.Context) (*repositoryJob.Job, error) {
+	row, err := a.q.Claim(ctx)
+	return (*repositoryJob.Job)(row), err
+}
+func (a *Adapter) CountPendingByType(ctx context.Context, type_ string) (int64, error) {
+	return a.q.CountPendingByType(ctx, type_)
+}
+func (a *Adapter) Create(ctx context.Context, arg repositoryJob.CreateParams) (*repositoryJob.Job, error) {
+	row, err := a.q.Create(ctx, CreateParams(arg))
+	return (*repositoryJob.Job)(row), err
+}
+func (a *Adapter) Delete(ctx context.Context, id int64) error {
+	return a.q.Delete(ctx, id)
+}
+func (a *Adapter) MarkDead(ctx context.Context, arg repositoryJob.MarkDeadParams) error {
+	return a.q.MarkDead(ctx, MarkDeadParams(arg))
+}
+func (a *Adapter) PurgeDead(ctx context.Context, age string) (int64, error) {
+	return a.q.PurgeDead(ctx, age)
+}
+func (a *Adapter) ResetRunning(ctx context.Context) (int64, error) {
+	return a.q.ResetRunning(ctx)
+}
+func (a *Adapter) Retry(ctx context.Context, arg repositoryJob.RetryParams) error {
+	return a.q.Retry(ctx, RetryParams(arg))
+}
@@ -0,0 +1,158 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package job
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.claimStmt, err = db.PrepareContext(ctx, claim); err != nil {
+		return nil, fmt.Errorf("error preparing query Claim: %w", err)
+	}
+	if q.countPendingByTypeStmt, err = db.PrepareContext(ctx, countPendingByType); err != nil {
+		return nil, fmt.Errorf("error preparing query CountPendingByType: %w", err)
+	}
+	if q.createStmt, err = db.PrepareContext(ctx, create); err != nil {
+		return nil, fmt.Errorf("error preparing query Create: %w", err)
+	}
+	if q.deleteStmt, err = db.PrepareContext(ctx, delete); err != nil {
+		return nil, fmt.Errorf("error preparing query Delete: %w", err)
+	}
+	if q.markDeadStmt, err = db.PrepareContext(ctx, markDead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkDead: %w", err)
+	}
+	if q.purgeDeadStmt, err = db.PrepareContext(ctx, purgeDead); err != nil {
+		return nil, fmt.Errorf("error preparing query PurgeDead: %w", err)
+	}
+	if q.resetRunningStmt, err = db.PrepareContext(ctx, resetRunning); err != nil {
+		return nil, fmt.Errorf("error preparing query ResetRunning: %w", err)
+	}
+	if q.retryStmt, err = db.PrepareContext(ctx, retry); err != nil {
+		return nil, fmt.Errorf("error preparing query Retry: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.claimStmt != nil {
+		if cerr := q.claimStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimStmt: %w", cerr)
+		}
+	}
+	if q.countPendingByTypeStmt != nil {
+		if cerr := q.countPendingByTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countPendingByTypeStmt: %w", cerr)
+		}
+	}
+	if q.createStmt != nil {
+		if cerr := q.createStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStmt: %w", cerr)
+		}
+	}
+	if q.deleteStmt != nil {
+		if cerr := q.deleteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStmt: %w", cerr)
+		}
+	}
+	if q.markDeadStmt != nil {
+		if cerr := q.markDeadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markDeadStmt: %w", cerr)
+		}
+	}
+	if q.purgeDeadStmt != nil {
+		if cerr := q.purgeDeadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing purgeDeadStmt: %w", cerr)
+		}
+	}
+	if q.resetRunningStmt != nil {
+		if cerr := q.resetRunningStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resetRunningStmt: %w", cerr)
+		}
+	}
+	if q.retryStmt != nil {
+		if cerr := q.retryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing retryStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db                     DBTX
+	tx                     *sql.Tx
+	claimStmt              *sql.Stmt
+	countPendingByTypeStmt *sql.Stmt
+	createStmt             *sql.Stmt
+	deleteStmt             *sql.Stmt
+	markDeadStmt           *sql.Stmt
+	purgeDeadStmt          *sql.Stmt
+	resetRunningStmt       *sql.Stmt
+	retryStmt              *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:                     tx,
+		tx:                     tx,
+		claimStmt:              q.claimStmt,
+		countPendingByTypeStmt: q.countPendingByTypeStmt,
+		createStmt:             q.createStmt,
+		deleteStmt:             q.deleteStmt,
+		markDeadStmt:           q.markDeadStmt,
+		purgeDeadStmt:          q.purgeDeadStmt,
+		resetRunningStmt:       q.resetRunningStmt,
+		retryStmt:              q.retryStmt,
+	}
+}
@@ -0,0 +1,47 @@
+-- name: Create :one
+INSERT INTO jobs (type, payload, max_attempts, run_at)
+VALUES (sqlc.arg(type), sqlc.arg(payload), sqlc.arg(max_attempts), now() + CAST(CAST(sqlc.arg(delay) AS text) AS interval))
+RETURNING *;
+
+-- name: Claim :one
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, updated_at = now()
+WHERE id = (SELECT id
+            FROM jobs
+            WHERE status = 'pending'
+              AND run_at <= now()
+            ORDER BY run_at, id
+            LIMIT 1
+            FOR UPDATE SKIP LOCKED)
+RETURNING *;
+
+-- name: Delete :exec
+DELETE FROM jobs
+WHERE id = $1;
+
+-- name: Retry :exec
+UPDATE jobs
+SET status = 'pending', last_error = sqlc.arg(last_error),
+    run_at = now() + CAST(CAST(sqlc.arg(delay) AS text) AS interval), updated_at = now()
+WHERE id = sqlc.arg(id);
+
+-- name: MarkDead :exec
+UPDATE jobs
+SET status = 'dead', last_error = $1, updated_at = now()
+WHERE id = $2;
+
+-- name: ResetRunning :execrows
+UPDATE jobs
+SET status = 'pending', updated_at = now()
+WHERE status = 'running';
+
+-- name: CountPendingByType :one
+SELECT count(*)
+FROM jobs
+WHERE type = $1
+  AND status IN ('pending', 'running');
+
+-- name: PurgeDead :execrows
+DELETE FROM jobs
+WHERE status = 'dead'
+  AND updated_at < now() + CAST(CAST(sqlc.arg(age) AS text) AS interval);
@@ -0,0 +1,23 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package job
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Job struct {
+	ID          int64          `json:"id"`
+	Type        string         `json:"type"`
+	Payload     string         `json:"payload"`
+	Status      string         `json:"status"`
+	Attempts    int64          `json:"attempts"`
+	MaxAttempts int64          `json:"maxAttempts"`
+	RunAt       time.Time      `json:"runAt"`
+	LastError   sql.NullString `json:"lastError"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
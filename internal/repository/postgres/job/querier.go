@@ -0,0 +1,70 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package job
+
+import (
+	"context"
+)
+
+type Querier interface {
+	//Claim
+	//
+	//  UPDATE jobs
+	//  SET status = 'running', attempts = attempts + 1, updated_at = now()
+	//  WHERE id = (SELECT id
+	//              FROM jobs
+	//              WHERE status = 'pending'
+	//                AND run_at <= now()
+	//              ORDER BY run_at, id
+	//              LIMIT 1
+	//              FOR UPDATE SKIP LOCKED)
+	//  RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at
+	Claim(ctx context.Context) (*Job, error)
+	//CountPendingByType
+	//
+	//  SELECT count(*)
+	//  FROM jobs
+	//  WHERE type = $1
+	//    AND status IN ('pending', 'running')
+	CountPendingByType(ctx context.Context, type_ string) (int64, error)
+	//Create
+	//
+	//  INSERT INTO jobs (type, payload, max_attempts, run_at)
+	//  VALUES ($1, $2, $3, now() + CAST(CAST($4 AS text) AS interval))
+	//  RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at
+	Create(ctx context.Context, arg CreateParams) (*Job, error)
+	//Delete
+	//
+	//  DELETE FROM jobs
+	//  WHERE id = $1
+	Delete(ctx context.Context, id int64) error
+	//MarkDead
+	//
+	//  UPDATE jobs
+	//  SET status = 'dead', last_error = $1, updated_at = now()
+	//  WHERE id = $2
+	MarkDead(ctx context.Context, arg MarkDeadParams) error
+	//PurgeDead
+	//
+	//  DELETE FROM jobs
+	//  WHERE status = 'dead'
+	//    AND updated_at < now() + CAST(CAST($1 AS text) AS interval)
+	PurgeDead(ctx context.Context, age string) (int64, error)
+	//ResetRunning
+	//
+	//  UPDATE jobs
+	//  SET status = 'pending', updated_at = now()
+	//  WHERE status = 'running'
+	ResetRunning(ctx context.Context) (int64, error)
+	//Retry
+	//
+	//  UPDATE jobs
+	//  SET status = 'pending', last_error = $1,
+	//      run_at = now() + CAST(CAST($2 AS text) AS interval), updated_at = now()
+	//  WHERE id = $3
+	Retry(ctx context.Context, arg RetryParams) error
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/HardDie/blog_engine/internal/repository/postgres"
+	repositoryNotification "github.com/HardDie/blog_engine/internal/repository/sqlite/notification"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositoryNotification.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	return a.q.CountUnread(ctx, userID)
+}
+func (a *Adapter) Create(ctx context.Context, arg repositoryNotification.CreateParams) (*repositoryNotification.Notification, error) {
+	row, err := a.q.Create(ctx, CreateParams(arg))
+	return (*repositoryNotification.Notification)(row), err
+}
+func (a *Adapter) GetPreference(ctx context.Context, arg repositoryNotification.GetPreferenceParams) (*repositoryNotification.NotificationPreference, error) {
+	row, err := a.q.GetPreference(ctx, GetPreferenceParams(arg))
+	return (*repositoryNotification.NotificationPreference)(row), err
+}
+func (a *Adapter) List(ctx context.Context, arg repositoryNotification.ListParams) ([]*repositoryNotification.ListRow, error) {
+	rows, err := a.q.List(ctx, ListParams(arg))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositoryNotification.ListRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, &repositoryNotification.ListRow{
+			Notification: repositoryNotification.Notification(row.Notification),
+			Count:        row.Count,
+		})
+	}
+	return result, nil
+}
+func (a *Adapter) ListPreferences(ctx context.Context, userID int64) ([]*repositoryNotification.NotificationPreference, error) {
+	rows, err := a.q.ListPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositoryNotification.NotificationPreference, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, (*repositoryNotification.NotificationPreference)(row))
+	}
+	return result, nil
+}
+func (a *Adapter) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	return a.q.MarkAllRead(ctx, userID)
+}
+func (a *Adapter) MarkRead(ctx context.Context, arg repositoryNotification.MarkReadParams) (int64, error) {
+	return a.q.MarkRead(ctx, MarkReadParams{
+		UserID: arg.UserID,
+		Ids:    postgres.BigintArray(arg.Ids),
+	})
+}
+func (a *Adapter) UpsertPreference(ctx context.Context, arg repositoryNotification.UpsertPreferenceParams) error {
+	return a.q.UpsertPreference(ctx, UpsertPreferenceParams(arg))
+}
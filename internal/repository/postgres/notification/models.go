@@ -0,0 +1,27 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package notification
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Notification struct {
+	ID        int64         `json:"id"`
+	UserID    int64         `json:"userId"`
+	Type      string        `json:"type"`
+	ActorID   int64         `json:"actorId"`
+	PostID    sql.NullInt64 `json:"postId"`
+	ReadAt    sql.NullTime  `json:"readAt"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+type NotificationPreference struct {
+	UserID int64  `json:"userId"`
+	Type   string `json:"type"`
+	InApp  bool   `json:"inApp"`
+	Email  bool   `json:"email"`
+}
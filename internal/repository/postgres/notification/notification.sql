@@ -0,0 +1,49 @@
+-- name: Create :one
+INSERT INTO notifications (user_id, type, actor_id, post_id)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: List :many
+SELECT sqlc.embed(notifications), count(*) over()
+FROM notifications
+WHERE user_id = sqlc.arg(user_id)
+  AND CASE WHEN CAST(sqlc.arg(only_unread) AS boolean) IS TRUE THEN read_at IS NULL ELSE true END
+ORDER BY id DESC
+LIMIT CASE WHEN CAST(sqlc.arg('limit') AS bigint) > 0 THEN sqlc.arg('limit') ELSE 10 END
+OFFSET CAST(sqlc.arg('offset') AS bigint);
+
+-- name: CountUnread :one
+SELECT count(*)
+FROM notifications
+WHERE user_id = $1
+  AND read_at IS NULL;
+
+-- name: MarkRead :execrows
+UPDATE notifications
+SET read_at = now()
+WHERE user_id = sqlc.arg(user_id)
+  AND id = ANY(CAST(CAST(sqlc.arg(ids) AS text) AS bigint[]))
+  AND read_at IS NULL;
+
+-- name: MarkAllRead :execrows
+UPDATE notifications
+SET read_at = now()
+WHERE user_id = $1
+  AND read_at IS NULL;
+
+-- name: ListPreferences :many
+SELECT *
+FROM notification_preferences
+WHERE user_id = $1
+ORDER BY type;
+
+-- name: GetPreference :one
+SELECT *
+FROM notification_preferences
+WHERE user_id = $1
+  AND type = $2;
+
+-- name: UpsertPreference :exec
+INSERT INTO notification_preferences (user_id, type, in_app, email)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, type) DO UPDATE SET in_app = excluded.in_app, email = excluded.email;
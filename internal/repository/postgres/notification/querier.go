@@ -0,0 +1,72 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package notification
+
+import (
+	"context"
+)
+
+type Querier interface {
+	//CountUnread
+	//
+	//  SELECT count(*)
+	//  FROM notifications
+	//  WHERE user_id = $1
+	//    AND read_at IS NULL
+	CountUnread(ctx context.Context, userID int64) (int64, error)
+	//Create
+	//
+	//  INSERT INTO notifications (user_id, type, actor_id, post_id)
+	//  VALUES ($1, $2, $3, $4)
+	//  RETURNING id, user_id, type, actor_id, post_id, read_at, created_at
+	Create(ctx context.Context, arg CreateParams) (*Notification, error)
+	//GetPreference
+	//
+	//  SELECT user_id, type, in_app, email
+	//  FROM notification_preferences
+	//  WHERE user_id = $1
+	//    AND type = $2
+	GetPreference(ctx context.Context, arg GetPreferenceParams) (*NotificationPreference, error)
+	//List
+	//
+	//  SELECT notifications.id, notifications.user_id, notifications.type, notifications.actor_id, notifications.post_id, notifications.read_at, notifications.created_at, count(*) over()
+	//  FROM notifications
+	//  WHERE user_id = $1
+	//    AND CASE WHEN CAST($2 AS boolean) IS TRUE THEN read_at IS NULL ELSE true END
+	//  ORDER BY id DESC
+	//  LIMIT CASE WHEN CAST($4 AS bigint) > 0 THEN $4 ELSE 10 END
+	//  OFFSET CAST($3 AS bigint)
+	List(ctx context.Context, arg ListParams) ([]*ListRow, error)
+	//ListPreferences
+	//
+	//  SELECT user_id, type, in_app, email
+	//  FROM notification_preferences
+	//  WHERE user_id = $1
+	//  ORDER BY type
+	ListPreferences(ctx context.Context, userID int64) ([]*NotificationPreference, error)
+	//MarkAllRead
+	//
+	//  UPDATE notifications
+	//  SET read_at = now()
+	//  WHERE user_id = $1
+	//    AND read_at IS NULL
+	MarkAllRead(ctx context.Context, userID int64) (int64, error)
+	//MarkRead
+	//
+	//  UPDATE notifications
+	//  SET read_at = now()
+	//  WHERE user_id = $1
+	//    AND id = ANY(CAST(CAST($2 AS text) AS bigint[]))
+	//    AND read_at IS NULL
+	MarkRead(ctx context.Context, arg MarkReadParams) (int64, error)
+	//UpsertPreference
+	//
+	//  INSERT INTO notification_preferences (user_id, type, in_app, email)
+	//  VALUES ($1, $2, $3, $4)
+	//  ON CONFLICT (user_id, type) DO UPDATE SET in_app = excluded.in_app, email = excluded.email
+	UpsertPreference(ctx context.Context, arg UpsertPreferenceParams) error
+}
+
+var _ Querier = (*Queries)(nil)
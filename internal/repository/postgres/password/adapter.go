@@ -0,0 +1,54 @@
+package password
+
+import (
+	"context"
+	"database/sql"
+
+	repositoryPassword "github.com/HardDie/blog_engine/internal/repository/sqlite/password"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositoryPassword.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) Create(ctx context.Context, arg repositoryPassword.CreateParams) (*repositoryPassword.Password, error) {
+	row, err := a.q.Create(ctx, CreateParams(arg))
+	return (*repositoryPassword.Password)(row), err
+}
+func (a *Adapter) GetByUserID(ctx context.Context, userID int64) (*repositoryPassword.Password, error) {
+	row, err := a.q.GetByUserID(ctx, userID)
+	return (*repositoryPassword.Password)(row), err
+}
+func (a *Adapter) GetLoginFailure(ctx context.Context, arg repositoryPassword.GetLoginFailureParams) (*repositoryPassword.LoginFailure, error) {
+	row, err := a.q.GetLoginFailure(ctx, GetLoginFailureParams(arg))
+	return (*repositoryPassword.LoginFailure)(row), err
+}
+func (a *Adapter) IncreaseLoginFailures(ctx context.Context, arg repositoryPassword.IncreaseLoginFailuresParams) (*repositoryPassword.LoginFailure, error) {
+	row, err := a.q.IncreaseLoginFailures(ctx, IncreaseLoginFailuresParams(arg))
+	return (*repositoryPassword.LoginFailure)(row), err
+}
+func (a *Adapter) PurgeLoginFailures(ctx context.Context, age string) (int64, error) {
+	return a.q.PurgeLoginFailures(ctx, age)
+}
+func (a *Adapter) ResetAllLoginFailures(ctx context.Context, userID int64) error {
+	return a.q.ResetAllLoginFailures(ctx, userID)
+}
+func (a *Adapter) ResetLoginFailures(ctx context.Context, arg repositoryPassword.ResetLoginFailuresParams) error {
+	return a.q.ResetLoginFailures(ctx, ResetLoginFailuresParams(arg))
+}
+func (a *Adapter) Update(ctx context.Context, arg repositoryPassword.UpdateParams) (*repositoryPassword.Password, error) {
+	row, err := a.q.Update(ctx, UpdateParams(arg))
+	return (*repositoryPassword.Password)(row), err
+}
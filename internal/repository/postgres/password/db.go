@@ -0,0 +1,158 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package password
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.createStmt, err = db.PrepareContext(ctx, create); err != nil {
+		return nil, fmt.Errorf("error preparing query Create: %w", err)
+	}
+	if q.getByUserIDStmt, err = db.PrepareContext(ctx, getByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetByUserID: %w", err)
+	}
+	if q.getLoginFailureStmt, err = db.PrepareContext(ctx, getLoginFailure); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLoginFailure: %w", err)
+	}
+	if q.increaseLoginFailuresStmt, err = db.PrepareContext(ctx, increaseLoginFailures); err != nil {
+		return nil, fmt.Errorf("error preparing query IncreaseLoginFailures: %w", err)
+	}
+	if q.purgeLoginFailuresStmt, err = db.PrepareContext(ctx, purgeLoginFailures); err != nil {
+		return nil, fmt.Errorf("error preparing query PurgeLoginFailures: %w", err)
+	}
+	if q.resetAllLoginFailuresStmt, err = db.PrepareContext(ctx, resetAllLoginFailures); err != nil {
+		return nil, fmt.Errorf("error preparing query ResetAllLoginFailures: %w", err)
+	}
+	if q.resetLoginFailuresStmt, err = db.PrepareContext(ctx, resetLoginFailures); err != nil {
+		return nil, fmt.Errorf("error preparing query ResetLoginFailures: %w", err)
+	}
+	if q.updateStmt, err = db.PrepareContext(ctx, update); err != nil {
+		return nil, fmt.Errorf("error preparing query Update: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.createStmt != nil {
+		if cerr := q.createStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStmt: %w", cerr)
+		}
+	}
+	if q.getByUserIDStmt != nil {
+		if cerr := q.getByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.getLoginFailureStmt != nil {
+		if cerr := q.getLoginFailureStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLoginFailureStmt: %w", cerr)
+		}
+	}
+	if q.increaseLoginFailuresStmt != nil {
+		if cerr := q.increaseLoginFailuresStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing increaseLoginFailuresStmt: %w", cerr)
+		}
+	}
+	if q.purgeLoginFailuresStmt != nil {
+		if cerr := q.purgeLoginFailuresStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing purgeLoginFailuresStmt: %w", cerr)
+		}
+	}
+	if q.resetAllLoginFailuresStmt != nil {
+		if cerr := q.resetAllLoginFailuresStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resetAllLoginFailuresStmt: %w", cerr)
+		}
+	}
+	if q.resetLoginFailuresStmt != nil {
+		if cerr := q.resetLoginFailuresStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resetLoginFailuresStmt: %w", cerr)
+		}
+	}
+	if q.updateStmt != nil {
+		if cerr := q.updateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db                        DBTX
+	tx                        *sql.Tx
+	createStmt                *sql.Stmt
+	getByUserIDStmt           *sql.Stmt
+	getLoginFailureStmt       *sql.Stmt
+	increaseLoginFailuresStmt *sql.Stmt
+	purgeLoginFailuresStmt    *sql.Stmt
+	resetAllLoginFailuresStmt *sql.Stmt
+	resetLoginFailuresStmt    *sql.Stmt
+	updateStmt                *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:                        tx,
+		tx:                        tx,
+		createStmt:                q.createStmt,
+		getByUserIDStmt:           q.getByUserIDStmt,
+		getLoginFailureStmt:       q.getLoginFailureStmt,
+		increaseLoginFailuresStmt: q.increaseLoginFailuresStmt,
+		purgeLoginFailuresStmt:    q.purgeLoginFailuresStmt,
+		resetAllLoginFailuresStmt: q.resetAllLoginFailuresStmt,
+		resetLoginFailuresStmt:    q.resetLoginFailuresStmt,
+		updateStmt:                q.updateStmt,
+	}
+}
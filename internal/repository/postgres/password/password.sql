@@ -0,0 +1,42 @@
+-- name: Create :one
+INSERT INTO passwords (user_id, password_hash)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetByUserID :one
+SELECT *
+FROM passwords
+WHERE user_id = $1
+  AND deleted_at IS NULL;
+
+-- name: Update :one
+UPDATE passwords
+SET password_hash = $1, updated_at = now()
+WHERE id = $2
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: GetLoginFailure :one
+SELECT *
+FROM login_failures
+WHERE user_id = $1
+  AND ip = $2;
+
+-- name: IncreaseLoginFailures :one
+INSERT INTO login_failures (user_id, ip, attempts)
+VALUES ($1, $2, 1)
+ON CONFLICT (user_id, ip) DO UPDATE SET attempts = login_failures.attempts + 1, updated_at = now()
+RETURNING *;
+
+-- name: ResetLoginFailures :exec
+DELETE FROM login_failures
+WHERE user_id = $1
+  AND ip = $2;
+
+-- name: ResetAllLoginFailures :exec
+DELETE FROM login_failures
+WHERE user_id = $1;
+
+-- name: PurgeLoginFailures :execrows
+DELETE FROM login_failures
+WHERE updated_at < now() + CAST(CAST(sqlc.arg(age) AS text) AS interval);
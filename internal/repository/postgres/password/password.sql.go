@@ -0,0 +1,216 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: password.sql
+
+package password
+
+import (
+	"context"
+)
+
+const create = `-- name: Create :one
+INSERT INTO passwords (user_id, password_hash)
+VALUES ($1, $2)
+RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
+`
+
+type CreateParams struct {
+	UserID       int64  `json:"userId"`
+	PasswordHash string `json:"passwordHash"`
+}
+
+// Create
+//
+//	INSERT INTO passwords (user_id, password_hash)
+//	VALUES ($1, $2)
+//	RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
+func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Password, error) {
+	row := q.queryRow(ctx, q.createStmt, create, arg.UserID, arg.PasswordHash)
+	var i Password
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.BlockedAt,
+	)
+	return &i, err
+}
+
+const getByUserID = `-- name: GetByUserID :one
+SELECT id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
+FROM passwords
+WHERE user_id = $1
+  AND deleted_at IS NULL
+`
+
+// GetByUserID
+//
+//	SELECT id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
+//	FROM passwords
+//	WHERE user_id = $1
+//	  AND deleted_at IS NULL
+func (q *Queries) GetByUserID(ctx context.Context, userID int64) (*Password, error) {
+	row := q.queryRow(ctx, q.getByUserIDStmt, getByUserID, userID)
+	var i Password
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.BlockedAt,
+	)
+	return &i, err
+}
+
+const getLoginFailure = `-- name: GetLoginFailure :one
+SELECT user_id, ip, attempts, updated_at
+FROM login_failures
+WHERE user_id = $1
+  AND ip = $2
+`
+
+type GetLoginFailureParams struct {
+	UserID int64  `json:"userId"`
+	Ip     string `json:"ip"`
+}
+
+// GetLoginFailure
+//
+//	SELECT user_id, ip, attempts, updated_at
+//	FROM login_failures
+//	WHERE user_id = $1
+//	  AND ip = $2
+func (q *Queries) GetLoginFailure(ctx context.Context, arg GetLoginFailureParams) (*LoginFailure, error) {
+	row := q.queryRow(ctx, q.getLoginFailureStmt, getLoginFailure, arg.UserID, arg.Ip)
+	var i LoginFailure
+	err := row.Scan(
+		&i.UserID,
+		&i.Ip,
+		&i.Attempts,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const increaseLoginFailures = `-- name: IncreaseLoginFailures :one
+INSERT INTO login_failures (user_id, ip, attempts)
+VALUES ($1, $2, 1)
+ON CONFLICT (user_id, ip) DO UPDATE SET attempts = login_failures.attempts + 1, updated_at = now()
+RETURNING user_id, ip, attempts, updated_at
+`
+
+type IncreaseLoginFailuresParams struct {
+	UserID int64  `json:"userId"`
+	Ip     string `json:"ip"`
+}
+
+// IncreaseLoginFailures
+//
+//	INSERT INTO login_failures (user_id, ip, attempts)
+//	VALUES ($1, $2, 1)
+//	ON CONFLICT (user_id, ip) DO UPDATE SET attempts = login_failures.attempts + 1, updated_at = now()
+//	RETURNING user_id, ip, attempts, updated_at
+func (q *Queries) IncreaseLoginFailures(ctx context.Context, arg IncreaseLoginFailuresParams) (*LoginFailure, error) {
+	row := q.queryRow(ctx, q.increaseLoginFailuresStmt, increaseLoginFailures, arg.UserID, arg.Ip)
+	var i LoginFailure
+	err := row.Scan(
+		&i.UserID,
+		&i.Ip,
+		&i.Attempts,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const purgeLoginFailures = `-- name: PurgeLoginFailures :execrows
+DELETE FROM login_failures
+WHERE updated_at < now() + CAST(CAST($1 AS text) AS interval)
+`
+
+// PurgeLoginFailures
+//
+//	DELETE FROM login_failures
+//	WHERE updated_at < now() + CAST(CAST($1 AS text) AS interval)
+func (q *Queries) PurgeLoginFailures(ctx context.Context, age string) (int64, error) {
+	result, err := q.exec(ctx, q.purgeLoginFailuresStmt, purgeLoginFailures, age)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const resetAllLoginFailures = `-- name: ResetAllLoginFailures :exec
+DELETE FROM login_failures
+WHERE user_id = $1
+`
+
+// ResetAllLoginFailures
+//
+//	DELETE FROM login_failures
+//	WHERE user_id = $1
+func (q *Queries) ResetAllLoginFailures(ctx context.Context, userID int64) error {
+	_, err := q.exec(ctx, q.resetAllLoginFailuresStmt, resetAllLoginFailures, userID)
+	return err
+}
+
+const resetLoginFailures = `-- name: ResetLoginFailures :exec
+DELETE FROM login_failures
+WHERE user_id = $1
+  AND ip = $2
+`
+
+type ResetLoginFailuresParams struct {
+	UserID int64  `json:"userId"`
+	Ip     string `json:"ip"`
+}
+
+// ResetLoginFailures
+//
+//	DELETE FROM login_failures
+//	WHERE user_id = $1
+//	  AND ip = $2
+func (q *Queries) ResetLoginFailures(ctx context.Context, arg ResetLoginFailuresParams) error {
+	_, err := q.exec(ctx, q.resetLoginFailuresStmt, resetLoginFailures, arg.UserID, arg.Ip)
+	return err
+}
+
+const update = `-- name: Update :one
+UPDATE passwords
+SET password_hash = $1, updated_at = now()
+WHERE id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
+`
+
+type UpdateParams struct {
+	PasswordHash string `json:"passwordHash"`
+	ID           int64  `json:"id"`
+}
+
+// Update
+//
+//	UPDATE passwords
+//	SET password_hash = $1, updated_at = now()
+//	WHERE id = $2
+//	  AND deleted_at IS NULL
+//	RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
+func (q *Queries) Update(ctx context.Context, arg UpdateParams) (*Password, error) {
+	row := q.queryRow(ctx, q.updateStmt, update, arg.PasswordHash, arg.ID)
+	var i Password
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.BlockedAt,
+	)
+	return &i, err
+}
@@ -0,0 +1,65 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package password
+
+import (
+	"context"
+)
+
+type Querier interface {
+	//Create
+	//
+	//  INSERT INTO passwords (user_id, password_hash)
+	//  VALUES ($1, $2)
+	//  RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
+	Create(ctx context.Context, arg CreateParams) (*Password, error)
+	//GetByUserID
+	//
+	//  SELECT id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
+	//  FROM passwords
+	//  WHERE user_id = $1
+	//    AND deleted_at IS NULL
+	GetByUserID(ctx context.Context, userID int64) (*Password, error)
+	//GetLoginFailure
+	//
+	//  SELECT user_id, ip, attempts, updated_at
+	//  FROM login_failures
+	//  WHERE user_id = $1
+	//    AND ip = $2
+	GetLoginFailure(ctx context.Context, arg GetLoginFailureParams) (*LoginFailure, error)
+	//IncreaseLoginFailures
+	//
+	//  INSERT INTO login_failures (user_id, ip, attempts)
+	//  VALUES ($1, $2, 1)
+	//  ON CONFLICT (user_id, ip) DO UPDATE SET attempts = login_failures.attempts + 1, updated_at = now()
+	//  RETURNING user_id, ip, attempts, updated_at
+	IncreaseLoginFailures(ctx context.Context, arg IncreaseLoginFailuresParams) (*LoginFailure, error)
+	//PurgeLoginFailures
+	//
+	//  DELETE FROM login_failures
+	//  WHERE updated_at < now() + CAST(CAST($1 AS text) AS interval)
+	PurgeLoginFailures(ctx context.Context, age string) (int64, error)
+	//ResetAllLoginFailures
+	//
+	//  DELETE FROM login_failures
+	//  WHERE user_id = $1
+	ResetAllLoginFailures(ctx context.Context, userID int64) error
+	//ResetLoginFailures
+	//
+	//  DELETE FROM login_failures
+	//  WHERE user_id = $1
+	//    AND ip = $2
+	ResetLoginFailures(ctx context.Context, arg ResetLoginFailuresParams) error
+	//Update
+	//
+	//  UPDATE passwords
+	//  SET password_hash = $1, updated_at = now()
+	//  WHERE id = $2
+	//    AND deleted_at IS NULL
+	//  RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
+	Update(ctx context.Context, arg UpdateParams) (*Password, error)
+}
+
+var _ Querier = (*Queries)(nil)
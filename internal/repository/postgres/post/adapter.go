@@ -0,0 +1,68 @@
+package post
+
+import (
+	"context"
+	"database/sql"
+
+	repositoryPost "github.com/HardDie/blog_engine/internal/repository/sqlite/post"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositoryPost.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) Create(ctx context.Context, arg repositoryPost.CreateParams) (*repositoryPost.Post, error) {
+	row, err := a.q.Create(ctx, CreateParams(arg))
+	return (*repositoryPost.Post)(row), err
+}
+func (a *Adapter) Delete(ctx context.Context, arg repositoryPost.DeleteParams) (int64, error) {
+	return a.q.Delete(ctx, DeleteParams(arg))
+}
+func (a *Adapter) Edit(ctx context.Context, arg repositoryPost.EditParams) (*repositoryPost.Post, error) {
+	row, err := a.q.Edit(ctx, EditParams(arg))
+	return (*repositoryPost.Post)(row), err
+}
+func (a *Adapter) GetByID(ctx context.Context, arg repositoryPost.GetByIDParams) (*repositoryPost.Post, error) {
+	row, err := a.q.GetByID(ctx, GetByIDParams(arg))
+	return (*repositoryPost.Post)(row), err
+}
+func (a *Adapter) List(ctx context.Context, arg repositoryPost.ListParams) ([]*repositoryPost.ListRow, error) {
+	rows, err := a.q.List(ctx, ListParams(arg))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositoryPost.ListRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, &repositoryPost.ListRow{
+			Post:  repositoryPost.Post(row.Post),
+			Count: row.Count,
+		})
+	}
+	return result, nil
+}
+func (a *Adapter) ListFollowing(ctx context.Context, arg repositoryPost.ListFollowingParams) ([]*repositoryPost.ListFollowingRow, error) {
+	rows, err := a.q.ListFollowing(ctx, ListFollowingParams(arg))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositoryPost.ListFollowingRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, &repositoryPost.ListFollowingRow{
+			Post:  repositoryPost.Post(row.Post),
+			Count: row.Count,
+		})
+	}
+	return result, nil
+}
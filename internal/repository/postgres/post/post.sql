@@ -0,0 +1,58 @@
+-- name: List :many
+SELECT sqlc.embed(posts), count(*) over()
+FROM posts
+WHERE deleted_at IS NULL
+  AND CASE WHEN CAST(sqlc.arg(display_only_published) AS boolean) IS TRUE THEN is_published IS true ELSE true END
+  AND CASE WHEN CAST(sqlc.arg(query) AS text) <> '' THEN lower(title) LIKE sqlc.arg(query) ELSE true END
+  AND CASE WHEN CAST(sqlc.arg(related_to_user) AS bigint) > 0 THEN user_id = sqlc.arg(related_to_user) ELSE true END
+ORDER BY id DESC
+LIMIT CASE WHEN CAST(sqlc.arg('limit') AS bigint) > 0 THEN sqlc.arg('limit') ELSE 10 END
+OFFSET CAST(sqlc.arg('offset') AS bigint);
+
+-- name: Create :one
+INSERT INTO posts (user_id, title, short, body, tags, is_published,
+                   cover_image, meta_description, canonical_url, noindex,
+                   og_title, og_description, og_image,
+                   twitter_card, twitter_title, twitter_description, twitter_image)
+VALUES ($1, $2, $3, $4, $5, $6,
+        $7, $8, $9, $10,
+        $11, $12, $13,
+        $14, $15, $16, $17)
+RETURNING *;
+
+-- name: Edit :one
+UPDATE posts
+SET title = $1, short = $2, body = $3, tags = $4, is_published = $5,
+    cover_image = $6, meta_description = $7, canonical_url = $8, noindex = $9,
+    og_title = $10, og_description = $11, og_image = $12,
+    twitter_card = $13, twitter_title = $14, twitter_description = $15, twitter_image = $16,
+    updated_at = now()
+WHERE id = $17
+  AND deleted_at IS NULL
+  AND user_id = $18
+RETURNING *;
+
+-- name: GetByID :one
+SELECT *
+FROM posts
+WHERE deleted_at IS NULL
+  AND id = sqlc.arg(id)
+  AND CASE WHEN CAST(sqlc.narg(user_id) AS bigint) IS NULL THEN is_published IS TRUE ELSE user_id = sqlc.narg(user_id) END;
+
+-- name: ListFollowing :many
+SELECT sqlc.embed(posts), count(*) over()
+FROM posts
+JOIN follows ON follows.followee_id = posts.user_id
+WHERE follows.follower_id = sqlc.arg(follower_id)
+  AND posts.deleted_at IS NULL
+  AND posts.is_published IS true
+ORDER BY posts.id DESC
+LIMIT CASE WHEN CAST(sqlc.arg('limit') AS bigint) > 0 THEN sqlc.arg('limit') ELSE 10 END
+OFFSET CAST(sqlc.arg('offset') AS bigint);
+
+-- name: Delete :execrows
+UPDATE posts
+SET deleted_at = now()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
@@ -0,0 +1,440 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: post.sql
+
+package post
+
+import (
+	"context"
+	"database/sql"
+)
+
+const create = `-- name: Create :one
+INSERT INTO posts (user_id, title, short, body, tags, is_published,
+                   cover_image, meta_description, canonical_url, noindex,
+                   og_title, og_description, og_image,
+                   twitter_card, twitter_title, twitter_description, twitter_image)
+VALUES ($1, $2, $3, $4, $5, $6,
+        $7, $8, $9, $10,
+        $11, $12, $13,
+        $14, $15, $16, $17)
+RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
+`
+
+type CreateParams struct {
+	UserID             int64          `json:"userId"`
+	Title              string         `json:"title"`
+	Short              string         `json:"short"`
+	Body               string         `json:"body"`
+	Tags               sql.NullString `json:"tags"`
+	IsPublished        bool           `json:"isPublished"`
+	CoverImage         sql.NullString `json:"coverImage"`
+	MetaDescription    sql.NullString `json:"metaDescription"`
+	CanonicalUrl       sql.NullString `json:"canonicalUrl"`
+	Noindex            bool           `json:"noindex"`
+	OgTitle            sql.NullString `json:"ogTitle"`
+	OgDescription      sql.NullString `json:"ogDescription"`
+	OgImage            sql.NullString `json:"ogImage"`
+	TwitterCard        sql.NullString `json:"twitterCard"`
+	TwitterTitle       sql.NullString `json:"twitterTitle"`
+	TwitterDescription sql.NullString `json:"twitterDescription"`
+	TwitterImage       sql.NullString `json:"twitterImage"`
+}
+
+// Create
+//
+//	INSERT INTO posts (user_id, title, short, body, tags, is_published,
+//	                   cover_image, meta_description, canonical_url, noindex,
+//	                   og_title, og_description, og_image,
+//	                   twitter_card, twitter_title, twitter_description, twitter_image)
+//	VALUES ($1, $2, $3, $4, $5, $6,
+//	        $7, $8, $9, $10,
+//	        $11, $12, $13,
+//	        $14, $15, $16, $17)
+//	RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
+func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Post, error) {
+	row := q.queryRow(ctx, q.createStmt, create,
+		arg.UserID,
+		arg.Title,
+		arg.Short,
+		arg.Body,
+		arg.Tags,
+		arg.IsPublished,
+		arg.CoverImage,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.Noindex,
+		arg.OgTitle,
+		arg.OgDescription,
+		arg.OgImage,
+		arg.TwitterCard,
+		arg.TwitterTitle,
+		arg.TwitterDescription,
+		arg.TwitterImage,
+	)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Short,
+		&i.Body,
+		&i.Tags,
+		&i.IsPublished,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CoverImage,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.Noindex,
+		&i.OgTitle,
+		&i.OgDescription,
+		&i.OgImage,
+		&i.TwitterCard,
+		&i.TwitterTitle,
+		&i.TwitterDescription,
+		&i.TwitterImage,
+	)
+	return &i, err
+}
+
+const delete = `-- name: Delete :execrows
+UPDATE posts
+SET deleted_at = now()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+`
+
+type DeleteParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"userId"`
+}
+
+// Delete
+//
+//	UPDATE posts
+//	SET deleted_at = now()
+//	WHERE id = $1
+//	  AND user_id = $2
+//	  AND deleted_at IS NULL
+func (q *Queries) Delete(ctx context.Context, arg DeleteParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteStmt, delete, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const edit = `-- name: Edit :one
+UPDATE posts
+SET title = $1, short = $2, body = $3, tags = $4, is_published = $5,
+    cover_image = $6, meta_description = $7, canonical_url = $8, noindex = $9,
+    og_title = $10, og_description = $11, og_image = $12,
+    twitter_card = $13, twitter_title = $14, twitter_description = $15, twitter_image = $16,
+    updated_at = now()
+WHERE id = $17
+  AND deleted_at IS NULL
+  AND user_id = $18
+RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
+`
+
+type EditParams struct {
+	Title              string         `json:"title"`
+	Short              string         `json:"short"`
+	Body               string         `json:"body"`
+	Tags               sql.NullString `json:"tags"`
+	IsPublished        bool           `json:"isPublished"`
+	CoverImage         sql.NullString `json:"coverImage"`
+	MetaDescription    sql.NullString `json:"metaDescription"`
+	CanonicalUrl       sql.NullString `json:"canonicalUrl"`
+	Noindex            bool           `json:"noindex"`
+	OgTitle            sql.NullString `json:"ogTitle"`
+	OgDescription      sql.NullString `json:"ogDescription"`
+	OgImage            sql.NullString `json:"ogImage"`
+	TwitterCard        sql.NullString `json:"twitterCard"`
+	TwitterTitle       sql.NullString `json:"twitterTitle"`
+	TwitterDescription sql.NullString `json:"twitterDescription"`
+	TwitterImage       sql.NullString `json:"twitterImage"`
+	ID                 int64          `json:"id"`
+	UserID             int64          `json:"userId"`
+}
+
+// Edit
+//
+//	UPDATE posts
+//	SET title = $1, short = $2, body = $3, tags = $4, is_published = $5,
+//	    cover_image = $6, meta_description = $7, canonical_url = $8, noindex = $9,
+//	    og_title = $10, og_description = $11, og_image = $12,
+//	    twitter_card = $13, twitter_title = $14, twitter_description = $15, twitter_image = $16,
+//	    updated_at = now()
+//	WHERE id = $17
+//	  AND deleted_at IS NULL
+//	  AND user_id = $18
+//	RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
+func (q *Queries) Edit(ctx context.Context, arg EditParams) (*Post, error) {
+	row := q.queryRow(ctx, q.editStmt, edit,
+		arg.Title,
+		arg.Short,
+		arg.Body,
+		arg.Tags,
+		arg.IsPublished,
+		arg.CoverImage,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.Noindex,
+		arg.OgTitle,
+		arg.OgDescription,
+		arg.OgImage,
+		arg.TwitterCard,
+		arg.TwitterTitle,
+		arg.TwitterDescription,
+		arg.TwitterImage,
+		arg.ID,
+		arg.UserID,
+	)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Short,
+		&i.Body,
+		&i.Tags,
+		&i.IsPublished,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CoverImage,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.Noindex,
+		&i.OgTitle,
+		&i.OgDescription,
+		&i.OgImage,
+		&i.TwitterCard,
+		&i.TwitterTitle,
+		&i.TwitterDescription,
+		&i.TwitterImage,
+	)
+	return &i, err
+}
+
+const getByID = `-- name: GetByID :one
+SELECT id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
+FROM posts
+WHERE deleted_at IS NULL
+  AND id = $1
+  AND CASE WHEN CAST($2 AS bigint) IS NULL THEN is_published IS TRUE ELSE user_id = $2 END
+`
+
+type GetByIDParams struct {
+	ID     int64         `json:"id"`
+	UserID sql.NullInt64 `json:"userId"`
+}
+
+// GetByID
+//
+//	SELECT id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
+//	FROM posts
+//	WHERE deleted_at IS NULL
+//	  AND id = $1
+//	  AND CASE WHEN CAST($2 AS bigint) IS NULL THEN is_published IS TRUE ELSE user_id = $2 END
+func (q *Queries) GetByID(ctx context.Context, arg GetByIDParams) (*Post, error) {
+	row := q.queryRow(ctx, q.getByIDStmt, getByID, arg.ID, arg.UserID)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Short,
+		&i.Body,
+		&i.Tags,
+		&i.IsPublished,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CoverImage,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.Noindex,
+		&i.OgTitle,
+		&i.OgDescription,
+		&i.OgImage,
+		&i.TwitterCard,
+		&i.TwitterTitle,
+		&i.TwitterDescription,
+		&i.TwitterImage,
+	)
+	return &i, err
+}
+
+const list = `-- name: List :many
+SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
+FROM posts
+WHERE deleted_at IS NULL
+  AND CASE WHEN CAST($1 AS boolean) IS TRUE THEN is_published IS true ELSE true END
+  AND CASE WHEN CAST($2 AS text) <> '' THEN lower(title) LIKE $2 ELSE true END
+  AND CASE WHEN CAST($3 AS bigint) > 0 THEN user_id = $3 ELSE true END
+ORDER BY id DESC
+LIMIT CASE WHEN CAST($5 AS bigint) > 0 THEN $5 ELSE 10 END
+OFFSET CAST($4 AS bigint)
+`
+
+type ListParams struct {
+	DisplayOnlyPublished bool   `json:"displayOnlyPublished"`
+	Query                string `json:"query"`
+	RelatedToUser        int64  `json:"relatedToUser"`
+	Offset               int64  `json:"offset"`
+	Limit                int64  `json:"limit"`
+}
+
+type ListRow struct {
+	Post  Post  `json:"post"`
+	Count int64 `json:"count"`
+}
+
+// List
+//
+//	SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
+//	FROM posts
+//	WHERE deleted_at IS NULL
+//	  AND CASE WHEN CAST($1 AS boolean) IS TRUE THEN is_published IS true ELSE true END
+//	  AND CASE WHEN CAST($2 AS text) <> '' THEN lower(title) LIKE $2 ELSE true END
+//	  AND CASE WHEN CAST($3 AS bigint) > 0 THEN user_id = $3 ELSE true END
+//	ORDER BY id DESC
+//	LIMIT CASE WHEN CAST($5 AS bigint) > 0 THEN $5 ELSE 10 END
+//	OFFSET CAST($4 AS bigint)
+func (q *Queries) List(ctx context.Context, arg ListParams) ([]*ListRow, error) {
+	rows, err := q.query(ctx, q.listStmt, list,
+		arg.DisplayOnlyPublished,
+		arg.Query,
+		arg.RelatedToUser,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListRow{}
+	for rows.Next() {
+		var i ListRow
+		if err := rows.Scan(
+			&i.Post.ID,
+			&i.Post.UserID,
+			&i.Post.Title,
+			&i.Post.Short,
+			&i.Post.Body,
+			&i.Post.Tags,
+			&i.Post.IsPublished,
+			&i.Post.CreatedAt,
+			&i.Post.UpdatedAt,
+			&i.Post.DeletedAt,
+			&i.Post.CoverImage,
+			&i.Post.MetaDescription,
+			&i.Post.CanonicalUrl,
+			&i.Post.Noindex,
+			&i.Post.OgTitle,
+			&i.Post.OgDescription,
+			&i.Post.OgImage,
+			&i.Post.TwitterCard,
+			&i.Post.TwitterTitle,
+			&i.Post.TwitterDescription,
+			&i.Post.TwitterImage,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFollowing = `-- name: ListFollowing :many
+SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
+FROM posts
+JOIN follows ON follows.followee_id = posts.user_id
+WHERE follows.follower_id = $1
+  AND posts.deleted_at IS NULL
+  AND posts.is_published IS true
+ORDER BY posts.id DESC
+LIMIT CASE WHEN CAST($3 AS bigint) > 0 THEN $3 ELSE 10 END
+OFFSET CAST($2 AS bigint)
+`
+
+type ListFollowingParams struct {
+	FollowerID int64 `json:"followerId"`
+	Offset     int64 `json:"offset"`
+	Limit      int64 `json:"limit"`
+}
+
+type ListFollowingRow struct {
+	Post  Post  `json:"post"`
+	Count int64 `json:"count"`
+}
+
+// ListFollowing
+//
+//	SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
+//	FROM posts
+//	JOIN follows ON follows.followee_id = posts.user_id
+//	WHERE follows.follower_id = $1
+//	  AND posts.deleted_at IS NULL
+//	  AND posts.is_published IS true
+//	ORDER BY posts.id DESC
+//	LIMIT CASE WHEN CAST($3 AS bigint) > 0 THEN $3 ELSE 10 END
+//	OFFSET CAST($2 AS bigint)
+func (q *Queries) ListFollowing(ctx context.Context, arg ListFollowingParams) ([]*ListFollowingRow, error) {
+	rows, err := q.query(ctx, q.listFollowingStmt, listFollowing, arg.FollowerID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListFollowingRow{}
+	for rows.Next() {
+		var i ListFollowingRow
+		if err := rows.Scan(
+			&i.Post.ID,
+			&i.Post.UserID,
+			&i.Post.Title,
+			&i.Post.Short,
+			&i.Post.Body,
+			&i.Post.Tags,
+			&i.Post.IsPublished,
+			&i.Post.CreatedAt,
+			&i.Post.UpdatedAt,
+			&i.Post.DeletedAt,
+			&i.Post.CoverImage,
+			&i.Post.MetaDescription,
+			&i.Post.CanonicalUrl,
+			&i.Post.Noindex,
+			&i.Post.OgTitle,
+			&i.Post.OgDescription,
+			&i.Post.OgImage,
+			&i.Post.TwitterCard,
+			&i.Post.TwitterTitle,
+			&i.Post.TwitterDescription,
+			&i.Post.TwitterImage,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
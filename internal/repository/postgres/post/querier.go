@@ -0,0 +1,79 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package post
+
+import (
+	"context"
+)
+
+type Querier interface {
+	//Create
+	//
+	//  INSERT INTO posts (user_id, title, short, body, tags, is_published,
+	//                     cover_image, meta_description, canonical_url, noindex,
+	//                     og_title, og_description, og_image,
+	//                     twitter_card, twitter_title, twitter_description, twitter_image)
+	//  VALUES ($1, $2, $3, $4, $5, $6,
+	//          $7, $8, $9, $10,
+	//          $11, $12, $13,
+	//          $14, $15, $16, $17)
+	//  RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
+	Create(ctx context.Context, arg CreateParams) (*Post, error)
+	//Delete
+	//
+	//  UPDATE posts
+	//  SET deleted_at = now()
+	//  WHERE id = $1
+	//    AND user_id = $2
+	//    AND deleted_at IS NULL
+	Delete(ctx context.Context, arg DeleteParams) (int64, error)
+	//Edit
+	//
+	//  UPDATE posts
+	//  SET title = $1, short = $2, body = $3, tags = $4, is_published = $5,
+	//      cover_image = $6, meta_description = $7, canonical_url = $8, noindex = $9,
+	//      og_title = $10, og_description = $11, og_image = $12,
+	//      twitter_card = $13, twitter_title = $14, twitter_description = $15, twitter_image = $16,
+	//      updated_at = now()
+	//  WHERE id = $17
+	//    AND deleted_at IS NULL
+	//    AND user_id = $18
+	//  RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
+	Edit(ctx context.Context, arg EditParams) (*Post, error)
+	//GetByID
+	//
+	//  SELECT id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
+	//  FROM posts
+	//  WHERE deleted_at IS NULL
+	//    AND id = $1
+	//    AND CASE WHEN CAST($2 AS bigint) IS NULL THEN is_published IS TRUE ELSE user_id = $2 END
+	GetByID(ctx context.Context, arg GetByIDParams) (*Post, error)
+	//List
+	//
+	//  SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
+	//  FROM posts
+	//  WHERE deleted_at IS NULL
+	//    AND CASE WHEN CAST($1 AS boolean) IS TRUE THEN is_published IS true ELSE true END
+	//    AND CASE WHEN CAST($2 AS text) <> '' THEN lower(title) LIKE $2 ELSE true END
+	//    AND CASE WHEN CAST($3 AS bigint) > 0 THEN user_id = $3 ELSE true END
+	//  ORDER BY id DESC
+	//  LIMIT CASE WHEN CAST($5 AS bigint) > 0 THEN $5 ELSE 10 END
+	//  OFFSET CAST($4 AS bigint)
+	List(ctx context.Context, arg ListParams) ([]*ListRow, error)
+	//ListFollowing
+	//
+	//  SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
+	//  FROM posts
+	//  JOIN follows ON follows.followee_id = posts.user_id
+	//  WHERE follows.follower_id = $1
+	//    AND posts.deleted_at IS NULL
+	//    AND posts.is_published IS true
+	//  ORDER BY posts.id DESC
+	//  LIMIT CASE WHEN CAST($3 AS bigint) > 0 THEN $3 ELSE 10 END
+	//  OFFSET CAST($2 AS bigint)
+	ListFollowing(ctx context.Context, arg ListFollowingParams) ([]*ListFollowingRow, error)
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,18 @@
+// Package postgres holds the PostgreSQL repositories. Each one is generated by sqlc from its own queries
+// and has an Adapter implementing the Querier of the SQLite repository, so services don't depend on the driver.
+package postgres
+
+import (
+	"strconv"
+	"strings"
+)
+
+// BigintArray formats ids as an array literal like {1,2,3}. database/sql doesn't bind Go slices,
+// so the queries take the literal as text and cast it to bigint[].
+func BigintArray(ids []int64) string {
+	items := make([]string, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, strconv.FormatInt(id, 10))
+	}
+	return "{" + strings.Join(items, ",") + "}"
+}
@@ -0,0 +1,57 @@
+package reaction
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/HardDie/blog_engine/internal/repository/postgres"
+	repositoryReaction "github.com/HardDie/blog_engine/internal/repository/sqlite/reaction"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositoryReaction.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) Add(ctx context.Context, arg repositoryReaction.AddParams) (int64, error) {
+	return a.q.Add(ctx, AddParams(arg))
+}
+func (a *Adapter) ListByUser(ctx context.Context, arg repositoryReaction.ListByUserParams) ([]*repositoryReaction.ListByUserRow, error) {
+	rows, err := a.q.ListByUser(ctx, ListByUserParams{
+		UserID:  arg.UserID,
+		PostIds: postgres.BigintArray(arg.PostIds),
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositoryReaction.ListByUserRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, (*repositoryReaction.ListByUserRow)(row))
+	}
+	return result, nil
+}
+func (a *Adapter) ListCounts(ctx context.Context, postIds []int64) ([]*repositoryReaction.PostReactionCount, error) {
+	rows, err := a.q.ListCounts(ctx, postgres.BigintArray(postIds))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositoryReaction.PostReactionCount, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, (*repositoryReaction.PostReactionCount)(row))
+	}
+	return result, nil
+}
+func (a *Adapter) Remove(ctx context.Context, arg repositoryReaction.RemoveParams) (int64, error) {
+	return a.q.Remove(ctx, RemoveParams(arg))
+}
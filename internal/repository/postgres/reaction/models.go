@@ -0,0 +1,11 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package reaction
+
+type PostReactionCount struct {
+	PostID   int64  `json:"postId"`
+	Reaction string `json:"reaction"`
+	Count    int64  `json:"count"`
+}
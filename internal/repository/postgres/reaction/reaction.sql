@@ -0,0 +1,24 @@
+-- name: Add :execrows
+INSERT INTO post_reactions (post_id, user_id, reaction)
+VALUES ($1, $2, $3)
+ON CONFLICT (post_id, user_id, reaction) DO NOTHING;
+
+-- name: Remove :execrows
+DELETE FROM post_reactions
+WHERE post_id = $1
+  AND user_id = $2
+  AND reaction = $3;
+
+-- name: ListCounts :many
+SELECT *
+FROM post_reaction_counts
+WHERE post_id = ANY(CAST(CAST(sqlc.arg(post_ids) AS text) AS bigint[]))
+  AND count > 0
+ORDER BY post_id, reaction;
+
+-- name: ListByUser :many
+SELECT post_id, reaction
+FROM post_reactions
+WHERE user_id = sqlc.arg(user_id)
+  AND post_id = ANY(CAST(CAST(sqlc.arg(post_ids) AS text) AS bigint[]))
+ORDER BY post_id, created_at;
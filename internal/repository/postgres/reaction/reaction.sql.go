@@ -0,0 +1,148 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: reaction.sql
+
+package reaction
+
+import (
+	"context"
+)
+
+const add = `-- name: Add :execrows
+INSERT INTO post_reactions (post_id, user_id, reaction)
+VALUES ($1, $2, $3)
+ON CONFLICT (post_id, user_id, reaction) DO NOTHING
+`
+
+type AddParams struct {
+	PostID   int64  `json:"postId"`
+	UserID   int64  `json:"userId"`
+	Reaction string `json:"reaction"`
+}
+
+// Add
+//
+//	INSERT INTO post_reactions (post_id, user_id, reaction)
+//	VALUES ($1, $2, $3)
+//	ON CONFLICT (post_id, user_id, reaction) DO NOTHING
+func (q *Queries) Add(ctx context.Context, arg AddParams) (int64, error) {
+	result, err := q.exec(ctx, q.addStmt, add, arg.PostID, arg.UserID, arg.Reaction)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listByUser = `-- name: ListByUser :many
+SELECT post_id, reaction
+FROM post_reactions
+WHERE user_id = $1
+  AND post_id = ANY(CAST(CAST($2 AS text) AS bigint[]))
+ORDER BY post_id, created_at
+`
+
+type ListByUserParams struct {
+	UserID  int64  `json:"userId"`
+	PostIds string `json:"postIds"`
+}
+
+type ListByUserRow struct {
+	PostID   int64  `json:"postId"`
+	Reaction string `json:"reaction"`
+}
+
+// ListByUser
+//
+//	SELECT post_id, reaction
+//	FROM post_reactions
+//	WHERE user_id = $1
+//	  AND post_id = ANY(CAST(CAST($2 AS text) AS bigint[]))
+//	ORDER BY post_id, created_at
+func (q *Queries) ListByUser(ctx context.Context, arg ListByUserParams) ([]*ListByUserRow, error) {
+	rows, err := q.query(ctx, q.listByUserStmt, listByUser, arg.UserID, arg.PostIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListByUserRow{}
+	for rows.Next() {
+		var i ListByUserRow
+		if err := rows.Scan(&i.PostID, &i.Reaction); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCounts = `-- name: ListCounts :many
+SELECT post_id, reaction, count
+FROM post_reaction_counts
+WHERE post_id = ANY(CAST(CAST($1 AS text) AS bigint[]))
+  AND count > 0
+ORDER BY post_id, reaction
+`
+
+// ListCounts
+//
+//	SELECT post_id, reaction, count
+//	FROM post_reaction_counts
+//	WHERE post_id = ANY(CAST(CAST($1 AS text) AS bigint[]))
+//	  AND count > 0
+//	ORDER BY post_id, reaction
+func (q *Queries) ListCounts(ctx context.Context, postIds string) ([]*PostReactionCount, error) {
+	rows, err := q.query(ctx, q.listCountsStmt, listCounts, postIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*PostReactionCount{}
+	for rows.Next() {
+		var i PostReactionCount
+		if err := rows.Scan(&i.PostID, &i.Reaction, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const remove = `-- name: Remove :execrows
+DELETE FROM post_reactions
+WHERE post_id = $1
+  AND user_id = $2
+  AND reaction = $3
+`
+
+type RemoveParams struct {
+	PostID   int64  `json:"postId"`
+	UserID   int64  `json:"userId"`
+	Reaction string `json:"reaction"`
+}
+
+// Remove
+//
+//	DELETE FROM post_reactions
+//	WHERE post_id = $1
+//	  AND user_id = $2
+//	  AND reaction = $3
+func (q *Queries) Remove(ctx context.Context, arg RemoveParams) (int64, error) {
+	result, err := q.exec(ctx, q.removeStmt, remove, arg.PostID, arg.UserID, arg.Reaction)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,92 @@
+package readinglist
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/HardDie/blog_engine/internal/repository/postgres"
+	repositoryReadingList "github.com/HardDie/blog_engine/internal/repository/sqlite/readinglist"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositoryReadingList.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) AddItem(ctx context.Context, arg repositoryReadingList.AddItemParams) (*repositoryReadingList.ReadingListItem, error) {
+	row, err := a.q.AddItem(ctx, AddItemParams(arg))
+	return (*repositoryReadingList.ReadingListItem)(row), err
+}
+func (a *Adapter) Create(ctx context.Context, arg repositoryReadingList.CreateParams) (*repositoryReadingList.ReadingList, error) {
+	row, err := a.q.Create(ctx, CreateParams(arg))
+	return (*repositoryReadingList.ReadingList)(row), err
+}
+func (a *Adapter) Delete(ctx context.Context, arg repositoryReadingList.DeleteParams) (int64, error) {
+	return a.q.Delete(ctx, DeleteParams(arg))
+}
+func (a *Adapter) Edit(ctx context.Context, arg repositoryReadingList.EditParams) (*repositoryReadingList.ReadingList, error) {
+	row, err := a.q.Edit(ctx, EditParams(arg))
+	return (*repositoryReadingList.ReadingList)(row), err
+}
+func (a *Adapter) GetByID(ctx context.Context, id int64) (*repositoryReadingList.ReadingList, error) {
+	row, err := a.q.GetByID(ctx, id)
+	return (*repositoryReadingList.ReadingList)(row), err
+}
+func (a *Adapter) ListByUser(ctx context.Context, arg repositoryReadingList.ListByUserParams) ([]*repositoryReadingList.ListByUserRow, error) {
+	rows, err := a.q.ListByUser(ctx, ListByUserParams(arg))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositoryReadingList.ListByUserRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, &repositoryReadingList.ListByUserRow{
+			ReadingList: repositoryReadingList.ReadingList(row.ReadingList),
+			ItemsCount:  row.ItemsCount,
+			Count:       row.Count,
+		})
+	}
+	return result, nil
+}
+func (a *Adapter) ListItemPostIDs(ctx context.Context, listID int64) ([]int64, error) {
+	return a.q.ListItemPostIDs(ctx, listID)
+}
+func (a *Adapter) ListItems(ctx context.Context, listID int64) ([]*repositoryReadingList.ListItemsRow, error) {
+	rows, err := a.q.ListItems(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositoryReadingList.ListItemsRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, &repositoryReadingList.ListItemsRow{
+			ReadingListItem: repositoryReadingList.ReadingListItem(row.ReadingListItem),
+			Post:            repositoryReadingList.Post(row.Post),
+		})
+	}
+	return result, nil
+}
+func (a *Adapter) ListSavedPostIDs(ctx context.Context, arg repositoryReadingList.ListSavedPostIDsParams) ([]int64, error) {
+	return a.q.ListSavedPostIDs(ctx, ListSavedPostIDsParams{
+		UserID:  arg.UserID,
+		PostIds: postgres.BigintArray(arg.PostIds),
+	})
+}
+func (a *Adapter) RemoveItem(ctx context.Context, arg repositoryReadingList.RemoveItemParams) (int64, error) {
+	return a.q.RemoveItem(ctx, RemoveItemParams(arg))
+}
+func (a *Adapter) SetItemPosition(ctx context.Context, arg repositoryReadingList.SetItemPositionParams) error {
+	return a.q.SetItemPosition(ctx, SetItemPositionParams(arg))
+}
+func (a *Adapter) Touch(ctx context.Context, id int64) error {
+	return a.q.Touch(ctx, id)
+}
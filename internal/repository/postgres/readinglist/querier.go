@@ -0,0 +1,110 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package readinglist
+
+import (
+	"context"
+)
+
+type Querier interface {
+	//AddItem
+	//
+	//  INSERT INTO reading_list_items (list_id, post_id, note, position)
+	//  VALUES ($1, $2, $3,
+	//          (SELECT coalesce(max(position) + 1, 0) FROM reading_list_items WHERE list_id = $1))
+	//  ON CONFLICT (list_id, post_id) DO UPDATE SET note = excluded.note
+	//  RETURNING list_id, post_id, note, position, created_at
+	AddItem(ctx context.Context, arg AddItemParams) (*ReadingListItem, error)
+	//Create
+	//
+	//  INSERT INTO reading_lists (user_id, title, is_public)
+	//  VALUES ($1, $2, $3)
+	//  RETURNING id, user_id, title, is_public, created_at, updated_at, deleted_at
+	Create(ctx context.Context, arg CreateParams) (*ReadingList, error)
+	//Delete
+	//
+	//  UPDATE reading_lists
+	//  SET deleted_at = now()
+	//  WHERE id = $1
+	//    AND deleted_at IS NULL
+	//    AND user_id = $2
+	Delete(ctx context.Context, arg DeleteParams) (int64, error)
+	//Edit
+	//
+	//  UPDATE reading_lists
+	//  SET title = $1, is_public = $2, updated_at = now()
+	//  WHERE id = $3
+	//    AND deleted_at IS NULL
+	//    AND user_id = $4
+	//  RETURNING id, user_id, title, is_public, created_at, updated_at, deleted_at
+	Edit(ctx context.Context, arg EditParams) (*ReadingList, error)
+	//GetByID
+	//
+	//  SELECT id, user_id, title, is_public, created_at, updated_at, deleted_at
+	//  FROM reading_lists
+	//  WHERE id = $1
+	//    AND deleted_at IS NULL
+	GetByID(ctx context.Context, id int64) (*ReadingList, error)
+	//ListByUser
+	//
+	//  SELECT reading_lists.id, reading_lists.user_id, reading_lists.title, reading_lists.is_public, reading_lists.created_at, reading_lists.updated_at, reading_lists.deleted_at,
+	//         (SELECT count(*) FROM reading_list_items WHERE reading_list_items.list_id = reading_lists.id) AS items_count,
+	//         count(*) over()
+	//  FROM reading_lists
+	//  WHERE user_id = $1
+	//    AND deleted_at IS NULL
+	//  ORDER BY id DESC
+	//  LIMIT CASE WHEN CAST($3 AS bigint) > 0 THEN $3 ELSE 10 END
+	//  OFFSET CAST($2 AS bigint)
+	ListByUser(ctx context.Context, arg ListByUserParams) ([]*ListByUserRow, error)
+	//ListItemPostIDs
+	//
+	//  SELECT post_id
+	//  FROM reading_list_items
+	//  WHERE list_id = $1
+	//  ORDER BY position
+	ListItemPostIDs(ctx context.Context, listID int64) ([]int64, error)
+	//ListItems
+	//
+	//  SELECT reading_list_items.list_id, reading_list_items.post_id, reading_list_items.note, reading_list_items.position, reading_list_items.created_at, posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image
+	//  FROM reading_list_items
+	//  JOIN posts ON posts.id = reading_list_items.post_id
+	//  WHERE reading_list_items.list_id = $1
+	//    AND posts.deleted_at IS NULL
+	//    AND posts.is_published IS TRUE
+	//  ORDER BY reading_list_items.position
+	ListItems(ctx context.Context, listID int64) ([]*ListItemsRow, error)
+	//ListSavedPostIDs
+	//
+	//  SELECT DISTINCT reading_list_items.post_id
+	//  FROM reading_list_items
+	//  JOIN reading_lists ON reading_lists.id = reading_list_items.list_id
+	//  WHERE reading_lists.user_id = $1
+	//    AND reading_lists.deleted_at IS NULL
+	//    AND reading_list_items.post_id = ANY(CAST(CAST($2 AS text) AS bigint[]))
+	ListSavedPostIDs(ctx context.Context, arg ListSavedPostIDsParams) ([]int64, error)
+	//RemoveItem
+	//
+	//  DELETE FROM reading_list_items
+	//  WHERE list_id = $1
+	//    AND post_id = $2
+	RemoveItem(ctx context.Context, arg RemoveItemParams) (int64, error)
+	//SetItemPosition
+	//
+	//  UPDATE reading_list_items
+	//  SET position = $1
+	//  WHERE list_id = $2
+	//    AND post_id = $3
+	SetItemPosition(ctx context.Context, arg SetItemPositionParams) error
+	//Touch
+	//
+	//  UPDATE reading_lists
+	//  SET updated_at = now()
+	//  WHERE id = $1
+	//    AND deleted_at IS NULL
+	Touch(ctx context.Context, id int64) error
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,466 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: readinglist.sql
+
+package readinglist
+
+import (
+	"context"
+	"database/sql"
+)
+
+const addItem = `-- name: AddItem :one
+INSERT INTO reading_list_items (list_id, post_id, note, position)
+VALUES ($1, $2, $3,
+        (SELECT coalesce(max(position) + 1, 0) FROM reading_list_items WHERE list_id = $1))
+ON CONFLICT (list_id, post_id) DO UPDATE SET note = excluded.note
+RETURNING list_id, post_id, note, position, created_at
+`
+
+type AddItemParams struct {
+	ListID int64          `json:"listId"`
+	PostID int64          `json:"postId"`
+	Note   sql.NullString `json:"note"`
+}
+
+// AddItem
+//
+//	INSERT INTO reading_list_items (list_id, post_id, note, position)
+//	VALUES ($1, $2, $3,
+//	        (SELECT coalesce(max(position) + 1, 0) FROM reading_list_items WHERE list_id = $1))
+//	ON CONFLICT (list_id, post_id) DO UPDATE SET note = excluded.note
+//	RETURNING list_id, post_id, note, position, created_at
+func (q *Queries) AddItem(ctx context.Context, arg AddItemParams) (*ReadingListItem, error) {
+	row := q.queryRow(ctx, q.addItemStmt, addItem, arg.ListID, arg.PostID, arg.Note)
+	var i ReadingListItem
+	err := row.Scan(
+		&i.ListID,
+		&i.PostID,
+		&i.Note,
+		&i.Position,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const create = `-- name: Create :one
+INSERT INTO reading_lists (user_id, title, is_public)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, title, is_public, created_at, updated_at, deleted_at
+`
+
+type CreateParams struct {
+	UserID   int64  `json:"userId"`
+	Title    string `json:"title"`
+	IsPublic bool   `json:"isPublic"`
+}
+
+// Create
+//
+//	INSERT INTO reading_lists (user_id, title, is_public)
+//	VALUES ($1, $2, $3)
+//	RETURNING id, user_id, title, is_public, created_at, updated_at, deleted_at
+func (q *Queries) Create(ctx context.Context, arg CreateParams) (*ReadingList, error) {
+	row := q.queryRow(ctx, q.createStmt, create, arg.UserID, arg.Title, arg.IsPublic)
+	var i ReadingList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.IsPublic,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return &i, err
+}
+
+const delete = `-- name: Delete :execrows
+UPDATE reading_lists
+SET deleted_at = now()
+WHERE id = $1
+  AND deleted_at IS NULL
+  AND user_id = $2
+`
+
+type DeleteParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"userId"`
+}
+
+// Delete
+//
+//	UPDATE reading_lists
+//	SET deleted_at = now()
+//	WHERE id = $1
+//	  AND deleted_at IS NULL
+//	  AND user_id = $2
+func (q *Queries) Delete(ctx context.Context, arg DeleteParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteStmt, delete, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const edit = `-- name: Edit :one
+UPDATE reading_lists
+SET title = $1, is_public = $2, updated_at = now()
+WHERE id = $3
+  AND deleted_at IS NULL
+  AND user_id = $4
+RETURNING id, user_id, title, is_public, created_at, updated_at, deleted_at
+`
+
+type EditParams struct {
+	Title    string `json:"title"`
+	IsPublic bool   `json:"isPublic"`
+	ID       int64  `json:"id"`
+	UserID   int64  `json:"userId"`
+}
+
+// Edit
+//
+//	UPDATE reading_lists
+//	SET title = $1, is_public = $2, updated_at = now()
+//	WHERE id = $3
+//	  AND deleted_at IS NULL
+//	  AND user_id = $4
+//	RETURNING id, user_id, title, is_public, created_at, updated_at, deleted_at
+func (q *Queries) Edit(ctx context.Context, arg EditParams) (*ReadingList, error) {
+	row := q.queryRow(ctx, q.editStmt, edit,
+		arg.Title,
+		arg.IsPublic,
+		arg.ID,
+		arg.UserID,
+	)
+	var i ReadingList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.IsPublic,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return &i, err
+}
+
+const getByID = `-- name: GetByID :one
+SELECT id, user_id, title, is_public, created_at, updated_at, deleted_at
+FROM reading_lists
+WHERE id = $1
+  AND deleted_at IS NULL
+`
+
+// GetByID
+//
+//	SELECT id, user_id, title, is_public, created_at, updated_at, deleted_at
+//	FROM reading_lists
+//	WHERE id = $1
+//	  AND deleted_at IS NULL
+func (q *Queries) GetByID(ctx context.Context, id int64) (*ReadingList, error) {
+	row := q.queryRow(ctx, q.getByIDStmt, getByID, id)
+	var i ReadingList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.IsPublic,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return &i, err
+}
+
+const listByUser = `-- name: ListByUser :many
+SELECT reading_lists.id, reading_lists.user_id, reading_lists.title, reading_lists.is_public, reading_lists.created_at, reading_lists.updated_at, reading_lists.deleted_at,
+       (SELECT count(*) FROM reading_list_items WHERE reading_list_items.list_id = reading_lists.id) AS items_count,
+       count(*) over()
+FROM reading_lists
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY id DESC
+LIMIT CASE WHEN CAST($3 AS bigint) > 0 THEN $3 ELSE 10 END
+OFFSET CAST($2 AS bigint)
+`
+
+type ListByUserParams struct {
+	UserID int64 `json:"userId"`
+	Offset int64 `json:"offset"`
+	Limit  int64 `json:"limit"`
+}
+
+type ListByUserRow struct {
+	ReadingList ReadingList `json:"readingList"`
+	ItemsCount  int64       `json:"itemsCount"`
+	Count       int64       `json:"count"`
+}
+
+// ListByUser
+//
+//	SELECT reading_lists.id, reading_lists.user_id, reading_lists.title, reading_lists.is_public, reading_lists.created_at, reading_lists.updated_at, reading_lists.deleted_at,
+//	       (SELECT count(*) FROM reading_list_items WHERE reading_list_items.list_id = reading_lists.id) AS items_count,
+//	       count(*) over()
+//	FROM reading_lists
+//	WHERE user_id = $1
+//	  AND deleted_at IS NULL
+//	ORDER BY id DESC
+//	LIMIT CASE WHEN CAST($3 AS bigint) > 0 THEN $3 ELSE 10 END
+//	OFFSET CAST($2 AS bigint)
+func (q *Queries) ListByUser(ctx context.Context, arg ListByUserParams) ([]*ListByUserRow, error) {
+	rows, err := q.query(ctx, q.listByUserStmt, listByUser, arg.UserID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListByUserRow{}
+	for rows.Next() {
+		var i ListByUserRow
+		if err := rows.Scan(
+			&i.ReadingList.ID,
+			&i.ReadingList.UserID,
+			&i.ReadingList.Title,
+			&i.ReadingList.IsPublic,
+			&i.ReadingList.CreatedAt,
+			&i.ReadingList.UpdatedAt,
+			&i.ReadingList.DeletedAt,
+			&i.ItemsCount,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemPostIDs = `-- name: ListItemPostIDs :many
+SELECT post_id
+FROM reading_list_items
+WHERE list_id = $1
+ORDER BY position
+`
+
+// ListItemPostIDs
+//
+//	SELECT post_id
+//	FROM reading_list_items
+//	WHERE list_id = $1
+//	ORDER BY position
+func (q *Queries) ListItemPostIDs(ctx context.Context, listID int64) ([]int64, error) {
+	rows, err := q.query(ctx, q.listItemPostIDsStmt, listItemPostIDs, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var post_id int64
+		if err := rows.Scan(&post_id); err != nil {
+			return nil, err
+		}
+		items = append(items, post_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItems = `-- name: ListItems :many
+SELECT reading_list_items.list_id, reading_list_items.post_id, reading_list_items.note, reading_list_items.position, reading_list_items.created_at, posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image
+FROM reading_list_items
+JOIN posts ON posts.id = reading_list_items.post_id
+WHERE reading_list_items.list_id = $1
+  AND posts.deleted_at IS NULL
+  AND posts.is_published IS TRUE
+ORDER BY reading_list_items.position
+`
+
+type ListItemsRow struct {
+	ReadingListItem ReadingListItem `json:"readingListItem"`
+	Post            Post            `json:"post"`
+}
+
+// ListItems
+//
+//	SELECT reading_list_items.list_id, reading_list_items.post_id, reading_list_items.note, reading_list_items.position, reading_list_items.created_at, posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image
+//	FROM reading_list_items
+//	JOIN posts ON posts.id = reading_list_items.post_id
+//	WHERE reading_list_items.list_id = $1
+//	  AND posts.deleted_at IS NULL
+//	  AND posts.is_published IS TRUE
+//	ORDER BY reading_list_items.position
+func (q *Queries) ListItems(ctx context.Context, listID int64) ([]*ListItemsRow, error) {
+	rows, err := q.query(ctx, q.listItemsStmt, listItems, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListItemsRow{}
+	for rows.Next() {
+		var i ListItemsRow
+		if err := rows.Scan(
+			&i.ReadingListItem.ListID,
+			&i.ReadingListItem.PostID,
+			&i.ReadingListItem.Note,
+			&i.ReadingListItem.Position,
+			&i.ReadingListItem.CreatedAt,
+			&i.Post.ID,
+			&i.Post.UserID,
+			&i.Post.Title,
+			&i.Post.Short,
+			&i.Post.Body,
+			&i.Post.Tags,
+			&i.Post.IsPublished,
+			&i.Post.CreatedAt,
+			&i.Post.UpdatedAt,
+			&i.Post.DeletedAt,
+			&i.Post.CoverImage,
+			&i.Post.MetaDescription,
+			&i.Post.CanonicalUrl,
+			&i.Post.Noindex,
+			&i.Post.OgTitle,
+			&i.Post.OgDescription,
+			&i.Post.OgImage,
+			&i.Post.TwitterCard,
+			&i.Post.TwitterTitle,
+			&i.Post.TwitterDescription,
+			&i.Post.TwitterImage,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSavedPostIDs = `-- name: ListSavedPostIDs :many
+SELECT DISTINCT reading_list_items.post_id
+FROM reading_list_items
+JOIN reading_lists ON reading_lists.id = reading_list_items.list_id
+WHERE reading_lists.user_id = $1
+  AND reading_lists.deleted_at IS NULL
+  AND reading_list_items.post_id = ANY(CAST(CAST($2 AS text) AS bigint[]))
+`
+
+type ListSavedPostIDsParams struct {
+	UserID  int64  `json:"userId"`
+	PostIds string `json:"postIds"`
+}
+
+// ListSavedPostIDs
+//
+//	SELECT DISTINCT reading_list_items.post_id
+//	FROM reading_list_items
+//	JOIN reading_lists ON reading_lists.id = reading_list_items.list_id
+//	WHERE reading_lists.user_id = $1
+//	  AND reading_lists.deleted_at IS NULL
+//	  AND reading_list_items.post_id = ANY(CAST(CAST($2 AS text) AS bigint[]))
+func (q *Queries) ListSavedPostIDs(ctx context.Context, arg ListSavedPostIDsParams) ([]int64, error) {
+	rows, err := q.query(ctx, q.listSavedPostIDsStmt, listSavedPostIDs, arg.UserID, arg.PostIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var post_id int64
+		if err := rows.Scan(&post_id); err != nil {
+			return nil, err
+		}
+		items = append(items, post_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeItem = `-- name: RemoveItem :execrows
+DELETE FROM reading_list_items
+WHERE list_id = $1
+  AND post_id = $2
+`
+
+type RemoveItemParams struct {
+	ListID int64 `json:"listId"`
+	PostID int64 `json:"postId"`
+}
+
+// RemoveItem
+//
+//	DELETE FROM reading_list_items
+//	WHERE list_id = $1
+//	  AND post_id = $2
+func (q *Queries) RemoveItem(ctx context.Context, arg RemoveItemParams) (int64, error) {
+	result, err := q.exec(ctx, q.removeItemStmt, removeItem, arg.ListID, arg.PostID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setItemPosition = `-- name: SetItemPosition :exec
+UPDATE reading_list_items
+SET position = $1
+WHERE list_id = $2
+  AND post_id = $3
+`
+
+type SetItemPositionParams struct {
+	Position int64 `json:"position"`
+	ListID   int64 `json:"listId"`
+	PostID   int64 `json:"postId"`
+}
+
+// SetItemPosition
+//
+//	UPDATE reading_list_items
+//	SET position = $1
+//	WHERE list_id = $2
+//	  AND post_id = $3
+func (q *Queries) SetItemPosition(ctx context.Context, arg SetItemPositionParams) error {
+	_, err := q.exec(ctx, q.setItemPositionStmt, setItemPosition, arg.Position, arg.ListID, arg.PostID)
+	return err
+}
+
+const touch = `-- name: Touch :exec
+UPDATE reading_lists
+SET updated_at = now()
+WHERE id = $1
+  AND deleted_at IS NULL
+`
+
+// Touch
+//
+//	UPDATE reading_lists
+//	SET updated_at = now()
+//	WHERE id = $1
+//	  AND deleted_at IS NULL
+func (q *Queries) Touch(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.touchStmt, touch, id)
+	return err
+}
@@ -0,0 +1,59 @@
+package series
+
+import (
+	"context"
+	"database/sql"
+
+	repositorySeries "github.com/HardDie/blog_engine/internal/repository/sqlite/series"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositorySeries.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) AddPost(ctx context.Context, arg repositorySeries.AddPostParams) error {
+	return a.q.AddPost(ctx, AddPostParams(arg))
+}
+func (a *Adapter) Create(ctx context.Context, arg repositorySeries.CreateParams) (*repositorySeries.Series, error) {
+	row, err := a.q.Create(ctx, CreateParams(arg))
+	return (*repositorySeries.Series)(row), err
+}
+func (a *Adapter) DeletePosts(ctx context.Context, seriesID int64) error {
+	return a.q.DeletePosts(ctx, seriesID)
+}
+func (a *Adapter) GetByID(ctx context.Context, id int64) (*repositorySeries.Series, error) {
+	row, err := a.q.GetByID(ctx, id)
+	return (*repositorySeries.Series)(row), err
+}
+func (a *Adapter) GetByPostID(ctx context.Context, postID int64) (*repositorySeries.Series, error) {
+	row, err := a.q.GetByPostID(ctx, postID)
+	return (*repositorySeries.Series)(row), err
+}
+func (a *Adapter) ListPosts(ctx context.Context, arg repositorySeries.ListPostsParams) ([]*repositorySeries.ListPostsRow, error) {
+	rows, err := a.q.ListPosts(ctx, ListPostsParams(arg))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositorySeries.ListPostsRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, &repositorySeries.ListPostsRow{
+			Post: repositorySeries.Post(row.Post),
+		})
+	}
+	return result, nil
+}
+func (a *Adapter) Touch(ctx context.Context, id int64) error {
+	return a.q.Touch(ctx, id)
+}
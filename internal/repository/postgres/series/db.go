@@ -0,0 +1,148 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package series
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.addPostStmt, err = db.PrepareContext(ctx, addPost); err != nil {
+		return nil, fmt.Errorf("error preparing query AddPost: %w", err)
+	}
+	if q.createStmt, err = db.PrepareContext(ctx, create); err != nil {
+		return nil, fmt.Errorf("error preparing query Create: %w", err)
+	}
+	if q.deletePostsStmt, err = db.PrepareContext(ctx, deletePosts); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePosts: %w", err)
+	}
+	if q.getByIDStmt, err = db.PrepareContext(ctx, getByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetByID: %w", err)
+	}
+	if q.getByPostIDStmt, err = db.PrepareContext(ctx, getByPostID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetByPostID: %w", err)
+	}
+	if q.listPostsStmt, err = db.PrepareContext(ctx, listPosts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPosts: %w", err)
+	}
+	if q.touchStmt, err = db.PrepareContext(ctx, touch); err != nil {
+		return nil, fmt.Errorf("error preparing query Touch: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.addPostStmt != nil {
+		if cerr := q.addPostStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addPostStmt: %w", cerr)
+		}
+	}
+	if q.createStmt != nil {
+		if cerr := q.createStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStmt: %w", cerr)
+		}
+	}
+	if q.deletePostsStmt != nil {
+		if cerr := q.deletePostsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePostsStmt: %w", cerr)
+		}
+	}
+	if q.getByIDStmt != nil {
+		if cerr := q.getByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getByIDStmt: %w", cerr)
+		}
+	}
+	if q.getByPostIDStmt != nil {
+		if cerr := q.getByPostIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getByPostIDStmt: %w", cerr)
+		}
+	}
+	if q.listPostsStmt != nil {
+		if cerr := q.listPostsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPostsStmt: %w", cerr)
+		}
+	}
+	if q.touchStmt != nil {
+		if cerr := q.touchStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing touchStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db              DBTX
+	tx              *sql.Tx
+	addPostStmt     *sql.Stmt
+	createStmt      *sql.Stmt
+	deletePostsStmt *sql.Stmt
+	getByIDStmt     *sql.Stmt
+	getByPostIDStmt *sql.Stmt
+	listPostsStmt   *sql.Stmt
+	touchStmt       *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:              tx,
+		tx:              tx,
+		addPostStmt:     q.addPostStmt,
+		createStmt:      q.createStmt,
+		deletePostsStmt: q.deletePostsStmt,
+		getByIDStmt:     q.getByIDStmt,
+		getByPostIDStmt: q.getByPostIDStmt,
+		listPostsStmt:   q.listPostsStmt,
+		touchStmt:       q.touchStmt,
+	}
+}
@@ -0,0 +1,57 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	repositorySession "github.com/HardDie/blog_engine/internal/repository/sqlite/session"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositorySession.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) CountCreatedAfter(ctx context.Context, createdAt time.Time) (int64, error) {
+	return a.q.CountCreatedAfter(ctx, createdAt)
+}
+func (a *Adapter) DeleteBySessionHash(ctx context.Context, sessionHash string) error {
+	return a.q.DeleteBySessionHash(ctx, sessionHash)
+}
+func (a *Adapter) DeleteByUserID(ctx context.Context, userID int64) (int64, error) {
+	return a.q.DeleteByUserID(ctx, userID)
+}
+func (a *Adapter) DeleteCreatedBefore(ctx context.Context, createdAt time.Time) (int64, error) {
+	return a.q.DeleteCreatedBefore(ctx, createdAt)
+}
+func (a *Adapter) GetBySessionHash(ctx context.Context, sessionHash string) (*repositorySession.Session, error) {
+	row, err := a.q.GetBySessionHash(ctx, sessionHash)
+	return (*repositorySession.Session)(row), err
+}
+func (a *Adapter) List(ctx context.Context) ([]*repositorySession.Session, error) {
+	rows, err := a.q.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositorySession.Session, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, (*repositorySession.Session)(row))
+	}
+	return result, nil
+}
+func (a *Adapter) Put(ctx context.Context, arg repositorySession.PutParams) (*repositorySession.Session, error) {
+	row, err := a.q.Put(ctx, PutParams(arg))
+	return (*repositorySession.Session)(row), err
+}
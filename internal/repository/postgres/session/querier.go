@@ -0,0 +1,55 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package session
+
+import (
+	"context"
+	"time"
+)
+
+type Querier interface {
+	//CountCreatedAfter
+	//
+	//  SELECT count(*)
+	//  FROM sessions
+	//  WHERE created_at > $1
+	CountCreatedAfter(ctx context.Context, createdAt time.Time) (int64, error)
+	//DeleteBySessionHash
+	//
+	//  DELETE FROM sessions
+	//  WHERE session_hash = $1
+	DeleteBySessionHash(ctx context.Context, sessionHash string) error
+	//DeleteByUserID
+	//
+	//  DELETE FROM sessions
+	//  WHERE user_id = $1
+	DeleteByUserID(ctx context.Context, userID int64) (int64, error)
+	//DeleteCreatedBefore
+	//
+	//  DELETE FROM sessions
+	//  WHERE created_at < $1
+	DeleteCreatedBefore(ctx context.Context, createdAt time.Time) (int64, error)
+	//GetBySessionHash
+	//
+	//  SELECT session_hash, user_id, created_at
+	//  FROM sessions
+	//  WHERE session_hash = $1
+	GetBySessionHash(ctx context.Context, sessionHash string) (*Session, error)
+	//List
+	//
+	//  SELECT session_hash, user_id, created_at
+	//  FROM sessions
+	//  ORDER BY created_at
+	List(ctx context.Context) ([]*Session, error)
+	//Put
+	//
+	//  INSERT INTO sessions (session_hash, user_id, created_at)
+	//  VALUES ($1, $2, $3)
+	//  ON CONFLICT (session_hash) DO UPDATE SET user_id = excluded.user_id, created_at = excluded.created_at
+	//  RETURNING session_hash, user_id, created_at
+	Put(ctx context.Context, arg PutParams) (*Session, error)
+}
+
+var _ Querier = (*Queries)(nil)
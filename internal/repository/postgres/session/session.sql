@@ -0,0 +1,32 @@
+-- name: Put :one
+INSERT INTO sessions (session_hash, user_id, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (session_hash) DO UPDATE SET user_id = excluded.user_id, created_at = excluded.created_at
+RETURNING *;
+
+-- name: GetBySessionHash :one
+SELECT *
+FROM sessions
+WHERE session_hash = $1;
+
+-- name: List :many
+SELECT *
+FROM sessions
+ORDER BY created_at;
+
+-- name: CountCreatedAfter :one
+SELECT count(*)
+FROM sessions
+WHERE created_at > $1;
+
+-- name: DeleteBySessionHash :exec
+DELETE FROM sessions
+WHERE session_hash = $1;
+
+-- name: DeleteByUserID :execrows
+DELETE FROM sessions
+WHERE user_id = $1;
+
+-- name: DeleteCreatedBefore :execrows
+DELETE FROM sessions
+WHERE created_at < $1;
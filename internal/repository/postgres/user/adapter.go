@@ -0,0 +1,52 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+
+	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositoryUser.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) Ban(ctx context.Context, id int64) (*repositoryUser.User, error) {
+	row, err := a.q.Ban(ctx, id)
+	return (*repositoryUser.User)(row), err
+}
+func (a *Adapter) CountRegistered(ctx context.Context) (int64, error) {
+	return a.q.CountRegistered(ctx)
+}
+func (a *Adapter) Create(ctx context.Context, arg repositoryUser.CreateParams) (*repositoryUser.User, error) {
+	row, err := a.q.Create(ctx, CreateParams(arg))
+	return (*repositoryUser.User)(row), err
+}
+func (a *Adapter) GetByIDPrivate(ctx context.Context, id int64) (*repositoryUser.User, error) {
+	row, err := a.q.GetByIDPrivate(ctx, id)
+	return (*repositoryUser.User)(row), err
+}
+func (a *Adapter) GetByIDPublic(ctx context.Context, id int64) (*repositoryUser.GetByIDPublicRow, error) {
+	row, err := a.q.GetByIDPublic(ctx, id)
+	return (*repositoryUser.GetByIDPublicRow)(row), err
+}
+func (a *Adapter) GetByName(ctx context.Context, username string) (*repositoryUser.User, error) {
+	row, err := a.q.GetByName(ctx, username)
+	return (*repositoryUser.User)(row), err
+}
+func (a *Adapter) Update(ctx context.Context, arg repositoryUser.UpdateParams) (*repositoryUser.User, error) {
+	row, err := a.q.Update(ctx, UpdateParams(arg))
+	return (*repositoryUser.User)(row), err
+}
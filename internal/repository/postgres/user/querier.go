@@ -0,0 +1,63 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package user
+
+import (
+	"context"
+)
+
+type Querier interface {
+	//Ban
+	//
+	//  UPDATE users
+	//  SET banned_at = now(), updated_at = now()
+	//  WHERE id = $1
+	//    AND deleted_at IS NULL
+	//  RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
+	Ban(ctx context.Context, id int64) (*User, error)
+	//CountRegistered
+	//
+	//  SELECT count(*)
+	//  FROM users
+	//  WHERE id <> 0
+	CountRegistered(ctx context.Context) (int64, error)
+	//Create
+	//
+	//  INSERT INTO users (username, displayed_name, invited_by_user)
+	//  VALUES ($1, $2, $3)
+	//  RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
+	Create(ctx context.Context, arg CreateParams) (*User, error)
+	//GetByIDPrivate
+	//
+	//  SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
+	//  FROM users
+	//  WHERE id = $1
+	//    AND deleted_at IS NULL
+	GetByIDPrivate(ctx context.Context, id int64) (*User, error)
+	//GetByIDPublic
+	//
+	//  SELECT id, displayed_name, invited_by_user, created_at, updated_at, deleted_at
+	//  FROM users
+	//  WHERE id = $1
+	//    AND deleted_at IS NULL
+	GetByIDPublic(ctx context.Context, id int64) (*GetByIDPublicRow, error)
+	//GetByName
+	//
+	//  SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
+	//  FROM users
+	//  WHERE username = $1
+	//    AND deleted_at IS NULL
+	GetByName(ctx context.Context, username string) (*User, error)
+	//Update
+	//
+	//  UPDATE users
+	//  SET displayed_name = $1, email = $2, updated_at = now()
+	//  WHERE id = $3
+	//    AND deleted_at IS NULL
+	//  RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
+	Update(ctx context.Context, arg UpdateParams) (*User, error)
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,41 @@
+-- name: GetByIDPublic :one
+SELECT id, displayed_name, invited_by_user, created_at, updated_at, deleted_at
+FROM users
+WHERE id = $1
+  AND deleted_at IS NULL;
+
+-- name: GetByIDPrivate :one
+SELECT *
+FROM users
+WHERE id = $1
+  AND deleted_at IS NULL;
+
+-- name: GetByName :one
+SELECT *
+FROM users
+WHERE username = $1
+  AND deleted_at IS NULL;
+
+-- name: Create :one
+INSERT INTO users (username, displayed_name, invited_by_user)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: Update :one
+UPDATE users
+SET displayed_name = $1, email = $2, updated_at = now()
+WHERE id = $3
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: Ban :one
+UPDATE users
+SET banned_at = now(), updated_at = now()
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: CountRegistered :one
+SELECT count(*)
+FROM users
+WHERE id <> 0;
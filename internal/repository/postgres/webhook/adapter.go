@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+
+	repositoryWebhook "github.com/HardDie/blog_engine/internal/repository/sqlite/webhook"
+)
+
+// Adapter implements the Querier of the SQLite repository, so services don't depend on the driver.
+type Adapter struct {
+	q *Queries
+}
+
+var _ repositoryWebhook.Querier = (*Adapter)(nil)
+
+func NewAdapter(q *Queries) *Adapter {
+	return &Adapter{
+		q: q,
+	}
+}
+func (a *Adapter) WithTx(tx *sql.Tx) *Adapter {
+	return NewAdapter(a.q.WithTx(tx))
+}
+
+func (a *Adapter) CancelDeliveries(ctx context.Context, webhookID int64) error {
+	return a.q.CancelDeliveries(ctx, webhookID)
+}
+func (a *Adapter) Create(ctx context.Context, arg repositoryWebhook.CreateParams) (*repositoryWebhook.Webhook, error) {
+	row, err := a.q.Create(ctx, CreateParams(arg))
+	return (*repositoryWebhook.Webhook)(row), err
+}
+func (a *Adapter) CreateDelivery(ctx context.Context, arg repositoryWebhook.CreateDeliveryParams) (*repositoryWebhook.WebhookDelivery, error) {
+	row, err := a.q.CreateDelivery(ctx, CreateDeliveryParams(arg))
+	return (*repositoryWebhook.WebhookDelivery)(row), err
+}
+func (a *Adapter) Delete(ctx context.Context, arg repositoryWebhook.DeleteParams) (int64, error) {
+	return a.q.Delete(ctx, DeleteParams(arg))
+}
+func (a *Adapter) GetByID(ctx context.Context, arg repositoryWebhook.GetByIDParams) (*repositoryWebhook.Webhook, error) {
+	row, err := a.q.GetByID(ctx, GetByIDParams(arg))
+	return (*repositoryWebhook.Webhook)(row), err
+}
+func (a *Adapter) GetDelivery(ctx context.Context, arg repositoryWebhook.GetDeliveryParams) (*repositoryWebhook.WebhookDelivery, error) {
+	row, err := a.q.GetDelivery(ctx, GetDeliveryParams(arg))
+	return (*repositoryWebhook.WebhookDelivery)(row), err
+}
+func (a *Adapter) GetPendingDelivery(ctx context.Context, id int64) (*repositoryWebhook.GetPendingDeliveryRow, error) {
+	row, err := a.q.GetPendingDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &repositoryWebhook.GetPendingDeliveryRow{
+		WebhookDelivery: repositoryWebhook.WebhookDelivery(row.WebhookDelivery),
+		Url:             row.Url,
+		Secret:          row.Secret,
+	}, nil
+}
+func (a *Adapter) ListByUser(ctx context.Context, userID int64) ([]*repositoryWebhook.Webhook, error) {
+	rows, err := a.q.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositoryWebhook.Webhook, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, (*repositoryWebhook.Webhook)(row))
+	}
+	return result, nil
+}
+func (a *Adapter) ListDeliveries(ctx context.Context, arg repositoryWebhook.ListDeliveriesParams) ([]*repositoryWebhook.ListDeliveriesRow, error) {
+	rows, err := a.q.ListDeliveries(ctx, ListDeliveriesParams(arg))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*repositoryWebhook.ListDeliveriesRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, &repositoryWebhook.ListDeliveriesRow{
+			WebhookDelivery: repositoryWebhook.WebhookDelivery(row.WebhookDelivery),
+			Count:           row.Count,
+		})
+	}
+	return result, nil
+}
+func (a *Adapter) MarkDeliveryFailed(ctx context.Context, arg repositoryWebhook.MarkDeliveryFailedParams) error {
+	return a.q.MarkDeliveryFailed(ctx, MarkDeliveryFailedParams(arg))
+}
+func (a *Adapter) MarkDeliveryRetry(ctx context.Context, arg repositoryWebhook.MarkDeliveryRetryParams) error {
+	return a.q.MarkDeliveryRetry(ctx, MarkDeliveryRetryParams(arg))
+}
+func (a *Adapter) MarkDeliverySucceeded(ctx context.Context, arg repositoryWebhook.MarkDeliverySucceededParams) error {
+	return a.q.MarkDeliverySucceeded(ctx, MarkDeliverySucceededParams(arg))
+}
@@ -0,0 +1,74 @@
+-- name: Create :one
+INSERT INTO webhooks (user_id, url, secret, events)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetByID :one
+SELECT *
+FROM webhooks
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: ListByUser :many
+SELECT *
+FROM webhooks
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: Delete :execrows
+UPDATE webhooks
+SET deleted_at = now()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: CreateDelivery :one
+INSERT INTO webhook_deliveries (webhook_id, event, payload)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetDelivery :one
+SELECT *
+FROM webhook_deliveries
+WHERE id = $1
+  AND webhook_id = $2;
+
+-- name: ListDeliveries :many
+SELECT sqlc.embed(webhook_deliveries), count(*) over()
+FROM webhook_deliveries
+WHERE webhook_id = sqlc.arg(webhook_id)
+ORDER BY id DESC
+LIMIT CASE WHEN CAST(sqlc.arg('limit') AS bigint) > 0 THEN sqlc.arg('limit') ELSE 10 END
+OFFSET CAST(sqlc.arg('offset') AS bigint);
+
+-- name: GetPendingDelivery :one
+SELECT sqlc.embed(webhook_deliveries), webhooks.url, webhooks.secret
+FROM webhook_deliveries
+JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+WHERE webhook_deliveries.id = $1
+  AND webhook_deliveries.status = 'pending'
+  AND webhooks.deleted_at IS NULL;
+
+-- name: MarkDeliverySucceeded :exec
+UPDATE webhook_deliveries
+SET status = 'succeeded', attempts = attempts + 1, response_status = $1, error = NULL, updated_at = now()
+WHERE id = $2;
+
+-- name: MarkDeliveryRetry :exec
+UPDATE webhook_deliveries
+SET attempts = attempts + 1, response_status = sqlc.narg(response_status), error = sqlc.narg(error),
+    next_attempt_at = now() + CAST(CAST(sqlc.arg(delay) AS text) AS interval), updated_at = now()
+WHERE id = sqlc.arg(id);
+
+-- name: MarkDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET status = 'failed', attempts = attempts + 1, response_status = $1, error = $2, updated_at = now()
+WHERE id = $3;
+
+-- name: CancelDeliveries :exec
+UPDATE webhook_deliveries
+SET status = 'failed', error = 'webhook was deleted', updated_at = now()
+WHERE webhook_id = $1
+  AND status = 'pending';
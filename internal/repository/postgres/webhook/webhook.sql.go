@@ -0,0 +1,433 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: webhook.sql
+
+package webhook
+
+import (
+	"context"
+	"database/sql"
+)
+
+const cancelDeliveries = `-- name: CancelDeliveries :exec
+UPDATE webhook_deliveries
+SET status = 'failed', error = 'webhook was deleted', updated_at = now()
+WHERE webhook_id = $1
+  AND status = 'pending'
+`
+
+// CancelDeliveries
+//
+//	UPDATE webhook_deliveries
+//	SET status = 'failed', error = 'webhook was deleted', updated_at = now()
+//	WHERE webhook_id = $1
+//	  AND status = 'pending'
+func (q *Queries) CancelDeliveries(ctx context.Context, webhookID int64) error {
+	_, err := q.exec(ctx, q.cancelDeliveriesStmt, cancelDeliveries, webhookID)
+	return err
+}
+
+const create = `-- name: Create :one
+INSERT INTO webhooks (user_id, url, secret, events)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, url, secret, events, created_at, updated_at, deleted_at
+`
+
+type CreateParams struct {
+	UserID int64  `json:"userId"`
+	Url    string `json:"url"`
+	Secret string `json:"secret"`
+	Events string `json:"events"`
+}
+
+// Create
+//
+//	INSERT INTO webhooks (user_id, url, secret, events)
+//	VALUES ($1, $2, $3, $4)
+//	RETURNING id, user_id, url, secret, events, created_at, updated_at, deleted_at
+func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Webhook, error) {
+	row := q.queryRow(ctx, q.createStmt, create,
+		arg.UserID,
+		arg.Url,
+		arg.Secret,
+		arg.Events,
+	)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return &i, err
+}
+
+const createDelivery = `-- name: CreateDelivery :one
+INSERT INTO webhook_deliveries (webhook_id, event, payload)
+VALUES ($1, $2, $3)
+RETURNING id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status, error, created_at, updated_at
+`
+
+type CreateDeliveryParams struct {
+	WebhookID int64  `json:"webhookId"`
+	Event     string `json:"event"`
+	Payload   string `json:"payload"`
+}
+
+// CreateDelivery
+//
+//	INSERT INTO webhook_deliveries (webhook_id, event, payload)
+//	VALUES ($1, $2, $3)
+//	RETURNING id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status, error, created_at, updated_at
+func (q *Queries) CreateDelivery(ctx context.Context, arg CreateDeliveryParams) (*WebhookDelivery, error) {
+	row := q.queryRow(ctx, q.createDeliveryStmt, createDelivery, arg.WebhookID, arg.Event, arg.Payload)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.WebhookID,
+		&i.Event,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.ResponseStatus,
+		&i.Error,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const delete = `-- name: Delete :execrows
+UPDATE webhooks
+SET deleted_at = now()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+`
+
+type DeleteParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"userId"`
+}
+
+// Delete
+//
+//	UPDATE webhooks
+//	SET deleted_at = now()
+//	WHERE id = $1
+//	  AND user_id = $2
+//	  AND deleted_at IS NULL
+func (q *Queries) Delete(ctx context.Context, arg DeleteParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteStmt, delete, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getByID = `-- name: GetByID :one
+SELECT id, user_id, url, secret, events, created_at, updated_at, deleted_at
+FROM webhooks
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+`
+
+type GetByIDParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"userId"`
+}
+
+// GetByID
+//
+//	SELECT id, user_id, url, secret, events, created_at, updated_at, deleted_at
+//	FROM webhooks
+//	WHERE id = $1
+//	  AND user_id = $2
+//	  AND deleted_at IS NULL
+func (q *Queries) GetByID(ctx context.Context, arg GetByIDParams) (*Webhook, error) {
+	row := q.queryRow(ctx, q.getByIDStmt, getByID, arg.ID, arg.UserID)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return &i, err
+}
+
+const getDelivery = `-- name: GetDelivery :one
+SELECT id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status, error, created_at, updated_at
+FROM webhook_deliveries
+WHERE id = $1
+  AND webhook_id = $2
+`
+
+type GetDeliveryParams struct {
+	ID        int64 `json:"id"`
+	WebhookID int64 `json:"webhookId"`
+}
+
+// GetDelivery
+//
+//	SELECT id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status, error, created_at, updated_at
+//	FROM webhook_deliveries
+//	WHERE id = $1
+//	  AND webhook_id = $2
+func (q *Queries) GetDelivery(ctx context.Context, arg GetDeliveryParams) (*WebhookDelivery, error) {
+	row := q.queryRow(ctx, q.getDeliveryStmt, getDelivery, arg.ID, arg.WebhookID)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.WebhookID,
+		&i.Event,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.ResponseStatus,
+		&i.Error,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const getPendingDelivery = `-- name: GetPendingDelivery :one
+SELECT webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.event, webhook_deliveries.payload, webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at, webhook_deliveries.response_status, webhook_deliveries.error, webhook_deliveries.created_at, webhook_deliveries.updated_at, webhooks.url, webhooks.secret
+FROM webhook_deliveries
+JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+WHERE webhook_deliveries.id = $1
+  AND webhook_deliveries.status = 'pending'
+  AND webhooks.deleted_at IS NULL
+`
+
+type GetPendingDeliveryRow struct {
+	WebhookDelivery WebhookDelivery `json:"webhookDelivery"`
+	Url             string          `json:"url"`
+	Secret          string          `json:"secret"`
+}
+
+// GetPendingDelivery
+//
+//	SELECT webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.event, webhook_deliveries.payload, webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at, webhook_deliveries.response_status, webhook_deliveries.error, webhook_deliveries.created_at, webhook_deliveries.updated_at, webhooks.url, webhooks.secret
+//	FROM webhook_deliveries
+//	JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+//	WHERE webhook_deliveries.id = $1
+//	  AND webhook_deliveries.status = 'pending'
+//	  AND webhooks.deleted_at IS NULL
+func (q *Queries) GetPendingDelivery(ctx context.Context, id int64) (*GetPendingDeliveryRow, error) {
+	row := q.queryRow(ctx, q.getPendingDeliveryStmt, getPendingDelivery, id)
+	var i GetPendingDeliveryRow
+	err := row.Scan(
+		&i.WebhookDelivery.ID,
+		&i.WebhookDelivery.WebhookID,
+		&i.WebhookDelivery.Event,
+		&i.WebhookDelivery.Payload,
+		&i.WebhookDelivery.Status,
+		&i.WebhookDelivery.Attempts,
+		&i.WebhookDelivery.NextAttemptAt,
+		&i.WebhookDelivery.ResponseStatus,
+		&i.WebhookDelivery.Error,
+		&i.WebhookDelivery.CreatedAt,
+		&i.WebhookDelivery.UpdatedAt,
+		&i.Url,
+		&i.Secret,
+	)
+	return &i, err
+}
+
+const listByUser = `-- name: ListByUser :many
+SELECT id, user_id, url, secret, events, created_at, updated_at, deleted_at
+FROM webhooks
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY id
+`
+
+// ListByUser
+//
+//	SELECT id, user_id, url, secret, events, created_at, updated_at, deleted_at
+//	FROM webhooks
+//	WHERE user_id = $1
+//	  AND deleted_at IS NULL
+//	ORDER BY id
+func (q *Queries) ListByUser(ctx context.Context, userID int64) ([]*Webhook, error) {
+	rows, err := q.query(ctx, q.listByUserStmt, listByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Webhook{}
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Url,
+			&i.Secret,
+			&i.Events,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeliveries = `-- name: ListDeliveries :many
+SELECT webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.event, webhook_deliveries.payload, webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at, webhook_deliveries.response_status, webhook_deliveries.error, webhook_deliveries.created_at, webhook_deliveries.updated_at, count(*) over()
+FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY id DESC
+LIMIT CASE WHEN CAST($3 AS bigint) > 0 THEN $3 ELSE 10 END
+OFFSET CAST($2 AS bigint)
+`
+
+type ListDeliveriesParams struct {
+	WebhookID int64 `json:"webhookId"`
+	Offset    int64 `json:"offset"`
+	Limit     int64 `json:"limit"`
+}
+
+type ListDeliveriesRow struct {
+	WebhookDelivery WebhookDelivery `json:"webhookDelivery"`
+	Count           int64           `json:"count"`
+}
+
+// ListDeliveries
+//
+//	SELECT webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.event, webhook_deliveries.payload, webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at, webhook_deliveries.response_status, webhook_deliveries.error, webhook_deliveries.created_at, webhook_deliveries.updated_at, count(*) over()
+//	FROM webhook_deliveries
+//	WHERE webhook_id = $1
+//	ORDER BY id DESC
+//	LIMIT CASE WHEN CAST($3 AS bigint) > 0 THEN $3 ELSE 10 END
+//	OFFSET CAST($2 AS bigint)
+func (q *Queries) ListDeliveries(ctx context.Context, arg ListDeliveriesParams) ([]*ListDeliveriesRow, error) {
+	rows, err := q.query(ctx, q.listDeliveriesStmt, listDeliveries, arg.WebhookID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListDeliveriesRow{}
+	for rows.Next() {
+		var i ListDeliveriesRow
+		if err := rows.Scan(
+			&i.WebhookDelivery.ID,
+			&i.WebhookDelivery.WebhookID,
+			&i.WebhookDelivery.Event,
+			&i.WebhookDelivery.Payload,
+			&i.WebhookDelivery.Status,
+			&i.WebhookDelivery.Attempts,
+			&i.WebhookDelivery.NextAttemptAt,
+			&i.WebhookDelivery.ResponseStatus,
+			&i.WebhookDelivery.Error,
+			&i.WebhookDelivery.CreatedAt,
+			&i.WebhookDelivery.UpdatedAt,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markDeliveryFailed = `-- name: MarkDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET status = 'failed', attempts = attempts + 1, response_status = $1, error = $2, updated_at = now()
+WHERE id = $3
+`
+
+type MarkDeliveryFailedParams struct {
+	ResponseStatus sql.NullInt64  `json:"responseStatus"`
+	Error          sql.NullString `json:"error"`
+	ID             int64          `json:"id"`
+}
+
+// MarkDeliveryFailed
+//
+//	UPDATE webhook_deliveries
+//	SET status = 'failed', attempts = attempts + 1, response_status = $1, error = $2, updated_at = now()
+//	WHERE id = $3
+func (q *Queries) MarkDeliveryFailed(ctx context.Context, arg MarkDeliveryFailedParams) error {
+	_, err := q.exec(ctx, q.markDeliveryFailedStmt, markDeliveryFailed, arg.ResponseStatus, arg.Error, arg.ID)
+	return err
+}
+
+const markDeliveryRetry = `-- name: MarkDeliveryRetry :exec
+UPDATE webhook_deliveries
+SET attempts = attempts + 1, response_status = $1, error = $2,
+    next_attempt_at = now() + CAST(CAST($3 AS text) AS interval), updated_at = now()
+WHERE id = $4
+`
+
+type MarkDeliveryRetryParams struct {
+	ResponseStatus sql.NullInt64  `json:"responseStatus"`
+	Error          sql.NullString `json:"error"`
+	Delay          string         `json:"delay"`
+	ID             int64          `json:"id"`
+}
+
+// MarkDeliveryRetry
+//
+//	UPDATE webhook_deliveries
+//	SET attempts = attempts + 1, response_status = $1, error = $2,
+//	    next_attempt_at = now() + CAST(CAST($3 AS text) AS interval), updated_at = now()
+//	WHERE id = $4
+func (q *Queries) MarkDeliveryRetry(ctx context.Context, arg MarkDeliveryRetryParams) error {
+	_, err := q.exec(ctx, q.markDeliveryRetryStmt, markDeliveryRetry,
+		arg.ResponseStatus,
+		arg.Error,
+		arg.Delay,
+		arg.ID,
+	)
+	return err
+}
+
+const markDeliverySucceeded = `-- name: MarkDeliverySucceeded :exec
+UPDATE webhook_deliveries
+SET status = 'succeeded', attempts = attempts + 1, response_status = $1, error = NULL, updated_at = now()
+WHERE id = $2
+`
+
+type MarkDeliverySucceededParams struct {
+	ResponseStatus sql.NullInt64 `json:"responseStatus"`
+	ID             int64         `json:"id"`
+}
+
+// MarkDeliverySucceeded
+//
+//	UPDATE webhook_deliveries
+//	SET status = 'succeeded', attempts = attempts + 1, response_status = $1, error = NULL, updated_at = now()
+//	WHERE id = $2
+func (q *Queries) MarkDeliverySucceeded(ctx context.Context, arg MarkDeliverySucceededParams) error {
+	_, err := q.exec(ctx, q.markDeliverySucceededStmt, markDeliverySucceeded, arg.ResponseStatus, arg.ID)
+	return err
+}
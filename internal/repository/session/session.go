@@ -0,0 +1,53 @@
+// Package session has what is common to the stores of sessions, the store is chosen by SESSION_STORE.
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/models"
+)
+
+// Names of the stores for SESSION_STORE
+const (
+	StoreBoltDB = "boltdb"
+	StoreDB     = "db"
+	StoreMemory = "memory"
+)
+
+// Store is implemented by every store. The auth service uses a part of it, List and Put move sessions between stores.
+type Store interface {
+	CreateOrUpdate(ctx context.Context, userID int64, sessionHash string) (*models.Session, error)
+	DeleteBySessionHash(ctx context.Context, sessionHash string) error
+	GetBySessionHash(ctx context.Context, sessionHash string) (*models.Session, error)
+	CountCreatedAfter(ctx context.Context, createdAfter time.Time) (int64, error)
+	DeleteByUserID(ctx context.Context, userID int64) (int64, error)
+	DeleteCreatedBefore(ctx context.Context, createdBefore time.Time) (int64, error)
+
+	// List returns all sessions, expired ones included
+	List(ctx context.Context) ([]*models.Session, error)
+	// Put saves the session as is, keeping the time of the creation
+	Put(ctx context.Context, ses *models.Session) error
+}
+
+// Copy puts every session of the source into the destination with the same hash and creation time,
+// so users stay logged in after the switch of the store. The source is not changed.
+func Copy(ctx context.Context, from, to Store) (int64, error) {
+	list, err := from.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Session.Copy() List: %w", err)
+	}
+	for _, ses := range list {
+		err = to.Put(ctx, ses)
+		if err != nil {
+			return 0, fmt.Errorf("Session.Copy() Put: %w", err)
+		}
+	}
+	return int64(len(list)), nil
+}
+
+var (
+	ErrorNotFound = errors.New("session not found")
+)
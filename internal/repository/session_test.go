@@ -0,0 +1,212 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/boltdb"
+	"github.com/HardDie/blog_engine/internal/db"
+	"github.com/HardDie/blog_engine/internal/models"
+	boltdbSession "github.com/HardDie/blog_engine/internal/repository/boltdb/session"
+	memorySession "github.com/HardDie/blog_engine/internal/repository/memory/session"
+	postgresSession "github.com/HardDie/blog_engine/internal/repository/postgres/session"
+	repositorySession "github.com/HardDie/blog_engine/internal/repository/session"
+	sqliteSession "github.com/HardDie/blog_engine/internal/repository/sqlite/session"
+)
+
+// forEachSessionStore runs the test against every store of SESSION_STORE, the table of the main DB with both drivers.
+func forEachSessionStore(t *testing.T, test func(t *testing.T, store repositorySession.Store)) {
+	t.Run(repositorySession.StoreBoltDB, func(t *testing.T) {
+		test(t, openBoltDBSessions(t))
+	})
+	t.Run(repositorySession.StoreMemory, func(t *testing.T) {
+		test(t, memorySession.New())
+	})
+	t.Run(repositorySession.StoreDB+"/"+db.DriverSQLite, func(t *testing.T) {
+		test(t, openSQLiteSessions(t))
+	})
+	t.Run(repositorySession.StoreDB+"/"+db.DriverPostgres, func(t *testing.T) {
+		database := openPostgres(t)
+		migrate(t, database)
+		test(t, sqliteSession.NewStore(postgresSession.NewAdapter(postgresSession.New(database))))
+	})
+}
+
+func openBoltDBSessions(t *testing.T) repositorySession.Store {
+	t.Helper()
+
+	boltDB, err := boltdb.Get(filepath.Join(t.TempDir(), "sessions.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { boltDB.Close() })
+	return boltdbSession.New(boltDB)
+}
+func openSQLiteSessions(t *testing.T) repositorySession.Store {
+	t.Helper()
+
+	database, err := db.Get(filepath.Join(t.TempDir(), "blog.db"), 2, 5000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	migrate(t, database)
+	return sqliteSession.NewStore(sqliteSession.New(database))
+}
+
+func TestSessionStore(t *testing.T) {
+	forEachSessionStore(t, func(t *testing.T, store repositorySession.Store) {
+		ctx := context.Background()
+		start := time.Now().Add(-time.Second)
+
+		_, err := store.GetBySessionHash(ctx, "missing")
+		if !errors.Is(err, repositorySession.ErrorNotFound) {
+			t.Fatalf("expected ErrorNotFound, got %v", err)
+		}
+
+		created, err := store.CreateOrUpdate(ctx, 1, "alice-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created.UserID != 1 || created.SessionHash != "alice-1" || created.CreatedAt.Before(start) {
+			t.Fatalf("unexpected created session %+v", created)
+		}
+		ses, err := store.GetBySessionHash(ctx, "alice-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ses.UserID != 1 || !ses.CreatedAt.Equal(created.CreatedAt) {
+			t.Fatalf("got %+v, expected %+v", ses, created)
+		}
+
+		// The same hash is replaced
+		_, err = store.CreateOrUpdate(ctx, 2, "alice-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ses, err = store.GetBySessionHash(ctx, "alice-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ses.UserID != 2 {
+			t.Fatalf("the session is not replaced: %+v", ses)
+		}
+
+		err = store.DeleteBySessionHash(ctx, "alice-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = store.GetBySessionHash(ctx, "alice-1")
+		if !errors.Is(err, repositorySession.ErrorNotFound) {
+			t.Fatalf("the session is not deleted: %v", err)
+		}
+		// Deleting a missing session is not an error, logout can be repeated
+		err = store.DeleteBySessionHash(ctx, "alice-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestSessionStoreDelete(t *testing.T) {
+	forEachSessionStore(t, func(t *testing.T, store repositorySession.Store) {
+		ctx := context.Background()
+		now := time.Now()
+
+		for _, ses := range []*models.Session{
+			{UserID: 1, SessionHash: "alice-old", CreatedAt: now.Add(-48 * time.Hour)},
+			{UserID: 1, SessionHash: "alice-new", CreatedAt: now.Add(-time.Hour)},
+			{UserID: 2, SessionHash: "bob-old", CreatedAt: now.Add(-30 * time.Hour)},
+			{UserID: 2, SessionHash: "bob-new", CreatedAt: now.Add(-time.Minute)},
+			{UserID: 3, SessionHash: "carol", CreatedAt: now},
+		} {
+			err := store.Put(ctx, ses)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		count, err := store.CountCreatedAfter(ctx, now.Add(-24*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 3 {
+			t.Fatalf("expected 3 active sessions, got %d", count)
+		}
+
+		deleted, err := store.DeleteCreatedBefore(ctx, now.Add(-24*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted != 2 {
+			t.Fatalf("expected 2 expired sessions, got %d", deleted)
+		}
+		deleted, err = store.DeleteByUserID(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted != 1 {
+			t.Fatalf("expected 1 session of the user, got %d", deleted)
+		}
+
+		list, err := store.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashes := make(map[string]bool)
+		for _, ses := range list {
+			hashes[ses.SessionHash] = true
+		}
+		if len(hashes) != 2 || !hashes["bob-new"] || !hashes["carol"] {
+			t.Fatalf("unexpected remaining sessions %v", hashes)
+		}
+	})
+}
+
+func TestSessionCopy(t *testing.T) {
+	forEachSessionStore(t, func(t *testing.T, store repositorySession.Store) {
+		ctx := context.Background()
+		createdAt := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+
+		src := openBoltDBSessions(t)
+		for _, hash := range []string{"alice", "bob"} {
+			err := src.Put(ctx, &models.Session{UserID: 1, SessionHash: hash, CreatedAt: createdAt})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		count, err := repositorySession.Copy(ctx, src, store)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 2 {
+			t.Fatalf("expected 2 copied sessions, got %d", count)
+		}
+		// The copy can be repeated, sessions are replaced
+		_, err = repositorySession.Copy(ctx, src, store)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, hash := range []string{"alice", "bob"} {
+			ses, err := store.GetBySessionHash(ctx, hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ses.UserID != 1 || !ses.CreatedAt.Equal(createdAt) {
+				t.Fatalf("the session is changed by the copy: %+v", ses)
+			}
+		}
+		list, err := store.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != 2 {
+			t.Fatalf("expected 2 sessions, got %d", len(list))
+		}
+	})
+}
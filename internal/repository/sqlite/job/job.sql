@@ -0,0 +1,46 @@
+-- name: Create :one
+INSERT INTO jobs (type, payload, max_attempts, run_at)
+VALUES (sqlc.arg(type), sqlc.arg(payload), sqlc.arg(max_attempts), datetime('now', CAST(sqlc.arg(delay) AS text)))
+RETURNING *;
+
+-- name: Claim :one
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, updated_at = datetime('now')
+WHERE id = (SELECT id
+            FROM jobs
+            WHERE status = 'pending'
+              AND run_at <= datetime('now')
+            ORDER BY run_at, id
+            LIMIT 1)
+RETURNING *;
+
+-- name: Delete :exec
+DELETE FROM jobs
+WHERE id = ?;
+
+-- name: Retry :exec
+UPDATE jobs
+SET status = 'pending', last_error = sqlc.arg(last_error),
+    run_at = datetime('now', CAST(sqlc.arg(delay) AS text)), updated_at = datetime('now')
+WHERE id = sqlc.arg(id);
+
+-- name: MarkDead :exec
+UPDATE jobs
+SET status = 'dead', last_error = ?, updated_at = datetime('now')
+WHERE id = ?;
+
+-- name: ResetRunning :execrows
+UPDATE jobs
+SET status = 'pending', updated_at = datetime('now')
+WHERE status = 'running';
+
+-- name: CountPendingByType :one
+SELECT count(*)
+FROM jobs
+WHERE type = ?
+  AND status IN ('pending', 'running');
+
+-- name: PurgeDead :execrows
+DELETE FROM jobs
+WHERE status = 'dead'
+  AND updated_at < datetime('now', CAST(sqlc.arg(age) AS text));
@@ -0,0 +1,210 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: job.sql
+
+package job
+
+import (
+	"context"
+	"database/sql"
+)
+
+const claim = `-- name: Claim :one
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, updated_at = datetime('now')
+WHERE id = (SELECT id
+            FROM jobs
+            WHERE status = 'pending'
+              AND run_at <= datetime('now')
+            ORDER BY run_at, id
+            LIMIT 1)
+RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at
+`
+
+// Claim
+//
+//	UPDATE jobs
+//	SET status = 'running', attempts = attempts + 1, updated_at = datetime('now')
+//	WHERE id = (SELECT id
+//	            FROM jobs
+//	            WHERE status = 'pending'
+//	              AND run_at <= datetime('now')
+//	            ORDER BY run_at, id
+//	            LIMIT 1)
+//	RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at
+func (q *Queries) Claim(ctx context.Context) (*Job, error) {
+	row := q.queryRow(ctx, q.claimStmt, claim)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const countPendingByType = `-- name: CountPendingByType :one
+SELECT count(*)
+FROM jobs
+WHERE type = ?
+  AND status IN ('pending', 'running')
+`
+
+// CountPendingByType
+//
+//	SELECT count(*)
+//	FROM jobs
+//	WHERE type = ?
+//	  AND status IN ('pending', 'running')
+func (q *Queries) CountPendingByType(ctx context.Context, type_ string) (int64, error) {
+	row := q.queryRow(ctx, q.countPendingByTypeStmt, countPendingByType, type_)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const create = `-- name: Create :one
+INSERT INTO jobs (type, payload, max_attempts, run_at)
+VALUES (?1, ?2, ?3, datetime('now', CAST(?4 AS text)))
+RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at
+`
+
+type CreateParams struct {
+	Type        string `json:"type"`
+	Payload     string `json:"payload"`
+	MaxAttempts int64  `json:"maxAttempts"`
+	Delay       string `json:"delay"`
+}
+
+// Create
+//
+//	INSERT INTO jobs (type, payload, max_attempts, run_at)
+//	VALUES (?1, ?2, ?3, datetime('now', CAST(?4 AS text)))
+//	RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at
+func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Job, error) {
+	row := q.queryRow(ctx, q.createStmt, create,
+		arg.Type,
+		arg.Payload,
+		arg.MaxAttempts,
+		arg.Delay,
+	)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const delete = `-- name: Delete :exec
+DELETE FROM jobs
+WHERE id = ?
+`
+
+// Delete
+//
+//	DELETE FROM jobs
+//	WHERE id = ?
+func (q *Queries) Delete(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deleteStmt, delete, id)
+	return err
+}
+
+const markDead = `-- name: MarkDead :exec
+UPDATE jobs
+SET status = 'dead', last_error = ?, updated_at = datetime('now')
+WHERE id = ?
+`
+
+type MarkDeadParams struct {
+	LastError sql.NullString `json:"lastError"`
+	ID        int64          `json:"id"`
+}
+
+// MarkDead
+//
+//	UPDATE jobs
+//	SET status = 'dead', last_error = ?, updated_at = datetime('now')
+//	WHERE id = ?
+func (q *Queries) MarkDead(ctx context.Context, arg MarkDeadParams) error {
+	_, err := q.exec(ctx, q.markDeadStmt, markDead, arg.LastError, arg.ID)
+	return err
+}
+
+const purgeDead = `-- name: PurgeDead :execrows
+DELETE FROM jobs
+WHERE status = 'dead'
+  AND updated_at < datetime('now', CAST(?1 AS text))
+`
+
+// PurgeDead
+//
+//	DELETE FROM jobs
+//	WHERE status = 'dead'
+//	  AND updated_at < datetime('now', CAST(?1 AS text))
+func (q *Queries) PurgeDead(ctx context.Context, age string) (int64, error) {
+	result, err := q.exec(ctx, q.purgeDeadStmt, purgeDead, age)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const resetRunning = `-- name: ResetRunning :execrows
+UPDATE jobs
+SET status = 'pending', updated_at = datetime('now')
+WHERE status = 'running'
+`
+
+// ResetRunning
+//
+//	UPDATE jobs
+//	SET status = 'pending', updated_at = datetime('now')
+//	WHERE status = 'running'
+func (q *Queries) ResetRunning(ctx context.Context) (int64, error) {
+	result, err := q.exec(ctx, q.resetRunningStmt, resetRunning)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const retry = `-- name: Retry :exec
+UPDATE jobs
+SET status = 'pending', last_error = ?1,
+    run_at = datetime('now', CAST(?2 AS text)), updated_at = datetime('now')
+WHERE id = ?3
+`
+
+type RetryParams struct {
+	LastError sql.NullString `json:"lastError"`
+	Delay     string         `json:"delay"`
+	ID        int64          `json:"id"`
+}
+
+// Retry
+//
+//	UPDATE jobs
+//	SET status = 'pending', last_error = ?1,
+//	    run_at = datetime('now', CAST(?2 AS text)), updated_at = datetime('now')
+//	WHERE id = ?3
+func (q *Queries) Retry(ctx context.Context, arg RetryParams) error {
+	_, err := q.exec(ctx, q.retryStmt, retry, arg.LastError, arg.Delay, arg.ID)
+	return err
+}
@@ -0,0 +1,69 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package job
+
+import (
+	"context"
+)
+
+type Querier interface {
+	//Claim
+	//
+	//  UPDATE jobs
+	//  SET status = 'running', attempts = attempts + 1, updated_at = datetime('now')
+	//  WHERE id = (SELECT id
+	//              FROM jobs
+	//              WHERE status = 'pending'
+	//                AND run_at <= datetime('now')
+	//              ORDER BY run_at, id
+	//              LIMIT 1)
+	//  RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at
+	Claim(ctx context.Context) (*Job, error)
+	//CountPendingByType
+	//
+	//  SELECT count(*)
+	//  FROM jobs
+	//  WHERE type = ?
+	//    AND status IN ('pending', 'running')
+	CountPendingByType(ctx context.Context, type_ string) (int64, error)
+	//Create
+	//
+	//  INSERT INTO jobs (type, payload, max_attempts, run_at)
+	//  VALUES (?1, ?2, ?3, datetime('now', CAST(?4 AS text)))
+	//  RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at
+	Create(ctx context.Context, arg CreateParams) (*Job, error)
+	//Delete
+	//
+	//  DELETE FROM jobs
+	//  WHERE id = ?
+	Delete(ctx context.Context, id int64) error
+	//MarkDead
+	//
+	//  UPDATE jobs
+	//  SET status = 'dead', last_error = ?, updated_at = datetime('now')
+	//  WHERE id = ?
+	MarkDead(ctx context.Context, arg MarkDeadParams) error
+	//PurgeDead
+	//
+	//  DELETE FROM jobs
+	//  WHERE status = 'dead'
+	//    AND updated_at < datetime('now', CAST(?1 AS text))
+	PurgeDead(ctx context.Context, age string) (int64, error)
+	//ResetRunning
+	//
+	//  UPDATE jobs
+	//  SET status = 'pending', updated_at = datetime('now')
+	//  WHERE status = 'running'
+	ResetRunning(ctx context.Context) (int64, error)
+	//Retry
+	//
+	//  UPDATE jobs
+	//  SET status = 'pending', last_error = ?1,
+	//      run_at = datetime('now', CAST(?2 AS text)), updated_at = datetime('now')
+	//  WHERE id = ?3
+	Retry(ctx context.Context, arg RetryParams) error
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,158 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.countUnreadStmt, err = db.PrepareContext(ctx, countUnread); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUnread: %w", err)
+	}
+	if q.createStmt, err = db.PrepareContext(ctx, create); err != nil {
+		return nil, fmt.Errorf("error preparing query Create: %w", err)
+	}
+	if q.getPreferenceStmt, err = db.PrepareContext(ctx, getPreference); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPreference: %w", err)
+	}
+	if q.listStmt, err = db.PrepareContext(ctx, list); err != nil {
+		return nil, fmt.Errorf("error preparing query List: %w", err)
+	}
+	if q.listPreferencesStmt, err = db.PrepareContext(ctx, listPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPreferences: %w", err)
+	}
+	if q.markAllReadStmt, err = db.PrepareContext(ctx, markAllRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkAllRead: %w", err)
+	}
+	if q.markReadStmt, err = db.PrepareContext(ctx, markRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkRead: %w", err)
+	}
+	if q.upsertPreferenceStmt, err = db.PrepareContext(ctx, upsertPreference); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertPreference: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.countUnreadStmt != nil {
+		if cerr := q.countUnreadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUnreadStmt: %w", cerr)
+		}
+	}
+	if q.createStmt != nil {
+		if cerr := q.createStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStmt: %w", cerr)
+		}
+	}
+	if q.getPreferenceStmt != nil {
+		if cerr := q.getPreferenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPreferenceStmt: %w", cerr)
+		}
+	}
+	if q.listStmt != nil {
+		if cerr := q.listStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStmt: %w", cerr)
+		}
+	}
+	if q.listPreferencesStmt != nil {
+		if cerr := q.listPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPreferencesStmt: %w", cerr)
+		}
+	}
+	if q.markAllReadStmt != nil {
+		if cerr := q.markAllReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markAllReadStmt: %w", cerr)
+		}
+	}
+	if q.markReadStmt != nil {
+		if cerr := q.markReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markReadStmt: %w", cerr)
+		}
+	}
+	if q.upsertPreferenceStmt != nil {
+		if cerr := q.upsertPreferenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertPreferenceStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db                   DBTX
+	tx                   *sql.Tx
+	countUnreadStmt      *sql.Stmt
+	createStmt           *sql.Stmt
+	getPreferenceStmt    *sql.Stmt
+	listStmt             *sql.Stmt
+	listPreferencesStmt  *sql.Stmt
+	markAllReadStmt      *sql.Stmt
+	markReadStmt         *sql.Stmt
+	upsertPreferenceStmt *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:                   tx,
+		tx:                   tx,
+		countUnreadStmt:      q.countUnreadStmt,
+		createStmt:           q.createStmt,
+		getPreferenceStmt:    q.getPreferenceStmt,
+		listStmt:             q.listStmt,
+		listPreferencesStmt:  q.listPreferencesStmt,
+		markAllReadStmt:      q.markAllReadStmt,
+		markReadStmt:         q.markReadStmt,
+		upsertPreferenceStmt: q.upsertPreferenceStmt,
+	}
+}
@@ -0,0 +1,49 @@
+-- name: Create :one
+INSERT INTO notifications (user_id, type, actor_id, post_id)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: List :many
+SELECT sqlc.embed(notifications), count(*) over()
+FROM notifications
+WHERE user_id = sqlc.arg(user_id)
+  AND CASE WHEN CAST(sqlc.arg(only_unread) AS boolean) IS TRUE THEN read_at IS NULL ELSE true END
+ORDER BY id DESC
+LIMIT CASE WHEN CAST(sqlc.arg(limit) AS int) > 0 THEN sqlc.arg(limit) ELSE 10 END
+OFFSET sqlc.arg(offset);
+
+-- name: CountUnread :one
+SELECT count(*)
+FROM notifications
+WHERE user_id = ?
+  AND read_at IS NULL;
+
+-- name: MarkRead :execrows
+UPDATE notifications
+SET read_at = datetime('now')
+WHERE user_id = sqlc.arg(user_id)
+  AND id IN (sqlc.slice(ids))
+  AND read_at IS NULL;
+
+-- name: MarkAllRead :execrows
+UPDATE notifications
+SET read_at = datetime('now')
+WHERE user_id = ?
+  AND read_at IS NULL;
+
+-- name: ListPreferences :many
+SELECT *
+FROM notification_preferences
+WHERE user_id = ?
+ORDER BY type;
+
+-- name: GetPreference :one
+SELECT *
+FROM notification_preferences
+WHERE user_id = ?
+  AND type = ?;
+
+-- name: UpsertPreference :exec
+INSERT INTO notification_preferences (user_id, type, in_app, email)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (user_id, type) DO UPDATE SET in_app = excluded.in_app, email = excluded.email;
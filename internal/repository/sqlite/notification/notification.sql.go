@@ -0,0 +1,297 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: notification.sql
+
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+const countUnread = `-- name: CountUnread :one
+SELECT count(*)
+FROM notifications
+WHERE user_id = ?
+  AND read_at IS NULL
+`
+
+// CountUnread
+//
+//	SELECT count(*)
+//	FROM notifications
+//	WHERE user_id = ?
+//	  AND read_at IS NULL
+func (q *Queries) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	row := q.queryRow(ctx, q.countUnreadStmt, countUnread, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const create = `-- name: Create :one
+INSERT INTO notifications (user_id, type, actor_id, post_id)
+VALUES (?, ?, ?, ?)
+RETURNING id, user_id, type, actor_id, post_id, read_at, created_at
+`
+
+type CreateParams struct {
+	UserID  int64         `json:"userId"`
+	Type    string        `json:"type"`
+	ActorID int64         `json:"actorId"`
+	PostID  sql.NullInt64 `json:"postId"`
+}
+
+// Create
+//
+//	INSERT INTO notifications (user_id, type, actor_id, post_id)
+//	VALUES (?, ?, ?, ?)
+//	RETURNING id, user_id, type, actor_id, post_id, read_at, created_at
+func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Notification, error) {
+	row := q.queryRow(ctx, q.createStmt, create,
+		arg.UserID,
+		arg.Type,
+		arg.ActorID,
+		arg.PostID,
+	)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.ActorID,
+		&i.PostID,
+		&i.ReadAt,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const getPreference = `-- name: GetPreference :one
+SELECT user_id, type, in_app, email
+FROM notification_preferences
+WHERE user_id = ?
+  AND type = ?
+`
+
+type GetPreferenceParams struct {
+	UserID int64  `json:"userId"`
+	Type   string `json:"type"`
+}
+
+// GetPreference
+//
+//	SELECT user_id, type, in_app, email
+//	FROM notification_preferences
+//	WHERE user_id = ?
+//	  AND type = ?
+func (q *Queries) GetPreference(ctx context.Context, arg GetPreferenceParams) (*NotificationPreference, error) {
+	row := q.queryRow(ctx, q.getPreferenceStmt, getPreference, arg.UserID, arg.Type)
+	var i NotificationPreference
+	err := row.Scan(
+		&i.UserID,
+		&i.Type,
+		&i.InApp,
+		&i.Email,
+	)
+	return &i, err
+}
+
+const list = `-- name: List :many
+SELECT notifications.id, notifications.user_id, notifications.type, notifications.actor_id, notifications.post_id, notifications.read_at, notifications.created_at, count(*) over()
+FROM notifications
+WHERE user_id = ?1
+  AND CASE WHEN CAST(?2 AS boolean) IS TRUE THEN read_at IS NULL ELSE true END
+ORDER BY id DESC
+LIMIT CASE WHEN CAST(?4 AS int) > 0 THEN ?4 ELSE 10 END
+OFFSET ?3
+`
+
+type ListParams struct {
+	UserID     int64 `json:"userId"`
+	OnlyUnread bool  `json:"onlyUnread"`
+	Offset     int64 `json:"offset"`
+	Limit      int64 `json:"limit"`
+}
+
+type ListRow struct {
+	Notification Notification `json:"notification"`
+	Count        int64        `json:"count"`
+}
+
+// List
+//
+//	SELECT notifications.id, notifications.user_id, notifications.type, notifications.actor_id, notifications.post_id, notifications.read_at, notifications.created_at, count(*) over()
+//	FROM notifications
+//	WHERE user_id = ?1
+//	  AND CASE WHEN CAST(?2 AS boolean) IS TRUE THEN read_at IS NULL ELSE true END
+//	ORDER BY id DESC
+//	LIMIT CASE WHEN CAST(?4 AS int) > 0 THEN ?4 ELSE 10 END
+//	OFFSET ?3
+func (q *Queries) List(ctx context.Context, arg ListParams) ([]*ListRow, error) {
+	rows, err := q.query(ctx, q.listStmt, list,
+		arg.UserID,
+		arg.OnlyUnread,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListRow{}
+	for rows.Next() {
+		var i ListRow
+		if err := rows.Scan(
+			&i.Notification.ID,
+			&i.Notification.UserID,
+			&i.Notification.Type,
+			&i.Notification.ActorID,
+			&i.Notification.PostID,
+			&i.Notification.ReadAt,
+			&i.Notification.CreatedAt,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPreferences = `-- name: ListPreferences :many
+SELECT user_id, type, in_app, email
+FROM notification_preferences
+WHERE user_id = ?
+ORDER BY type
+`
+
+// ListPreferences
+//
+//	SELECT user_id, type, in_app, email
+//	FROM notification_preferences
+//	WHERE user_id = ?
+//	ORDER BY type
+func (q *Queries) ListPreferences(ctx context.Context, userID int64) ([]*NotificationPreference, error) {
+	rows, err := q.query(ctx, q.listPreferencesStmt, listPreferences, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*NotificationPreference{}
+	for rows.Next() {
+		var i NotificationPreference
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Type,
+			&i.InApp,
+			&i.Email,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markAllRead = `-- name: MarkAllRead :execrows
+UPDATE notifications
+SET read_at = datetime('now')
+WHERE user_id = ?
+  AND read_at IS NULL
+`
+
+// MarkAllRead
+//
+//	UPDATE notifications
+//	SET read_at = datetime('now')
+//	WHERE user_id = ?
+//	  AND read_at IS NULL
+func (q *Queries) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	result, err := q.exec(ctx, q.markAllReadStmt, markAllRead, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markRead = `-- name: MarkRead :execrows
+UPDATE notifications
+SET read_at = datetime('now')
+WHERE user_id = ?1
+  AND id IN (/*SLICE:ids*/?)
+  AND read_at IS NULL
+`
+
+type MarkReadParams struct {
+	UserID int64   `json:"userId"`
+	Ids    []int64 `json:"ids"`
+}
+
+// MarkRead
+//
+//	UPDATE notifications
+//	SET read_at = datetime('now')
+//	WHERE user_id = ?1
+//	  AND id IN (/*SLICE:ids*/?)
+//	  AND read_at IS NULL
+func (q *Queries) MarkRead(ctx context.Context, arg MarkReadParams) (int64, error) {
+	query := markRead
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.UserID)
+	if len(arg.Ids) > 0 {
+		for _, v := range arg.Ids {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:ids*/?", strings.Repeat(",?", len(arg.Ids))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	result, err := q.exec(ctx, nil, query, queryParams...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertPreference = `-- name: UpsertPreference :exec
+INSERT INTO notification_preferences (user_id, type, in_app, email)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (user_id, type) DO UPDATE SET in_app = excluded.in_app, email = excluded.email
+`
+
+type UpsertPreferenceParams struct {
+	UserID int64  `json:"userId"`
+	Type   string `json:"type"`
+	InApp  bool   `json:"inApp"`
+	Email  bool   `json:"email"`
+}
+
+// UpsertPreference
+//
+//	INSERT INTO notification_preferences (user_id, type, in_app, email)
+//	VALUES (?, ?, ?, ?)
+//	ON CONFLICT (user_id, type) DO UPDATE SET in_app = excluded.in_app, email = excluded.email
+func (q *Queries) UpsertPreference(ctx context.Context, arg UpsertPreferenceParams) error {
+	_, err := q.exec(ctx, q.upsertPreferenceStmt, upsertPreference,
+		arg.UserID,
+		arg.Type,
+		arg.InApp,
+		arg.Email,
+	)
+	return err
+}
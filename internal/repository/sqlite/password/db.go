@@ -30,11 +30,20 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getByUserIDStmt, err = db.PrepareContext(ctx, getByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetByUserID: %w", err)
 	}
-	if q.increaseFailedAttemptsStmt, err = db.PrepareContext(ctx, increaseFailedAttempts); err != nil {
-		return nil, fmt.Errorf("error preparing query IncreaseFailedAttempts: %w", err)
+	if q.getLoginFailureStmt, err = db.PrepareContext(ctx, getLoginFailure); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLoginFailure: %w", err)
 	}
-	if q.resetFailedAttemptsStmt, err = db.PrepareContext(ctx, resetFailedAttempts); err != nil {
-		return nil, fmt.Errorf("error preparing query ResetFailedAttempts: %w", err)
+	if q.increaseLoginFailuresStmt, err = db.PrepareContext(ctx, increaseLoginFailures); err != nil {
+		return nil, fmt.Errorf("error preparing query IncreaseLoginFailures: %w", err)
+	}
+	if q.purgeLoginFailuresStmt, err = db.PrepareContext(ctx, purgeLoginFailures); err != nil {
+		return nil, fmt.Errorf("error preparing query PurgeLoginFailures: %w", err)
+	}
+	if q.resetAllLoginFailuresStmt, err = db.PrepareContext(ctx, resetAllLoginFailures); err != nil {
+		return nil, fmt.Errorf("error preparing query ResetAllLoginFailures: %w", err)
+	}
+	if q.resetLoginFailuresStmt, err = db.PrepareContext(ctx, resetLoginFailures); err != nil {
+		return nil, fmt.Errorf("error preparing query ResetLoginFailures: %w", err)
 	}
 	if q.updateStmt, err = db.PrepareContext(ctx, update); err != nil {
 		return nil, fmt.Errorf("error preparing query Update: %w", err)
@@ -54,14 +63,29 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getByUserIDStmt: %w", cerr)
 		}
 	}
-	if q.increaseFailedAttemptsStmt != nil {
-		if cerr := q.increaseFailedAttemptsStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing increaseFailedAttemptsStmt: %w", cerr)
+	if q.getLoginFailureStmt != nil {
+		if cerr := q.getLoginFailureStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLoginFailureStmt: %w", cerr)
+		}
+	}
+	if q.increaseLoginFailuresStmt != nil {
+		if cerr := q.increaseLoginFailuresStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing increaseLoginFailuresStmt: %w", cerr)
+		}
+	}
+	if q.purgeLoginFailuresStmt != nil {
+		if cerr := q.purgeLoginFailuresStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing purgeLoginFailuresStmt: %w", cerr)
+		}
+	}
+	if q.resetAllLoginFailuresStmt != nil {
+		if cerr := q.resetAllLoginFailuresStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resetAllLoginFailuresStmt: %w", cerr)
 		}
 	}
-	if q.resetFailedAttemptsStmt != nil {
-		if cerr := q.resetFailedAttemptsStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing resetFailedAttemptsStmt: %w", cerr)
+	if q.resetLoginFailuresStmt != nil {
+		if cerr := q.resetLoginFailuresStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resetLoginFailuresStmt: %w", cerr)
 		}
 	}
 	if q.updateStmt != nil {
@@ -106,23 +130,29 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                         DBTX
-	tx                         *sql.Tx
-	createStmt                 *sql.Stmt
-	getByUserIDStmt            *sql.Stmt
-	increaseFailedAttemptsStmt *sql.Stmt
-	resetFailedAttemptsStmt    *sql.Stmt
-	updateStmt                 *sql.Stmt
+	db                        DBTX
+	tx                        *sql.Tx
+	createStmt                *sql.Stmt
+	getByUserIDStmt           *sql.Stmt
+	getLoginFailureStmt       *sql.Stmt
+	increaseLoginFailuresStmt *sql.Stmt
+	purgeLoginFailuresStmt    *sql.Stmt
+	resetAllLoginFailuresStmt *sql.Stmt
+	resetLoginFailuresStmt    *sql.Stmt
+	updateStmt                *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                         tx,
-		tx:                         tx,
-		createStmt:                 q.createStmt,
-		getByUserIDStmt:            q.getByUserIDStmt,
-		increaseFailedAttemptsStmt: q.increaseFailedAttemptsStmt,
-		resetFailedAttemptsStmt:    q.resetFailedAttemptsStmt,
-		updateStmt:                 q.updateStmt,
+		db:                        tx,
+		tx:                        tx,
+		createStmt:                q.createStmt,
+		getByUserIDStmt:           q.getByUserIDStmt,
+		getLoginFailureStmt:       q.getLoginFailureStmt,
+		increaseLoginFailuresStmt: q.increaseLoginFailuresStmt,
+		purgeLoginFailuresStmt:    q.purgeLoginFailuresStmt,
+		resetAllLoginFailuresStmt: q.resetAllLoginFailuresStmt,
+		resetLoginFailuresStmt:    q.resetLoginFailuresStmt,
+		updateStmt:                q.updateStmt,
 	}
 }
@@ -16,16 +16,27 @@ WHERE id = ?
   AND deleted_at IS NULL
 RETURNING *;
 
--- name: IncreaseFailedAttempts :one
-UPDATE passwords
-SET failed_attempts = failed_attempts + 1, updated_at = datetime('now')
-WHERE id = ?
-  AND deleted_at IS NULL
-RETURNING *;
+-- name: GetLoginFailure :one
+SELECT *
+FROM login_failures
+WHERE user_id = ?
+  AND ip = ?;
 
--- name: ResetFailedAttempts :one
-UPDATE passwords
-SET failed_attempts = 0, updated_at = datetime('now')
-WHERE id = ?
-  AND deleted_at IS NULL
+-- name: IncreaseLoginFailures :one
+INSERT INTO login_failures (user_id, ip, attempts)
+VALUES (?, ?, 1)
+ON CONFLICT (user_id, ip) DO UPDATE SET attempts = login_failures.attempts + 1, updated_at = datetime('now')
 RETURNING *;
+
+-- name: ResetLoginFailures :exec
+DELETE FROM login_failures
+WHERE user_id = ?
+  AND ip = ?;
+
+-- name: ResetAllLoginFailures :exec
+DELETE FROM login_failures
+WHERE user_id = ?;
+
+-- name: PurgeLoginFailures :execrows
+DELETE FROM login_failures
+WHERE updated_at < datetime('now', CAST(sqlc.arg(age) AS text));
@@ -12,7 +12,7 @@ import (
 const create = `-- name: Create :one
 INSERT INTO passwords (user_id, password_hash)
 VALUES (?, ?)
-RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
+RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
 `
 
 type CreateParams struct {
@@ -24,7 +24,7 @@ type CreateParams struct {
 //
 //	INSERT INTO passwords (user_id, password_hash)
 //	VALUES (?, ?)
-//	RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
+//	RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
 func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Password, error) {
 	row := q.queryRow(ctx, q.createStmt, create, arg.UserID, arg.PasswordHash)
 	var i Password
@@ -32,7 +32,6 @@ func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Password, erro
 		&i.ID,
 		&i.UserID,
 		&i.PasswordHash,
-		&i.FailedAttempts,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -42,7 +41,7 @@ func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Password, erro
 }
 
 const getByUserID = `-- name: GetByUserID :one
-SELECT id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
+SELECT id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
 FROM passwords
 WHERE user_id = ?
   AND deleted_at IS NULL
@@ -50,7 +49,7 @@ WHERE user_id = ?
 
 // GetByUserID
 //
-//	SELECT id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
+//	SELECT id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
 //	FROM passwords
 //	WHERE user_id = ?
 //	  AND deleted_at IS NULL
@@ -61,7 +60,6 @@ func (q *Queries) GetByUserID(ctx context.Context, userID int64) (*Password, err
 		&i.ID,
 		&i.UserID,
 		&i.PasswordHash,
-		&i.FailedAttempts,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -70,74 +68,124 @@ func (q *Queries) GetByUserID(ctx context.Context, userID int64) (*Password, err
 	return &i, err
 }
 
-const increaseFailedAttempts = `-- name: IncreaseFailedAttempts :one
-UPDATE passwords
-SET failed_attempts = failed_attempts + 1, updated_at = datetime('now')
-WHERE id = ?
-  AND deleted_at IS NULL
-RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
+const getLoginFailure = `-- name: GetLoginFailure :one
+SELECT user_id, ip, attempts, updated_at
+FROM login_failures
+WHERE user_id = ?
+  AND ip = ?
 `
 
-// IncreaseFailedAttempts
+type GetLoginFailureParams struct {
+	UserID int64  `json:"userId"`
+	Ip     string `json:"ip"`
+}
+
+// GetLoginFailure
 //
-//	UPDATE passwords
-//	SET failed_attempts = failed_attempts + 1, updated_at = datetime('now')
-//	WHERE id = ?
-//	  AND deleted_at IS NULL
-//	RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
-func (q *Queries) IncreaseFailedAttempts(ctx context.Context, id int64) (*Password, error) {
-	row := q.queryRow(ctx, q.increaseFailedAttemptsStmt, increaseFailedAttempts, id)
-	var i Password
+//	SELECT user_id, ip, attempts, updated_at
+//	FROM login_failures
+//	WHERE user_id = ?
+//	  AND ip = ?
+func (q *Queries) GetLoginFailure(ctx context.Context, arg GetLoginFailureParams) (*LoginFailure, error) {
+	row := q.queryRow(ctx, q.getLoginFailureStmt, getLoginFailure, arg.UserID, arg.Ip)
+	var i LoginFailure
 	err := row.Scan(
-		&i.ID,
 		&i.UserID,
-		&i.PasswordHash,
-		&i.FailedAttempts,
-		&i.CreatedAt,
+		&i.Ip,
+		&i.Attempts,
 		&i.UpdatedAt,
-		&i.DeletedAt,
-		&i.BlockedAt,
 	)
 	return &i, err
 }
 
-const resetFailedAttempts = `-- name: ResetFailedAttempts :one
-UPDATE passwords
-SET failed_attempts = 0, updated_at = datetime('now')
-WHERE id = ?
-  AND deleted_at IS NULL
-RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
+const increaseLoginFailures = `-- name: IncreaseLoginFailures :one
+INSERT INTO login_failures (user_id, ip, attempts)
+VALUES (?, ?, 1)
+ON CONFLICT (user_id, ip) DO UPDATE SET attempts = login_failures.attempts + 1, updated_at = datetime('now')
+RETURNING user_id, ip, attempts, updated_at
 `
 
-// ResetFailedAttempts
+type IncreaseLoginFailuresParams struct {
+	UserID int64  `json:"userId"`
+	Ip     string `json:"ip"`
+}
+
+// IncreaseLoginFailures
 //
-//	UPDATE passwords
-//	SET failed_attempts = 0, updated_at = datetime('now')
-//	WHERE id = ?
-//	  AND deleted_at IS NULL
-//	RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
-func (q *Queries) ResetFailedAttempts(ctx context.Context, id int64) (*Password, error) {
-	row := q.queryRow(ctx, q.resetFailedAttemptsStmt, resetFailedAttempts, id)
-	var i Password
+//	INSERT INTO login_failures (user_id, ip, attempts)
+//	VALUES (?, ?, 1)
+//	ON CONFLICT (user_id, ip) DO UPDATE SET attempts = login_failures.attempts + 1, updated_at = datetime('now')
+//	RETURNING user_id, ip, attempts, updated_at
+func (q *Queries) IncreaseLoginFailures(ctx context.Context, arg IncreaseLoginFailuresParams) (*LoginFailure, error) {
+	row := q.queryRow(ctx, q.increaseLoginFailuresStmt, increaseLoginFailures, arg.UserID, arg.Ip)
+	var i LoginFailure
 	err := row.Scan(
-		&i.ID,
 		&i.UserID,
-		&i.PasswordHash,
-		&i.FailedAttempts,
-		&i.CreatedAt,
+		&i.Ip,
+		&i.Attempts,
 		&i.UpdatedAt,
-		&i.DeletedAt,
-		&i.BlockedAt,
 	)
 	return &i, err
 }
 
+const purgeLoginFailures = `-- name: PurgeLoginFailures :execrows
+DELETE FROM login_failures
+WHERE updated_at < datetime('now', CAST(?1 AS text))
+`
+
+// PurgeLoginFailures
+//
+//	DELETE FROM login_failures
+//	WHERE updated_at < datetime('now', CAST(?1 AS text))
+func (q *Queries) PurgeLoginFailures(ctx context.Context, age string) (int64, error) {
+	result, err := q.exec(ctx, q.purgeLoginFailuresStmt, purgeLoginFailures, age)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const resetAllLoginFailures = `-- name: ResetAllLoginFailures :exec
+DELETE FROM login_failures
+WHERE user_id = ?
+`
+
+// ResetAllLoginFailures
+//
+//	DELETE FROM login_failures
+//	WHERE user_id = ?
+func (q *Queries) ResetAllLoginFailures(ctx context.Context, userID int64) error {
+	_, err := q.exec(ctx, q.resetAllLoginFailuresStmt, resetAllLoginFailures, userID)
+	return err
+}
+
+const resetLoginFailures = `-- name: ResetLoginFailures :exec
+DELETE FROM login_failures
+WHERE user_id = ?
+  AND ip = ?
+`
+
+type ResetLoginFailuresParams struct {
+	UserID int64  `json:"userId"`
+	Ip     string `json:"ip"`
+}
+
+// ResetLoginFailures
+//
+//	DELETE FROM login_failures
+//	WHERE user_id = ?
+//	  AND ip = ?
+func (q *Queries) ResetLoginFailures(ctx context.Context, arg ResetLoginFailuresParams) error {
+	_, err := q.exec(ctx, q.resetLoginFailuresStmt, resetLoginFailures, arg.UserID, arg.Ip)
+	return err
+}
+
 const update = `-- name: Update :one
 UPDATE passwords
 SET password_hash = ?, updated_at = datetime('now')
 WHERE id = ?
   AND deleted_at IS NULL
-RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
+RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
 `
 
 type UpdateParams struct {
@@ -151,7 +199,7 @@ type UpdateParams struct {
 //	SET password_hash = ?, updated_at = datetime('now')
 //	WHERE id = ?
 //	  AND deleted_at IS NULL
-//	RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
+//	RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
 func (q *Queries) Update(ctx context.Context, arg UpdateParams) (*Password, error) {
 	row := q.queryRow(ctx, q.updateStmt, update, arg.PasswordHash, arg.ID)
 	var i Password
@@ -159,7 +207,6 @@ func (q *Queries) Update(ctx context.Context, arg UpdateParams) (*Password, erro
 		&i.ID,
 		&i.UserID,
 		&i.PasswordHash,
-		&i.FailedAttempts,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -13,38 +13,52 @@ type Querier interface {
 	//
 	//  INSERT INTO passwords (user_id, password_hash)
 	//  VALUES (?, ?)
-	//  RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
+	//  RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
 	Create(ctx context.Context, arg CreateParams) (*Password, error)
 	//GetByUserID
 	//
-	//  SELECT id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
+	//  SELECT id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
 	//  FROM passwords
 	//  WHERE user_id = ?
 	//    AND deleted_at IS NULL
 	GetByUserID(ctx context.Context, userID int64) (*Password, error)
-	//IncreaseFailedAttempts
+	//GetLoginFailure
 	//
-	//  UPDATE passwords
-	//  SET failed_attempts = failed_attempts + 1, updated_at = datetime('now')
-	//  WHERE id = ?
-	//    AND deleted_at IS NULL
-	//  RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
-	IncreaseFailedAttempts(ctx context.Context, id int64) (*Password, error)
-	//ResetFailedAttempts
+	//  SELECT user_id, ip, attempts, updated_at
+	//  FROM login_failures
+	//  WHERE user_id = ?
+	//    AND ip = ?
+	GetLoginFailure(ctx context.Context, arg GetLoginFailureParams) (*LoginFailure, error)
+	//IncreaseLoginFailures
 	//
-	//  UPDATE passwords
-	//  SET failed_attempts = 0, updated_at = datetime('now')
-	//  WHERE id = ?
-	//    AND deleted_at IS NULL
-	//  RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
-	ResetFailedAttempts(ctx context.Context, id int64) (*Password, error)
+	//  INSERT INTO login_failures (user_id, ip, attempts)
+	//  VALUES (?, ?, 1)
+	//  ON CONFLICT (user_id, ip) DO UPDATE SET attempts = login_failures.attempts + 1, updated_at = datetime('now')
+	//  RETURNING user_id, ip, attempts, updated_at
+	IncreaseLoginFailures(ctx context.Context, arg IncreaseLoginFailuresParams) (*LoginFailure, error)
+	//PurgeLoginFailures
+	//
+	//  DELETE FROM login_failures
+	//  WHERE updated_at < datetime('now', CAST(?1 AS text))
+	PurgeLoginFailures(ctx context.Context, age string) (int64, error)
+	//ResetAllLoginFailures
+	//
+	//  DELETE FROM login_failures
+	//  WHERE user_id = ?
+	ResetAllLoginFailures(ctx context.Context, userID int64) error
+	//ResetLoginFailures
+	//
+	//  DELETE FROM login_failures
+	//  WHERE user_id = ?
+	//    AND ip = ?
+	ResetLoginFailures(ctx context.Context, arg ResetLoginFailuresParams) error
 	//Update
 	//
 	//  UPDATE passwords
 	//  SET password_hash = ?, updated_at = datetime('now')
 	//  WHERE id = ?
 	//    AND deleted_at IS NULL
-	//  RETURNING id, user_id, password_hash, failed_attempts, created_at, updated_at, deleted_at, blocked_at
+	//  RETURNING id, user_id, password_hash, created_at, updated_at, deleted_at, blocked_at
 	Update(ctx context.Context, arg UpdateParams) (*Password, error)
 }
 
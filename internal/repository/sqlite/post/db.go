@@ -27,6 +27,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createStmt, err = db.PrepareContext(ctx, create); err != nil {
 		return nil, fmt.Errorf("error preparing query Create: %w", err)
 	}
+	if q.deleteStmt, err = db.PrepareContext(ctx, delete); err != nil {
+		return nil, fmt.Errorf("error preparing query Delete: %w", err)
+	}
 	if q.editStmt, err = db.PrepareContext(ctx, edit); err != nil {
 		return nil, fmt.Errorf("error preparing query Edit: %w", err)
 	}
@@ -36,6 +39,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listStmt, err = db.PrepareContext(ctx, list); err != nil {
 		return nil, fmt.Errorf("error preparing query List: %w", err)
 	}
+	if q.listFollowingStmt, err = db.PrepareContext(ctx, listFollowing); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFollowing: %w", err)
+	}
 	return &q, nil
 }
 
@@ -46,6 +52,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createStmt: %w", cerr)
 		}
 	}
+	if q.deleteStmt != nil {
+		if cerr := q.deleteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStmt: %w", cerr)
+		}
+	}
 	if q.editStmt != nil {
 		if cerr := q.editStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing editStmt: %w", cerr)
@@ -61,6 +72,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listStmt: %w", cerr)
 		}
 	}
+	if q.listFollowingStmt != nil {
+		if cerr := q.listFollowingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFollowingStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -98,21 +114,25 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db          DBTX
-	tx          *sql.Tx
-	createStmt  *sql.Stmt
-	editStmt    *sql.Stmt
-	getByIDStmt *sql.Stmt
-	listStmt    *sql.Stmt
+	db                DBTX
+	tx                *sql.Tx
+	createStmt        *sql.Stmt
+	deleteStmt        *sql.Stmt
+	editStmt          *sql.Stmt
+	getByIDStmt       *sql.Stmt
+	listStmt          *sql.Stmt
+	listFollowingStmt *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:          tx,
-		tx:          tx,
-		createStmt:  q.createStmt,
-		editStmt:    q.editStmt,
-		getByIDStmt: q.getByIDStmt,
-		listStmt:    q.listStmt,
+		db:                tx,
+		tx:                tx,
+		createStmt:        q.createStmt,
+		deleteStmt:        q.deleteStmt,
+		editStmt:          q.editStmt,
+		getByIDStmt:       q.getByIDStmt,
+		listStmt:          q.listStmt,
+		listFollowingStmt: q.listFollowingStmt,
 	}
 }
@@ -10,13 +10,23 @@ LIMIT CASE WHEN CAST(sqlc.arg(limit) AS int) > 0 THEN sqlc.arg(limit) ELSE 10 EN
 OFFSET sqlc.arg(offset);
 
 -- name: Create :one
-INSERT INTO posts (user_id, title, short, body, tags, is_published)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO posts (user_id, title, short, body, tags, is_published,
+                   cover_image, meta_description, canonical_url, noindex,
+                   og_title, og_description, og_image,
+                   twitter_card, twitter_title, twitter_description, twitter_image)
+VALUES (?, ?, ?, ?, ?, ?,
+        ?, ?, ?, ?,
+        ?, ?, ?,
+        ?, ?, ?, ?)
 RETURNING *;
 
 -- name: Edit :one
 UPDATE posts
-SET title = ?, short = ?, body = ?, tags = ?, is_published = ?, updated_at = datetime('now')
+SET title = ?, short = ?, body = ?, tags = ?, is_published = ?,
+    cover_image = ?, meta_description = ?, canonical_url = ?, noindex = ?,
+    og_title = ?, og_description = ?, og_image = ?,
+    twitter_card = ?, twitter_title = ?, twitter_description = ?, twitter_image = ?,
+    updated_at = datetime('now')
 WHERE id = ?
   AND deleted_at IS NULL
   AND user_id = ?
@@ -27,4 +37,22 @@ SELECT *
 FROM posts
 WHERE deleted_at IS NULL
   AND id = ?
-  AND CASE WHEN CAST(sqlc.narg(user_id) AS int) IS NULL THEN is_published IS TRUE ELSE user_id = sqlc.narg(user_id) END;
\ No newline at end of file
+  AND CASE WHEN CAST(sqlc.narg(user_id) AS int) IS NULL THEN is_published IS TRUE ELSE user_id = sqlc.narg(user_id) END;
+
+-- name: ListFollowing :many
+SELECT sqlc.embed(posts), count(*) over()
+FROM posts
+JOIN follows ON follows.followee_id = posts.user_id
+WHERE follows.follower_id = sqlc.arg(follower_id)
+  AND posts.deleted_at IS NULL
+  AND posts.is_published IS true
+ORDER BY posts.id DESC
+LIMIT CASE WHEN CAST(sqlc.arg(limit) AS int) > 0 THEN sqlc.arg(limit) ELSE 10 END
+OFFSET sqlc.arg(offset);
+
+-- name: Delete :execrows
+UPDATE posts
+SET deleted_at = datetime('now')
+WHERE id = ?
+  AND user_id = ?
+  AND deleted_at IS NULL;
@@ -11,25 +11,48 @@ import (
 )
 
 const create = `-- name: Create :one
-INSERT INTO posts (user_id, title, short, body, tags, is_published)
-VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at
+INSERT INTO posts (user_id, title, short, body, tags, is_published,
+                   cover_image, meta_description, canonical_url, noindex,
+                   og_title, og_description, og_image,
+                   twitter_card, twitter_title, twitter_description, twitter_image)
+VALUES (?, ?, ?, ?, ?, ?,
+        ?, ?, ?, ?,
+        ?, ?, ?,
+        ?, ?, ?, ?)
+RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
 `
 
 type CreateParams struct {
-	UserID      int64          `json:"userId"`
-	Title       string         `json:"title"`
-	Short       string         `json:"short"`
-	Body        string         `json:"body"`
-	Tags        sql.NullString `json:"tags"`
-	IsPublished bool           `json:"isPublished"`
+	UserID             int64          `json:"userId"`
+	Title              string         `json:"title"`
+	Short              string         `json:"short"`
+	Body               string         `json:"body"`
+	Tags               sql.NullString `json:"tags"`
+	IsPublished        bool           `json:"isPublished"`
+	CoverImage         sql.NullString `json:"coverImage"`
+	MetaDescription    sql.NullString `json:"metaDescription"`
+	CanonicalUrl       sql.NullString `json:"canonicalUrl"`
+	Noindex            bool           `json:"noindex"`
+	OgTitle            sql.NullString `json:"ogTitle"`
+	OgDescription      sql.NullString `json:"ogDescription"`
+	OgImage            sql.NullString `json:"ogImage"`
+	TwitterCard        sql.NullString `json:"twitterCard"`
+	TwitterTitle       sql.NullString `json:"twitterTitle"`
+	TwitterDescription sql.NullString `json:"twitterDescription"`
+	TwitterImage       sql.NullString `json:"twitterImage"`
 }
 
 // Create
 //
-//	INSERT INTO posts (user_id, title, short, body, tags, is_published)
-//	VALUES (?, ?, ?, ?, ?, ?)
-//	RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at
+//	INSERT INTO posts (user_id, title, short, body, tags, is_published,
+//	                   cover_image, meta_description, canonical_url, noindex,
+//	                   og_title, og_description, og_image,
+//	                   twitter_card, twitter_title, twitter_description, twitter_image)
+//	VALUES (?, ?, ?, ?, ?, ?,
+//	        ?, ?, ?, ?,
+//	        ?, ?, ?,
+//	        ?, ?, ?, ?)
+//	RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
 func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Post, error) {
 	row := q.queryRow(ctx, q.createStmt, create,
 		arg.UserID,
@@ -38,6 +61,17 @@ func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Post, error) {
 		arg.Body,
 		arg.Tags,
 		arg.IsPublished,
+		arg.CoverImage,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.Noindex,
+		arg.OgTitle,
+		arg.OgDescription,
+		arg.OgImage,
+		arg.TwitterCard,
+		arg.TwitterTitle,
+		arg.TwitterDescription,
+		arg.TwitterImage,
 	)
 	var i Post
 	err := row.Scan(
@@ -51,37 +85,95 @@ func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Post, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.CoverImage,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.Noindex,
+		&i.OgTitle,
+		&i.OgDescription,
+		&i.OgImage,
+		&i.TwitterCard,
+		&i.TwitterTitle,
+		&i.TwitterDescription,
+		&i.TwitterImage,
 	)
 	return &i, err
 }
 
+const delete = `-- name: Delete :execrows
+UPDATE posts
+SET deleted_at = datetime('now')
+WHERE id = ?
+  AND user_id = ?
+  AND deleted_at IS NULL
+`
+
+type DeleteParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"userId"`
+}
+
+// Delete
+//
+//	UPDATE posts
+//	SET deleted_at = datetime('now')
+//	WHERE id = ?
+//	  AND user_id = ?
+//	  AND deleted_at IS NULL
+func (q *Queries) Delete(ctx context.Context, arg DeleteParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteStmt, delete, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const edit = `-- name: Edit :one
 UPDATE posts
-SET title = ?, short = ?, body = ?, tags = ?, is_published = ?, updated_at = datetime('now')
+SET title = ?, short = ?, body = ?, tags = ?, is_published = ?,
+    cover_image = ?, meta_description = ?, canonical_url = ?, noindex = ?,
+    og_title = ?, og_description = ?, og_image = ?,
+    twitter_card = ?, twitter_title = ?, twitter_description = ?, twitter_image = ?,
+    updated_at = datetime('now')
 WHERE id = ?
   AND deleted_at IS NULL
   AND user_id = ?
-RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at
+RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
 `
 
 type EditParams struct {
-	Title       string         `json:"title"`
-	Short       string         `json:"short"`
-	Body        string         `json:"body"`
-	Tags        sql.NullString `json:"tags"`
-	IsPublished bool           `json:"isPublished"`
-	ID          int64          `json:"id"`
-	UserID      int64          `json:"userId"`
+	Title              string         `json:"title"`
+	Short              string         `json:"short"`
+	Body               string         `json:"body"`
+	Tags               sql.NullString `json:"tags"`
+	IsPublished        bool           `json:"isPublished"`
+	CoverImage         sql.NullString `json:"coverImage"`
+	MetaDescription    sql.NullString `json:"metaDescription"`
+	CanonicalUrl       sql.NullString `json:"canonicalUrl"`
+	Noindex            bool           `json:"noindex"`
+	OgTitle            sql.NullString `json:"ogTitle"`
+	OgDescription      sql.NullString `json:"ogDescription"`
+	OgImage            sql.NullString `json:"ogImage"`
+	TwitterCard        sql.NullString `json:"twitterCard"`
+	TwitterTitle       sql.NullString `json:"twitterTitle"`
+	TwitterDescription sql.NullString `json:"twitterDescription"`
+	TwitterImage       sql.NullString `json:"twitterImage"`
+	ID                 int64          `json:"id"`
+	UserID             int64          `json:"userId"`
 }
 
 // Edit
 //
 //	UPDATE posts
-//	SET title = ?, short = ?, body = ?, tags = ?, is_published = ?, updated_at = datetime('now')
+//	SET title = ?, short = ?, body = ?, tags = ?, is_published = ?,
+//	    cover_image = ?, meta_description = ?, canonical_url = ?, noindex = ?,
+//	    og_title = ?, og_description = ?, og_image = ?,
+//	    twitter_card = ?, twitter_title = ?, twitter_description = ?, twitter_image = ?,
+//	    updated_at = datetime('now')
 //	WHERE id = ?
 //	  AND deleted_at IS NULL
 //	  AND user_id = ?
-//	RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at
+//	RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
 func (q *Queries) Edit(ctx context.Context, arg EditParams) (*Post, error) {
 	row := q.queryRow(ctx, q.editStmt, edit,
 		arg.Title,
@@ -89,6 +181,17 @@ func (q *Queries) Edit(ctx context.Context, arg EditParams) (*Post, error) {
 		arg.Body,
 		arg.Tags,
 		arg.IsPublished,
+		arg.CoverImage,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.Noindex,
+		arg.OgTitle,
+		arg.OgDescription,
+		arg.OgImage,
+		arg.TwitterCard,
+		arg.TwitterTitle,
+		arg.TwitterDescription,
+		arg.TwitterImage,
 		arg.ID,
 		arg.UserID,
 	)
@@ -104,12 +207,23 @@ func (q *Queries) Edit(ctx context.Context, arg EditParams) (*Post, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.CoverImage,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.Noindex,
+		&i.OgTitle,
+		&i.OgDescription,
+		&i.OgImage,
+		&i.TwitterCard,
+		&i.TwitterTitle,
+		&i.TwitterDescription,
+		&i.TwitterImage,
 	)
 	return &i, err
 }
 
 const getByID = `-- name: GetByID :one
-SELECT id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at
+SELECT id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
 FROM posts
 WHERE deleted_at IS NULL
   AND id = ?
@@ -123,7 +237,7 @@ type GetByIDParams struct {
 
 // GetByID
 //
-//	SELECT id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at
+//	SELECT id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
 //	FROM posts
 //	WHERE deleted_at IS NULL
 //	  AND id = ?
@@ -142,12 +256,23 @@ func (q *Queries) GetByID(ctx context.Context, arg GetByIDParams) (*Post, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.CoverImage,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.Noindex,
+		&i.OgTitle,
+		&i.OgDescription,
+		&i.OgImage,
+		&i.TwitterCard,
+		&i.TwitterTitle,
+		&i.TwitterDescription,
+		&i.TwitterImage,
 	)
 	return &i, err
 }
 
 const list = `-- name: List :many
-SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, count(*) over()
+SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
 FROM posts
 WHERE deleted_at IS NULL
   AND CASE WHEN CAST(?1 AS boolean) IS TRUE THEN is_published IS true ELSE true END
@@ -173,7 +298,7 @@ type ListRow struct {
 
 // List
 //
-//	SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, count(*) over()
+//	SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
 //	FROM posts
 //	WHERE deleted_at IS NULL
 //	  AND CASE WHEN CAST(?1 AS boolean) IS TRUE THEN is_published IS true ELSE true END
@@ -208,6 +333,97 @@ func (q *Queries) List(ctx context.Context, arg ListParams) ([]*ListRow, error)
 			&i.Post.CreatedAt,
 			&i.Post.UpdatedAt,
 			&i.Post.DeletedAt,
+			&i.Post.CoverImage,
+			&i.Post.MetaDescription,
+			&i.Post.CanonicalUrl,
+			&i.Post.Noindex,
+			&i.Post.OgTitle,
+			&i.Post.OgDescription,
+			&i.Post.OgImage,
+			&i.Post.TwitterCard,
+			&i.Post.TwitterTitle,
+			&i.Post.TwitterDescription,
+			&i.Post.TwitterImage,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFollowing = `-- name: ListFollowing :many
+SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
+FROM posts
+JOIN follows ON follows.followee_id = posts.user_id
+WHERE follows.follower_id = ?1
+  AND posts.deleted_at IS NULL
+  AND posts.is_published IS true
+ORDER BY posts.id DESC
+LIMIT CASE WHEN CAST(?3 AS int) > 0 THEN ?3 ELSE 10 END
+OFFSET ?2
+`
+
+type ListFollowingParams struct {
+	FollowerID int64 `json:"followerId"`
+	Offset     int64 `json:"offset"`
+	Limit      int64 `json:"limit"`
+}
+
+type ListFollowingRow struct {
+	Post  Post  `json:"post"`
+	Count int64 `json:"count"`
+}
+
+// ListFollowing
+//
+//	SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
+//	FROM posts
+//	JOIN follows ON follows.followee_id = posts.user_id
+//	WHERE follows.follower_id = ?1
+//	  AND posts.deleted_at IS NULL
+//	  AND posts.is_published IS true
+//	ORDER BY posts.id DESC
+//	LIMIT CASE WHEN CAST(?3 AS int) > 0 THEN ?3 ELSE 10 END
+//	OFFSET ?2
+func (q *Queries) ListFollowing(ctx context.Context, arg ListFollowingParams) ([]*ListFollowingRow, error) {
+	rows, err := q.query(ctx, q.listFollowingStmt, listFollowing, arg.FollowerID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListFollowingRow{}
+	for rows.Next() {
+		var i ListFollowingRow
+		if err := rows.Scan(
+			&i.Post.ID,
+			&i.Post.UserID,
+			&i.Post.Title,
+			&i.Post.Short,
+			&i.Post.Body,
+			&i.Post.Tags,
+			&i.Post.IsPublished,
+			&i.Post.CreatedAt,
+			&i.Post.UpdatedAt,
+			&i.Post.DeletedAt,
+			&i.Post.CoverImage,
+			&i.Post.MetaDescription,
+			&i.Post.CanonicalUrl,
+			&i.Post.Noindex,
+			&i.Post.OgTitle,
+			&i.Post.OgDescription,
+			&i.Post.OgImage,
+			&i.Post.TwitterCard,
+			&i.Post.TwitterTitle,
+			&i.Post.TwitterDescription,
+			&i.Post.TwitterImage,
 			&i.Count,
 		); err != nil {
 			return nil, err
@@ -11,22 +11,40 @@ import (
 type Querier interface {
 	//Create
 	//
-	//  INSERT INTO posts (user_id, title, short, body, tags, is_published)
-	//  VALUES (?, ?, ?, ?, ?, ?)
-	//  RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at
+	//  INSERT INTO posts (user_id, title, short, body, tags, is_published,
+	//                     cover_image, meta_description, canonical_url, noindex,
+	//                     og_title, og_description, og_image,
+	//                     twitter_card, twitter_title, twitter_description, twitter_image)
+	//  VALUES (?, ?, ?, ?, ?, ?,
+	//          ?, ?, ?, ?,
+	//          ?, ?, ?,
+	//          ?, ?, ?, ?)
+	//  RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
 	Create(ctx context.Context, arg CreateParams) (*Post, error)
+	//Delete
+	//
+	//  UPDATE posts
+	//  SET deleted_at = datetime('now')
+	//  WHERE id = ?
+	//    AND user_id = ?
+	//    AND deleted_at IS NULL
+	Delete(ctx context.Context, arg DeleteParams) (int64, error)
 	//Edit
 	//
 	//  UPDATE posts
-	//  SET title = ?, short = ?, body = ?, tags = ?, is_published = ?, updated_at = datetime('now')
+	//  SET title = ?, short = ?, body = ?, tags = ?, is_published = ?,
+	//      cover_image = ?, meta_description = ?, canonical_url = ?, noindex = ?,
+	//      og_title = ?, og_description = ?, og_image = ?,
+	//      twitter_card = ?, twitter_title = ?, twitter_description = ?, twitter_image = ?,
+	//      updated_at = datetime('now')
 	//  WHERE id = ?
 	//    AND deleted_at IS NULL
 	//    AND user_id = ?
-	//  RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at
+	//  RETURNING id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
 	Edit(ctx context.Context, arg EditParams) (*Post, error)
 	//GetByID
 	//
-	//  SELECT id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at
+	//  SELECT id, user_id, title, short, body, tags, is_published, created_at, updated_at, deleted_at, cover_image, meta_description, canonical_url, noindex, og_title, og_description, og_image, twitter_card, twitter_title, twitter_description, twitter_image
 	//  FROM posts
 	//  WHERE deleted_at IS NULL
 	//    AND id = ?
@@ -34,7 +52,7 @@ type Querier interface {
 	GetByID(ctx context.Context, arg GetByIDParams) (*Post, error)
 	//List
 	//
-	//  SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, count(*) over()
+	//  SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
 	//  FROM posts
 	//  WHERE deleted_at IS NULL
 	//    AND CASE WHEN CAST(?1 AS boolean) IS TRUE THEN is_published IS true ELSE true END
@@ -44,6 +62,18 @@ type Querier interface {
 	//  LIMIT CASE WHEN CAST(?5 AS int) > 0 THEN ?5 ELSE 10 END
 	//  OFFSET ?4
 	List(ctx context.Context, arg ListParams) ([]*ListRow, error)
+	//ListFollowing
+	//
+	//  SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image, count(*) over()
+	//  FROM posts
+	//  JOIN follows ON follows.followee_id = posts.user_id
+	//  WHERE follows.follower_id = ?1
+	//    AND posts.deleted_at IS NULL
+	//    AND posts.is_published IS true
+	//  ORDER BY posts.id DESC
+	//  LIMIT CASE WHEN CAST(?3 AS int) > 0 THEN ?3 ELSE 10 END
+	//  OFFSET ?2
+	ListFollowing(ctx context.Context, arg ListFollowingParams) ([]*ListFollowingRow, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,118 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package reaction
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.addStmt, err = db.PrepareContext(ctx, add); err != nil {
+		return nil, fmt.Errorf("error preparing query Add: %w", err)
+	}
+	if q.listByUserStmt, err = db.PrepareContext(ctx, listByUser); err != nil {
+		return nil, fmt.Errorf("error preparing query ListByUser: %w", err)
+	}
+	if q.listCountsStmt, err = db.PrepareContext(ctx, listCounts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCounts: %w", err)
+	}
+	if q.removeStmt, err = db.PrepareContext(ctx, remove); err != nil {
+		return nil, fmt.Errorf("error preparing query Remove: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.addStmt != nil {
+		if cerr := q.addStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addStmt: %w", cerr)
+		}
+	}
+	if q.listByUserStmt != nil {
+		if cerr := q.listByUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listByUserStmt: %w", cerr)
+		}
+	}
+	if q.listCountsStmt != nil {
+		if cerr := q.listCountsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCountsStmt: %w", cerr)
+		}
+	}
+	if q.removeStmt != nil {
+		if cerr := q.removeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db             DBTX
+	tx             *sql.Tx
+	addStmt        *sql.Stmt
+	listByUserStmt *sql.Stmt
+	listCountsStmt *sql.Stmt
+	removeStmt     *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:             tx,
+		tx:             tx,
+		addStmt:        q.addStmt,
+		listByUserStmt: q.listByUserStmt,
+		listCountsStmt: q.listCountsStmt,
+		removeStmt:     q.removeStmt,
+	}
+}
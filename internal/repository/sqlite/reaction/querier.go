@@ -0,0 +1,43 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package reaction
+
+import (
+	"context"
+)
+
+type Querier interface {
+	//Add
+	//
+	//  INSERT INTO post_reactions (post_id, user_id, reaction)
+	//  VALUES (?, ?, ?)
+	//  ON CONFLICT (post_id, user_id, reaction) DO NOTHING
+	Add(ctx context.Context, arg AddParams) (int64, error)
+	//ListByUser
+	//
+	//  SELECT post_id, reaction
+	//  FROM post_reactions
+	//  WHERE user_id = ?1
+	//    AND post_id IN (/*SLICE:post_ids*/?)
+	//  ORDER BY post_id, created_at
+	ListByUser(ctx context.Context, arg ListByUserParams) ([]*ListByUserRow, error)
+	//ListCounts
+	//
+	//  SELECT post_id, reaction, count
+	//  FROM post_reaction_counts
+	//  WHERE post_id IN (/*SLICE:post_ids*/?)
+	//    AND count > 0
+	//  ORDER BY post_id, reaction
+	ListCounts(ctx context.Context, postIds []int64) ([]*PostReactionCount, error)
+	//Remove
+	//
+	//  DELETE FROM post_reactions
+	//  WHERE post_id = ?
+	//    AND user_id = ?
+	//    AND reaction = ?
+	Remove(ctx context.Context, arg RemoveParams) (int64, error)
+}
+
+var _ Querier = (*Queries)(nil)
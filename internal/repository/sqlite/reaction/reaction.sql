@@ -0,0 +1,24 @@
+-- name: Add :execrows
+INSERT INTO post_reactions (post_id, user_id, reaction)
+VALUES (?, ?, ?)
+ON CONFLICT (post_id, user_id, reaction) DO NOTHING;
+
+-- name: Remove :execrows
+DELETE FROM post_reactions
+WHERE post_id = ?
+  AND user_id = ?
+  AND reaction = ?;
+
+-- name: ListCounts :many
+SELECT *
+FROM post_reaction_counts
+WHERE post_id IN (sqlc.slice(post_ids))
+  AND count > 0
+ORDER BY post_id, reaction;
+
+-- name: ListByUser :many
+SELECT post_id, reaction
+FROM post_reactions
+WHERE user_id = sqlc.arg(user_id)
+  AND post_id IN (sqlc.slice(post_ids))
+ORDER BY post_id, created_at;
@@ -0,0 +1,170 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: reaction.sql
+
+package reaction
+
+import (
+	"context"
+	"strings"
+)
+
+const add = `-- name: Add :execrows
+INSERT INTO post_reactions (post_id, user_id, reaction)
+VALUES (?, ?, ?)
+ON CONFLICT (post_id, user_id, reaction) DO NOTHING
+`
+
+type AddParams struct {
+	PostID   int64  `json:"postId"`
+	UserID   int64  `json:"userId"`
+	Reaction string `json:"reaction"`
+}
+
+// Add
+//
+//	INSERT INTO post_reactions (post_id, user_id, reaction)
+//	VALUES (?, ?, ?)
+//	ON CONFLICT (post_id, user_id, reaction) DO NOTHING
+func (q *Queries) Add(ctx context.Context, arg AddParams) (int64, error) {
+	result, err := q.exec(ctx, q.addStmt, add, arg.PostID, arg.UserID, arg.Reaction)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listByUser = `-- name: ListByUser :many
+SELECT post_id, reaction
+FROM post_reactions
+WHERE user_id = ?1
+  AND post_id IN (/*SLICE:post_ids*/?)
+ORDER BY post_id, created_at
+`
+
+type ListByUserParams struct {
+	UserID  int64   `json:"userId"`
+	PostIds []int64 `json:"postIds"`
+}
+
+type ListByUserRow struct {
+	PostID   int64  `json:"postId"`
+	Reaction string `json:"reaction"`
+}
+
+// ListByUser
+//
+//	SELECT post_id, reaction
+//	FROM post_reactions
+//	WHERE user_id = ?1
+//	  AND post_id IN (/*SLICE:post_ids*/?)
+//	ORDER BY post_id, created_at
+func (q *Queries) ListByUser(ctx context.Context, arg ListByUserParams) ([]*ListByUserRow, error) {
+	query := listByUser
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.UserID)
+	if len(arg.PostIds) > 0 {
+		for _, v := range arg.PostIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:post_ids*/?", strings.Repeat(",?", len(arg.PostIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:post_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListByUserRow{}
+	for rows.Next() {
+		var i ListByUserRow
+		if err := rows.Scan(&i.PostID, &i.Reaction); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCounts = `-- name: ListCounts :many
+SELECT post_id, reaction, count
+FROM post_reaction_counts
+WHERE post_id IN (/*SLICE:post_ids*/?)
+  AND count > 0
+ORDER BY post_id, reaction
+`
+
+// ListCounts
+//
+//	SELECT post_id, reaction, count
+//	FROM post_reaction_counts
+//	WHERE post_id IN (/*SLICE:post_ids*/?)
+//	  AND count > 0
+//	ORDER BY post_id, reaction
+func (q *Queries) ListCounts(ctx context.Context, postIds []int64) ([]*PostReactionCount, error) {
+	query := listCounts
+	var queryParams []interface{}
+	if len(postIds) > 0 {
+		for _, v := range postIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:post_ids*/?", strings.Repeat(",?", len(postIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:post_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*PostReactionCount{}
+	for rows.Next() {
+		var i PostReactionCount
+		if err := rows.Scan(&i.PostID, &i.Reaction, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const remove = `-- name: Remove :execrows
+DELETE FROM post_reactions
+WHERE post_id = ?
+  AND user_id = ?
+  AND reaction = ?
+`
+
+type RemoveParams struct {
+	PostID   int64  `json:"postId"`
+	UserID   int64  `json:"userId"`
+	Reaction string `json:"reaction"`
+}
+
+// Remove
+//
+//	DELETE FROM post_reactions
+//	WHERE post_id = ?
+//	  AND user_id = ?
+//	  AND reaction = ?
+func (q *Queries) Remove(ctx context.Context, arg RemoveParams) (int64, error) {
+	result, err := q.exec(ctx, q.removeStmt, remove, arg.PostID, arg.UserID, arg.Reaction)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
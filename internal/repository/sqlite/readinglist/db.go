@@ -0,0 +1,198 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package readinglist
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.addItemStmt, err = db.PrepareContext(ctx, addItem); err != nil {
+		return nil, fmt.Errorf("error preparing query AddItem: %w", err)
+	}
+	if q.createStmt, err = db.PrepareContext(ctx, create); err != nil {
+		return nil, fmt.Errorf("error preparing query Create: %w", err)
+	}
+	if q.deleteStmt, err = db.PrepareContext(ctx, delete); err != nil {
+		return nil, fmt.Errorf("error preparing query Delete: %w", err)
+	}
+	if q.editStmt, err = db.PrepareContext(ctx, edit); err != nil {
+		return nil, fmt.Errorf("error preparing query Edit: %w", err)
+	}
+	if q.getByIDStmt, err = db.PrepareContext(ctx, getByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetByID: %w", err)
+	}
+	if q.listByUserStmt, err = db.PrepareContext(ctx, listByUser); err != nil {
+		return nil, fmt.Errorf("error preparing query ListByUser: %w", err)
+	}
+	if q.listItemPostIDsStmt, err = db.PrepareContext(ctx, listItemPostIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItemPostIDs: %w", err)
+	}
+	if q.listItemsStmt, err = db.PrepareContext(ctx, listItems); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItems: %w", err)
+	}
+	if q.listSavedPostIDsStmt, err = db.PrepareContext(ctx, listSavedPostIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSavedPostIDs: %w", err)
+	}
+	if q.removeItemStmt, err = db.PrepareContext(ctx, removeItem); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveItem: %w", err)
+	}
+	if q.setItemPositionStmt, err = db.PrepareContext(ctx, setItemPosition); err != nil {
+		return nil, fmt.Errorf("error preparing query SetItemPosition: %w", err)
+	}
+	if q.touchStmt, err = db.PrepareContext(ctx, touch); err != nil {
+		return nil, fmt.Errorf("error preparing query Touch: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.addItemStmt != nil {
+		if cerr := q.addItemStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addItemStmt: %w", cerr)
+		}
+	}
+	if q.createStmt != nil {
+		if cerr := q.createStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStmt: %w", cerr)
+		}
+	}
+	if q.deleteStmt != nil {
+		if cerr := q.deleteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStmt: %w", cerr)
+		}
+	}
+	if q.editStmt != nil {
+		if cerr := q.editStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing editStmt: %w", cerr)
+		}
+	}
+	if q.getByIDStmt != nil {
+		if cerr := q.getByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getByIDStmt: %w", cerr)
+		}
+	}
+	if q.listByUserStmt != nil {
+		if cerr := q.listByUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listByUserStmt: %w", cerr)
+		}
+	}
+	if q.listItemPostIDsStmt != nil {
+		if cerr := q.listItemPostIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemPostIDsStmt: %w", cerr)
+		}
+	}
+	if q.listItemsStmt != nil {
+		if cerr := q.listItemsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemsStmt: %w", cerr)
+		}
+	}
+	if q.listSavedPostIDsStmt != nil {
+		if cerr := q.listSavedPostIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSavedPostIDsStmt: %w", cerr)
+		}
+	}
+	if q.removeItemStmt != nil {
+		if cerr := q.removeItemStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeItemStmt: %w", cerr)
+		}
+	}
+	if q.setItemPositionStmt != nil {
+		if cerr := q.setItemPositionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setItemPositionStmt: %w", cerr)
+		}
+	}
+	if q.touchStmt != nil {
+		if cerr := q.touchStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing touchStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db                   DBTX
+	tx                   *sql.Tx
+	addItemStmt          *sql.Stmt
+	createStmt           *sql.Stmt
+	deleteStmt           *sql.Stmt
+	editStmt             *sql.Stmt
+	getByIDStmt          *sql.Stmt
+	listByUserStmt       *sql.Stmt
+	listItemPostIDsStmt  *sql.Stmt
+	listItemsStmt        *sql.Stmt
+	listSavedPostIDsStmt *sql.Stmt
+	removeItemStmt       *sql.Stmt
+	setItemPositionStmt  *sql.Stmt
+	touchStmt            *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:                   tx,
+		tx:                   tx,
+		addItemStmt:          q.addItemStmt,
+		createStmt:           q.createStmt,
+		deleteStmt:           q.deleteStmt,
+		editStmt:             q.editStmt,
+		getByIDStmt:          q.getByIDStmt,
+		listByUserStmt:       q.listByUserStmt,
+		listItemPostIDsStmt:  q.listItemPostIDsStmt,
+		listItemsStmt:        q.listItemsStmt,
+		listSavedPostIDsStmt: q.listSavedPostIDsStmt,
+		removeItemStmt:       q.removeItemStmt,
+		setItemPositionStmt:  q.setItemPositionStmt,
+		touchStmt:            q.touchStmt,
+	}
+}
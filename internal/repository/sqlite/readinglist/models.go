@@ -0,0 +1,52 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package readinglist
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Post struct {
+	ID                 int64          `json:"id"`
+	UserID             int64          `json:"userId"`
+	Title              string         `json:"title"`
+	Short              string         `json:"short"`
+	Body               string         `json:"body"`
+	Tags               sql.NullString `json:"tags"`
+	IsPublished        bool           `json:"isPublished"`
+	CreatedAt          time.Time      `json:"createdAt"`
+	UpdatedAt          time.Time      `json:"updatedAt"`
+	DeletedAt          sql.NullTime   `json:"deletedAt"`
+	CoverImage         sql.NullString `json:"coverImage"`
+	MetaDescription    sql.NullString `json:"metaDescription"`
+	CanonicalUrl       sql.NullString `json:"canonicalUrl"`
+	Noindex            bool           `json:"noindex"`
+	OgTitle            sql.NullString `json:"ogTitle"`
+	OgDescription      sql.NullString `json:"ogDescription"`
+	OgImage            sql.NullString `json:"ogImage"`
+	TwitterCard        sql.NullString `json:"twitterCard"`
+	TwitterTitle       sql.NullString `json:"twitterTitle"`
+	TwitterDescription sql.NullString `json:"twitterDescription"`
+	TwitterImage       sql.NullString `json:"twitterImage"`
+}
+
+type ReadingList struct {
+	ID        int64        `json:"id"`
+	UserID    int64        `json:"userId"`
+	Title     string       `json:"title"`
+	IsPublic  bool         `json:"isPublic"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+	DeletedAt sql.NullTime `json:"deletedAt"`
+}
+
+type ReadingListItem struct {
+	ListID    int64          `json:"listId"`
+	PostID    int64          `json:"postId"`
+	Note      sql.NullString `json:"note"`
+	Position  int64          `json:"position"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
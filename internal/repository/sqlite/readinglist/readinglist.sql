@@ -0,0 +1,83 @@
+-- name: Create :one
+INSERT INTO reading_lists (user_id, title, is_public)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: Edit :one
+UPDATE reading_lists
+SET title = ?, is_public = ?, updated_at = datetime('now')
+WHERE id = ?
+  AND deleted_at IS NULL
+  AND user_id = ?
+RETURNING *;
+
+-- name: Delete :execrows
+UPDATE reading_lists
+SET deleted_at = datetime('now')
+WHERE id = ?
+  AND deleted_at IS NULL
+  AND user_id = ?;
+
+-- name: GetByID :one
+SELECT *
+FROM reading_lists
+WHERE id = ?
+  AND deleted_at IS NULL;
+
+-- name: ListByUser :many
+SELECT sqlc.embed(reading_lists),
+       (SELECT count(*) FROM reading_list_items WHERE reading_list_items.list_id = reading_lists.id) AS items_count,
+       count(*) over()
+FROM reading_lists
+WHERE user_id = sqlc.arg(user_id)
+  AND deleted_at IS NULL
+ORDER BY id DESC
+LIMIT CASE WHEN CAST(sqlc.arg(limit) AS int) > 0 THEN sqlc.arg(limit) ELSE 10 END
+OFFSET sqlc.arg(offset);
+
+-- name: AddItem :one
+INSERT INTO reading_list_items (list_id, post_id, note, position)
+VALUES (sqlc.arg(list_id), sqlc.arg(post_id), sqlc.narg(note),
+        (SELECT coalesce(max(position) + 1, 0) FROM reading_list_items WHERE list_id = sqlc.arg(list_id)))
+ON CONFLICT (list_id, post_id) DO UPDATE SET note = excluded.note
+RETURNING *;
+
+-- name: RemoveItem :execrows
+DELETE FROM reading_list_items
+WHERE list_id = ?
+  AND post_id = ?;
+
+-- name: ListItems :many
+SELECT sqlc.embed(reading_list_items), sqlc.embed(posts)
+FROM reading_list_items
+JOIN posts ON posts.id = reading_list_items.post_id
+WHERE reading_list_items.list_id = ?
+  AND posts.deleted_at IS NULL
+  AND posts.is_published IS TRUE
+ORDER BY reading_list_items.position;
+
+-- name: ListItemPostIDs :many
+SELECT post_id
+FROM reading_list_items
+WHERE list_id = ?
+ORDER BY position;
+
+-- name: SetItemPosition :exec
+UPDATE reading_list_items
+SET position = ?
+WHERE list_id = ?
+  AND post_id = ?;
+
+-- name: Touch :exec
+UPDATE reading_lists
+SET updated_at = datetime('now')
+WHERE id = ?
+  AND deleted_at IS NULL;
+
+-- name: ListSavedPostIDs :many
+SELECT DISTINCT reading_list_items.post_id
+FROM reading_list_items
+JOIN reading_lists ON reading_lists.id = reading_list_items.list_id
+WHERE reading_lists.user_id = sqlc.arg(user_id)
+  AND reading_lists.deleted_at IS NULL
+  AND reading_list_items.post_id IN (sqlc.slice(post_ids));
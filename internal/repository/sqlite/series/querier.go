@@ -0,0 +1,62 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package series
+
+import (
+	"context"
+)
+
+type Querier interface {
+	//AddPost
+	//
+	//  INSERT INTO series_posts (series_id, post_id, position)
+	//  VALUES (?, ?, ?)
+	AddPost(ctx context.Context, arg AddPostParams) error
+	//Create
+	//
+	//  INSERT INTO series (user_id, title, description)
+	//  VALUES (?, ?, ?)
+	//  RETURNING id, user_id, title, description, created_at, updated_at, deleted_at
+	Create(ctx context.Context, arg CreateParams) (*Series, error)
+	//DeletePosts
+	//
+	//  DELETE FROM series_posts
+	//  WHERE series_id = ?
+	DeletePosts(ctx context.Context, seriesID int64) error
+	//GetByID
+	//
+	//  SELECT id, user_id, title, description, created_at, updated_at, deleted_at
+	//  FROM series
+	//  WHERE id = ?
+	//    AND deleted_at IS NULL
+	GetByID(ctx context.Context, id int64) (*Series, error)
+	//GetByPostID
+	//
+	//  SELECT series.id, series.user_id, series.title, series.description, series.created_at, series.updated_at, series.deleted_at
+	//  FROM series
+	//  JOIN series_posts ON series_posts.series_id = series.id
+	//  WHERE series_posts.post_id = ?
+	//    AND series.deleted_at IS NULL
+	GetByPostID(ctx context.Context, postID int64) (*Series, error)
+	//ListPosts
+	//
+	//  SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image
+	//  FROM series_posts
+	//  JOIN posts ON posts.id = series_posts.post_id
+	//  WHERE series_posts.series_id = ?1
+	//    AND posts.deleted_at IS NULL
+	//    AND CASE WHEN CAST(?2 AS boolean) IS TRUE THEN posts.is_published IS true ELSE true END
+	//  ORDER BY series_posts.position
+	ListPosts(ctx context.Context, arg ListPostsParams) ([]*ListPostsRow, error)
+	//Touch
+	//
+	//  UPDATE series
+	//  SET updated_at = datetime('now')
+	//  WHERE id = ?
+	//    AND deleted_at IS NULL
+	Touch(ctx context.Context, id int64) error
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,40 @@
+-- name: Create :one
+INSERT INTO series (user_id, title, description)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: GetByID :one
+SELECT *
+FROM series
+WHERE id = ?
+  AND deleted_at IS NULL;
+
+-- name: GetByPostID :one
+SELECT series.*
+FROM series
+JOIN series_posts ON series_posts.series_id = series.id
+WHERE series_posts.post_id = ?
+  AND series.deleted_at IS NULL;
+
+-- name: ListPosts :many
+SELECT sqlc.embed(posts)
+FROM series_posts
+JOIN posts ON posts.id = series_posts.post_id
+WHERE series_posts.series_id = sqlc.arg(series_id)
+  AND posts.deleted_at IS NULL
+  AND CASE WHEN CAST(sqlc.arg(display_only_published) AS boolean) IS TRUE THEN posts.is_published IS true ELSE true END
+ORDER BY series_posts.position;
+
+-- name: AddPost :exec
+INSERT INTO series_posts (series_id, post_id, position)
+VALUES (?, ?, ?);
+
+-- name: DeletePosts :exec
+DELETE FROM series_posts
+WHERE series_id = ?;
+
+-- name: Touch :exec
+UPDATE series
+SET updated_at = datetime('now')
+WHERE id = ?
+  AND deleted_at IS NULL;
@@ -0,0 +1,226 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: series.sql
+
+package series
+
+import (
+	"context"
+	"database/sql"
+)
+
+const addPost = `-- name: AddPost :exec
+INSERT INTO series_posts (series_id, post_id, position)
+VALUES (?, ?, ?)
+`
+
+type AddPostParams struct {
+	SeriesID int64 `json:"seriesId"`
+	PostID   int64 `json:"postId"`
+	Position int64 `json:"position"`
+}
+
+// AddPost
+//
+//	INSERT INTO series_posts (series_id, post_id, position)
+//	VALUES (?, ?, ?)
+func (q *Queries) AddPost(ctx context.Context, arg AddPostParams) error {
+	_, err := q.exec(ctx, q.addPostStmt, addPost, arg.SeriesID, arg.PostID, arg.Position)
+	return err
+}
+
+const create = `-- name: Create :one
+INSERT INTO series (user_id, title, description)
+VALUES (?, ?, ?)
+RETURNING id, user_id, title, description, created_at, updated_at, deleted_at
+`
+
+type CreateParams struct {
+	UserID      int64          `json:"userId"`
+	Title       string         `json:"title"`
+	Description sql.NullString `json:"description"`
+}
+
+// Create
+//
+//	INSERT INTO series (user_id, title, description)
+//	VALUES (?, ?, ?)
+//	RETURNING id, user_id, title, description, created_at, updated_at, deleted_at
+func (q *Queries) Create(ctx context.Context, arg CreateParams) (*Series, error) {
+	row := q.queryRow(ctx, q.createStmt, create, arg.UserID, arg.Title, arg.Description)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return &i, err
+}
+
+const deletePosts = `-- name: DeletePosts :exec
+DELETE FROM series_posts
+WHERE series_id = ?
+`
+
+// DeletePosts
+//
+//	DELETE FROM series_posts
+//	WHERE series_id = ?
+func (q *Queries) DeletePosts(ctx context.Context, seriesID int64) error {
+	_, err := q.exec(ctx, q.deletePostsStmt, deletePosts, seriesID)
+	return err
+}
+
+const getByID = `-- name: GetByID :one
+SELECT id, user_id, title, description, created_at, updated_at, deleted_at
+FROM series
+WHERE id = ?
+  AND deleted_at IS NULL
+`
+
+// GetByID
+//
+//	SELECT id, user_id, title, description, created_at, updated_at, deleted_at
+//	FROM series
+//	WHERE id = ?
+//	  AND deleted_at IS NULL
+func (q *Queries) GetByID(ctx context.Context, id int64) (*Series, error) {
+	row := q.queryRow(ctx, q.getByIDStmt, getByID, id)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return &i, err
+}
+
+const getByPostID = `-- name: GetByPostID :one
+SELECT series.id, series.user_id, series.title, series.description, series.created_at, series.updated_at, series.deleted_at
+FROM series
+JOIN series_posts ON series_posts.series_id = series.id
+WHERE series_posts.post_id = ?
+  AND series.deleted_at IS NULL
+`
+
+// GetByPostID
+//
+//	SELECT series.id, series.user_id, series.title, series.description, series.created_at, series.updated_at, series.deleted_at
+//	FROM series
+//	JOIN series_posts ON series_posts.series_id = series.id
+//	WHERE series_posts.post_id = ?
+//	  AND series.deleted_at IS NULL
+func (q *Queries) GetByPostID(ctx context.Context, postID int64) (*Series, error) {
+	row := q.queryRow(ctx, q.getByPostIDStmt, getByPostID, postID)
+	var i Series
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return &i, err
+}
+
+const listPosts = `-- name: ListPosts :many
+SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image
+FROM series_posts
+JOIN posts ON posts.id = series_posts.post_id
+WHERE series_posts.series_id = ?1
+  AND posts.deleted_at IS NULL
+  AND CASE WHEN CAST(?2 AS boolean) IS TRUE THEN posts.is_published IS true ELSE true END
+ORDER BY series_posts.position
+`
+
+type ListPostsParams struct {
+	SeriesID             int64 `json:"seriesId"`
+	DisplayOnlyPublished bool  `json:"displayOnlyPublished"`
+}
+
+type ListPostsRow struct {
+	Post Post `json:"post"`
+}
+
+// ListPosts
+//
+//	SELECT posts.id, posts.user_id, posts.title, posts.short, posts.body, posts.tags, posts.is_published, posts.created_at, posts.updated_at, posts.deleted_at, posts.cover_image, posts.meta_description, posts.canonical_url, posts.noindex, posts.og_title, posts.og_description, posts.og_image, posts.twitter_card, posts.twitter_title, posts.twitter_description, posts.twitter_image
+//	FROM series_posts
+//	JOIN posts ON posts.id = series_posts.post_id
+//	WHERE series_posts.series_id = ?1
+//	  AND posts.deleted_at IS NULL
+//	  AND CASE WHEN CAST(?2 AS boolean) IS TRUE THEN posts.is_published IS true ELSE true END
+//	ORDER BY series_posts.position
+func (q *Queries) ListPosts(ctx context.Context, arg ListPostsParams) ([]*ListPostsRow, error) {
+	rows, err := q.query(ctx, q.listPostsStmt, listPosts, arg.SeriesID, arg.DisplayOnlyPublished)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListPostsRow{}
+	for rows.Next() {
+		var i ListPostsRow
+		if err := rows.Scan(
+			&i.Post.ID,
+			&i.Post.UserID,
+			&i.Post.Title,
+			&i.Post.Short,
+			&i.Post.Body,
+			&i.Post.Tags,
+			&i.Post.IsPublished,
+			&i.Post.CreatedAt,
+			&i.Post.UpdatedAt,
+			&i.Post.DeletedAt,
+			&i.Post.CoverImage,
+			&i.Post.MetaDescription,
+			&i.Post.CanonicalUrl,
+			&i.Post.Noindex,
+			&i.Post.OgTitle,
+			&i.Post.OgDescription,
+			&i.Post.OgImage,
+			&i.Post.TwitterCard,
+			&i.Post.TwitterTitle,
+			&i.Post.TwitterDescription,
+			&i.Post.TwitterImage,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const touch = `-- name: Touch :exec
+UPDATE series
+SET updated_at = datetime('now')
+WHERE id = ?
+  AND deleted_at IS NULL
+`
+
+// Touch
+//
+//	UPDATE series
+//	SET updated_at = datetime('now')
+//	WHERE id = ?
+//	  AND deleted_at IS NULL
+func (q *Queries) Touch(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.touchStmt, touch, id)
+	return err
+}
@@ -0,0 +1,148 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package session
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.countCreatedAfterStmt, err = db.PrepareContext(ctx, countCreatedAfter); err != nil {
+		return nil, fmt.Errorf("error preparing query CountCreatedAfter: %w", err)
+	}
+	if q.deleteBySessionHashStmt, err = db.PrepareContext(ctx, deleteBySessionHash); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteBySessionHash: %w", err)
+	}
+	if q.deleteByUserIDStmt, err = db.PrepareContext(ctx, deleteByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteByUserID: %w", err)
+	}
+	if q.deleteCreatedBeforeStmt, err = db.PrepareContext(ctx, deleteCreatedBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCreatedBefore: %w", err)
+	}
+	if q.getBySessionHashStmt, err = db.PrepareContext(ctx, getBySessionHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBySessionHash: %w", err)
+	}
+	if q.listStmt, err = db.PrepareContext(ctx, list); err != nil {
+		return nil, fmt.Errorf("error preparing query List: %w", err)
+	}
+	if q.putStmt, err = db.PrepareContext(ctx, put); err != nil {
+		return nil, fmt.Errorf("error preparing query Put: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.countCreatedAfterStmt != nil {
+		if cerr := q.countCreatedAfterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countCreatedAfterStmt: %w", cerr)
+		}
+	}
+	if q.deleteBySessionHashStmt != nil {
+		if cerr := q.deleteBySessionHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteBySessionHashStmt: %w", cerr)
+		}
+	}
+	if q.deleteByUserIDStmt != nil {
+		if cerr := q.deleteByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteCreatedBeforeStmt != nil {
+		if cerr := q.deleteCreatedBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCreatedBeforeStmt: %w", cerr)
+		}
+	}
+	if q.getBySessionHashStmt != nil {
+		if cerr := q.getBySessionHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBySessionHashStmt: %w", cerr)
+		}
+	}
+	if q.listStmt != nil {
+		if cerr := q.listStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStmt: %w", cerr)
+		}
+	}
+	if q.putStmt != nil {
+		if cerr := q.putStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing putStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db                      DBTX
+	tx                      *sql.Tx
+	countCreatedAfterStmt   *sql.Stmt
+	deleteBySessionHashStmt *sql.Stmt
+	deleteByUserIDStmt      *sql.Stmt
+	deleteCreatedBeforeStmt *sql.Stmt
+	getBySessionHashStmt    *sql.Stmt
+	listStmt                *sql.Stmt
+	putStmt                 *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:                      tx,
+		tx:                      tx,
+		countCreatedAfterStmt:   q.countCreatedAfterStmt,
+		deleteBySessionHashStmt: q.deleteBySessionHashStmt,
+		deleteByUserIDStmt:      q.deleteByUserIDStmt,
+		deleteCreatedBeforeStmt: q.deleteCreatedBeforeStmt,
+		getBySessionHashStmt:    q.getBySessionHashStmt,
+		listStmt:                q.listStmt,
+		putStmt:                 q.putStmt,
+	}
+}
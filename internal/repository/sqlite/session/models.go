@@ -0,0 +1,15 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package session
+
+import (
+	"time"
+)
+
+type Session struct {
+	SessionHash string    `json:"sessionHash"`
+	UserID      int64     `json:"userId"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
@@ -0,0 +1,155 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: session.sql
+
+package session
+
+import (
+	"context"
+	"time"
+)
+
+const countCreatedAfter = `-- name: CountCreatedAfter :one
+SELECT count(*)
+FROM sessions
+WHERE created_at > ?
+`
+
+// CountCreatedAfter
+//
+//	SELECT count(*)
+//	FROM sessions
+//	WHERE created_at > ?
+func (q *Queries) CountCreatedAfter(ctx context.Context, createdAt time.Time) (int64, error) {
+	row := q.queryRow(ctx, q.countCreatedAfterStmt, countCreatedAfter, createdAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteBySessionHash = `-- name: DeleteBySessionHash :exec
+DELETE FROM sessions
+WHERE session_hash = ?
+`
+
+// DeleteBySessionHash
+//
+//	DELETE FROM sessions
+//	WHERE session_hash = ?
+func (q *Queries) DeleteBySessionHash(ctx context.Context, sessionHash string) error {
+	_, err := q.exec(ctx, q.deleteBySessionHashStmt, deleteBySessionHash, sessionHash)
+	return err
+}
+
+const deleteByUserID = `-- name: DeleteByUserID :execrows
+DELETE FROM sessions
+WHERE user_id = ?
+`
+
+// DeleteByUserID
+//
+//	DELETE FROM sessions
+//	WHERE user_id = ?
+func (q *Queries) DeleteByUserID(ctx context.Context, userID int64) (int64, error) {
+	result, err := q.exec(ctx, q.deleteByUserIDStmt, deleteByUserID, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCreatedBefore = `-- name: DeleteCreatedBefore :execrows
+DELETE FROM sessions
+WHERE created_at < ?
+`
+
+// DeleteCreatedBefore
+//
+//	DELETE FROM sessions
+//	WHERE created_at < ?
+func (q *Queries) DeleteCreatedBefore(ctx context.Context, createdAt time.Time) (int64, error) {
+	result, err := q.exec(ctx, q.deleteCreatedBeforeStmt, deleteCreatedBefore, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getBySessionHash = `-- name: GetBySessionHash :one
+SELECT session_hash, user_id, created_at
+FROM sessions
+WHERE session_hash = ?
+`
+
+// GetBySessionHash
+//
+//	SELECT session_hash, user_id, created_at
+//	FROM sessions
+//	WHERE session_hash = ?
+func (q *Queries) GetBySessionHash(ctx context.Context, sessionHash string) (*Session, error) {
+	row := q.queryRow(ctx, q.getBySessionHashStmt, getBySessionHash, sessionHash)
+	var i Session
+	err := row.Scan(&i.SessionHash, &i.UserID, &i.CreatedAt)
+	return &i, err
+}
+
+const list = `-- name: List :many
+SELECT session_hash, user_id, created_at
+FROM sessions
+ORDER BY created_at
+`
+
+// List
+//
+//	SELECT session_hash, user_id, created_at
+//	FROM sessions
+//	ORDER BY created_at
+func (q *Queries) List(ctx context.Context) ([]*Session, error) {
+	rows, err := q.query(ctx, q.listStmt, list)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(&i.SessionHash, &i.UserID, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const put = `-- name: Put :one
+INSERT INTO sessions (session_hash, user_id, created_at)
+VALUES (?, ?, ?)
+ON CONFLICT (session_hash) DO UPDATE SET user_id = excluded.user_id, created_at = excluded.created_at
+RETURNING session_hash, user_id, created_at
+`
+
+type PutParams struct {
+	SessionHash string    `json:"sessionHash"`
+	UserID      int64     `json:"userId"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Put
+//
+//	INSERT INTO sessions (session_hash, user_id, created_at)
+//	VALUES (?, ?, ?)
+//	ON CONFLICT (session_hash) DO UPDATE SET user_id = excluded.user_id, created_at = excluded.created_at
+//	RETURNING session_hash, user_id, created_at
+func (q *Queries) Put(ctx context.Context, arg PutParams) (*Session, error) {
+	row := q.queryRow(ctx, q.putStmt, put, arg.SessionHash, arg.UserID, arg.CreatedAt)
+	var i Session
+	err := row.Scan(&i.SessionHash, &i.UserID, &i.CreatedAt)
+	return &i, err
+}
@@ -0,0 +1,93 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/models"
+	repositorySession "github.com/HardDie/blog_engine/internal/repository/session"
+)
+
+// Store keeps sessions in the table of the main DB. PostgreSQL uses it too, through the adapter of its queries.
+// Time is written in UTC, so SQLite compares it as text in the right order.
+type Store struct {
+	q Querier
+}
+
+var _ repositorySession.Store = (*Store)(nil)
+
+func NewStore(q Querier) *Store {
+	return &Store{
+		q: q,
+	}
+}
+
+func (s *Store) CreateOrUpdate(ctx context.Context, userID int64, sessionHash string) (*models.Session, error) {
+	row, err := s.q.Put(ctx, PutParams{
+		SessionHash: sessionHash,
+		UserID:      userID,
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toModel(row), nil
+}
+
+func (s *Store) DeleteBySessionHash(ctx context.Context, sessionHash string) error {
+	return s.q.DeleteBySessionHash(ctx, sessionHash)
+}
+
+func (s *Store) GetBySessionHash(ctx context.Context, sessionHash string) (*models.Session, error) {
+	row, err := s.q.GetBySessionHash(ctx, sessionHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repositorySession.ErrorNotFound
+		}
+		return nil, err
+	}
+	return toModel(row), nil
+}
+
+func (s *Store) CountCreatedAfter(ctx context.Context, createdAfter time.Time) (int64, error) {
+	return s.q.CountCreatedAfter(ctx, createdAfter.UTC())
+}
+
+func (s *Store) DeleteByUserID(ctx context.Context, userID int64) (int64, error) {
+	return s.q.DeleteByUserID(ctx, userID)
+}
+
+func (s *Store) DeleteCreatedBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	return s.q.DeleteCreatedBefore(ctx, createdBefore.UTC())
+}
+
+func (s *Store) List(ctx context.Context) ([]*models.Session, error) {
+	rows, err := s.q.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*models.Session, 0, len(rows))
+	for _, row := range rows {
+		list = append(list, toModel(row))
+	}
+	return list, nil
+}
+
+func (s *Store) Put(ctx context.Context, ses *models.Session) error {
+	_, err := s.q.Put(ctx, PutParams{
+		SessionHash: ses.SessionHash,
+		UserID:      ses.UserID,
+		CreatedAt:   ses.CreatedAt.UTC(),
+	})
+	return err
+}
+
+func toModel(row *Session) *models.Session {
+	return &models.Session{
+		UserID:      row.UserID,
+		SessionHash: row.SessionHash,
+		CreatedAt:   row.CreatedAt,
+	}
+}
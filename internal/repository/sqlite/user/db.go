@@ -24,6 +24,12 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.banStmt, err = db.PrepareContext(ctx, ban); err != nil {
+		return nil, fmt.Errorf("error preparing query Ban: %w", err)
+	}
+	if q.countRegisteredStmt, err = db.PrepareContext(ctx, countRegistered); err != nil {
+		return nil, fmt.Errorf("error preparing query CountRegistered: %w", err)
+	}
 	if q.createStmt, err = db.PrepareContext(ctx, create); err != nil {
 		return nil, fmt.Errorf("error preparing query Create: %w", err)
 	}
@@ -44,6 +50,16 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 
 func (q *Queries) Close() error {
 	var err error
+	if q.banStmt != nil {
+		if cerr := q.banStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing banStmt: %w", cerr)
+		}
+	}
+	if q.countRegisteredStmt != nil {
+		if cerr := q.countRegisteredStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countRegisteredStmt: %w", cerr)
+		}
+	}
 	if q.createStmt != nil {
 		if cerr := q.createStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createStmt: %w", cerr)
@@ -106,23 +122,27 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                 DBTX
-	tx                 *sql.Tx
-	createStmt         *sql.Stmt
-	getByIDPrivateStmt *sql.Stmt
-	getByIDPublicStmt  *sql.Stmt
-	getByNameStmt      *sql.Stmt
-	updateStmt         *sql.Stmt
+	db                  DBTX
+	tx                  *sql.Tx
+	banStmt             *sql.Stmt
+	countRegisteredStmt *sql.Stmt
+	createStmt          *sql.Stmt
+	getByIDPrivateStmt  *sql.Stmt
+	getByIDPublicStmt   *sql.Stmt
+	getByNameStmt       *sql.Stmt
+	updateStmt          *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                 tx,
-		tx:                 tx,
-		createStmt:         q.createStmt,
-		getByIDPrivateStmt: q.getByIDPrivateStmt,
-		getByIDPublicStmt:  q.getByIDPublicStmt,
-		getByNameStmt:      q.getByNameStmt,
-		updateStmt:         q.updateStmt,
+		db:                  tx,
+		tx:                  tx,
+		banStmt:             q.banStmt,
+		countRegisteredStmt: q.countRegisteredStmt,
+		createStmt:          q.createStmt,
+		getByIDPrivateStmt:  q.getByIDPrivateStmt,
+		getByIDPublicStmt:   q.getByIDPublicStmt,
+		getByNameStmt:       q.getByNameStmt,
+		updateStmt:          q.updateStmt,
 	}
 }
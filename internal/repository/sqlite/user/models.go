@@ -18,4 +18,5 @@ type User struct {
 	CreatedAt     time.Time      `json:"createdAt"`
 	UpdatedAt     time.Time      `json:"updatedAt"`
 	DeletedAt     sql.NullTime   `json:"deletedAt"`
+	BannedAt      sql.NullTime   `json:"bannedAt"`
 }
@@ -9,15 +9,29 @@ import (
 )
 
 type Querier interface {
+	//Ban
+	//
+	//  UPDATE users
+	//  SET banned_at = datetime('now'), updated_at = datetime('now')
+	//  WHERE id = ?
+	//    AND deleted_at IS NULL
+	//  RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
+	Ban(ctx context.Context, id int64) (*User, error)
+	//CountRegistered
+	//
+	//  SELECT count(*)
+	//  FROM users
+	//  WHERE id <> 0
+	CountRegistered(ctx context.Context) (int64, error)
 	//Create
 	//
 	//  INSERT INTO users (username, displayed_name, invited_by_user)
 	//  VALUES (?, ?, ?)
-	//  RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+	//  RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 	Create(ctx context.Context, arg CreateParams) (*User, error)
 	//GetByIDPrivate
 	//
-	//  SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+	//  SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 	//  FROM users
 	//  WHERE id = ?
 	//    AND deleted_at IS NULL
@@ -31,7 +45,7 @@ type Querier interface {
 	GetByIDPublic(ctx context.Context, id int64) (*GetByIDPublicRow, error)
 	//GetByName
 	//
-	//  SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+	//  SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 	//  FROM users
 	//  WHERE username = ?
 	//    AND deleted_at IS NULL
@@ -42,7 +56,7 @@ type Querier interface {
 	//  SET displayed_name = ?, email = ?, updated_at = datetime('now')
 	//  WHERE id = ?
 	//    AND deleted_at IS NULL
-	//  RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+	//  RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 	Update(ctx context.Context, arg UpdateParams) (*User, error)
 }
 
@@ -27,3 +27,15 @@ SET displayed_name = ?, email = ?, updated_at = datetime('now')
 WHERE id = ?
   AND deleted_at IS NULL
 RETURNING *;
+
+-- name: Ban :one
+UPDATE users
+SET banned_at = datetime('now'), updated_at = datetime('now')
+WHERE id = ?
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: CountRegistered :one
+SELECT count(*)
+FROM users
+WHERE id <> 0;
@@ -11,10 +11,60 @@ import (
 	"time"
 )
 
+const ban = `-- name: Ban :one
+UPDATE users
+SET banned_at = datetime('now'), updated_at = datetime('now')
+WHERE id = ?
+  AND deleted_at IS NULL
+RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
+`
+
+// Ban
+//
+//	UPDATE users
+//	SET banned_at = datetime('now'), updated_at = datetime('now')
+//	WHERE id = ?
+//	  AND deleted_at IS NULL
+//	RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
+func (q *Queries) Ban(ctx context.Context, id int64) (*User, error) {
+	row := q.queryRow(ctx, q.banStmt, ban, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.DisplayedName,
+		&i.Email,
+		&i.InvitedByUser,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.BannedAt,
+	)
+	return &i, err
+}
+
+const countRegistered = `-- name: CountRegistered :one
+SELECT count(*)
+FROM users
+WHERE id <> 0
+`
+
+// CountRegistered
+//
+//	SELECT count(*)
+//	FROM users
+//	WHERE id <> 0
+func (q *Queries) CountRegistered(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.countRegisteredStmt, countRegistered)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const create = `-- name: Create :one
 INSERT INTO users (username, displayed_name, invited_by_user)
 VALUES (?, ?, ?)
-RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 `
 
 type CreateParams struct {
@@ -27,7 +77,7 @@ type CreateParams struct {
 //
 //	INSERT INTO users (username, displayed_name, invited_by_user)
 //	VALUES (?, ?, ?)
-//	RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+//	RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 func (q *Queries) Create(ctx context.Context, arg CreateParams) (*User, error) {
 	row := q.queryRow(ctx, q.createStmt, create, arg.Username, arg.DisplayedName, arg.InvitedByUser)
 	var i User
@@ -40,12 +90,13 @@ func (q *Queries) Create(ctx context.Context, arg CreateParams) (*User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.BannedAt,
 	)
 	return &i, err
 }
 
 const getByIDPrivate = `-- name: GetByIDPrivate :one
-SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 FROM users
 WHERE id = ?
   AND deleted_at IS NULL
@@ -53,7 +104,7 @@ WHERE id = ?
 
 // GetByIDPrivate
 //
-//	SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+//	SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 //	FROM users
 //	WHERE id = ?
 //	  AND deleted_at IS NULL
@@ -69,6 +120,7 @@ func (q *Queries) GetByIDPrivate(ctx context.Context, id int64) (*User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.BannedAt,
 	)
 	return &i, err
 }
@@ -110,7 +162,7 @@ func (q *Queries) GetByIDPublic(ctx context.Context, id int64) (*GetByIDPublicRo
 }
 
 const getByName = `-- name: GetByName :one
-SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 FROM users
 WHERE username = ?
   AND deleted_at IS NULL
@@ -118,7 +170,7 @@ WHERE username = ?
 
 // GetByName
 //
-//	SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+//	SELECT id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 //	FROM users
 //	WHERE username = ?
 //	  AND deleted_at IS NULL
@@ -134,6 +186,7 @@ func (q *Queries) GetByName(ctx context.Context, username string) (*User, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.BannedAt,
 	)
 	return &i, err
 }
@@ -143,7 +196,7 @@ UPDATE users
 SET displayed_name = ?, email = ?, updated_at = datetime('now')
 WHERE id = ?
   AND deleted_at IS NULL
-RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 `
 
 type UpdateParams struct {
@@ -158,7 +211,7 @@ type UpdateParams struct {
 //	SET displayed_name = ?, email = ?, updated_at = datetime('now')
 //	WHERE id = ?
 //	  AND deleted_at IS NULL
-//	RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at
+//	RETURNING id, username, displayed_name, email, invited_by_user, created_at, updated_at, deleted_at, banned_at
 func (q *Queries) Update(ctx context.Context, arg UpdateParams) (*User, error) {
 	row := q.queryRow(ctx, q.updateStmt, update, arg.DisplayedName, arg.Email, arg.ID)
 	var i User
@@ -171,6 +224,7 @@ func (q *Queries) Update(ctx context.Context, arg UpdateParams) (*User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.BannedAt,
 	)
 	return &i, err
 }
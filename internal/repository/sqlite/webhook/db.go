@@ -0,0 +1,198 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.cancelDeliveriesStmt, err = db.PrepareContext(ctx, cancelDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query CancelDeliveries: %w", err)
+	}
+	if q.createStmt, err = db.PrepareContext(ctx, create); err != nil {
+		return nil, fmt.Errorf("error preparing query Create: %w", err)
+	}
+	if q.createDeliveryStmt, err = db.PrepareContext(ctx, createDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateDelivery: %w", err)
+	}
+	if q.deleteStmt, err = db.PrepareContext(ctx, delete); err != nil {
+		return nil, fmt.Errorf("error preparing query Delete: %w", err)
+	}
+	if q.getByIDStmt, err = db.PrepareContext(ctx, getByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetByID: %w", err)
+	}
+	if q.getDeliveryStmt, err = db.PrepareContext(ctx, getDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDelivery: %w", err)
+	}
+	if q.getPendingDeliveryStmt, err = db.PrepareContext(ctx, getPendingDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPendingDelivery: %w", err)
+	}
+	if q.listByUserStmt, err = db.PrepareContext(ctx, listByUser); err != nil {
+		return nil, fmt.Errorf("error preparing query ListByUser: %w", err)
+	}
+	if q.listDeliveriesStmt, err = db.PrepareContext(ctx, listDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDeliveries: %w", err)
+	}
+	if q.markDeliveryFailedStmt, err = db.PrepareContext(ctx, markDeliveryFailed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkDeliveryFailed: %w", err)
+	}
+	if q.markDeliveryRetryStmt, err = db.PrepareContext(ctx, markDeliveryRetry); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkDeliveryRetry: %w", err)
+	}
+	if q.markDeliverySucceededStmt, err = db.PrepareContext(ctx, markDeliverySucceeded); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkDeliverySucceeded: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.cancelDeliveriesStmt != nil {
+		if cerr := q.cancelDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cancelDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.createStmt != nil {
+		if cerr := q.createStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStmt: %w", cerr)
+		}
+	}
+	if q.createDeliveryStmt != nil {
+		if cerr := q.createDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.deleteStmt != nil {
+		if cerr := q.deleteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStmt: %w", cerr)
+		}
+	}
+	if q.getByIDStmt != nil {
+		if cerr := q.getByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getByIDStmt: %w", cerr)
+		}
+	}
+	if q.getDeliveryStmt != nil {
+		if cerr := q.getDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.getPendingDeliveryStmt != nil {
+		if cerr := q.getPendingDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPendingDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.listByUserStmt != nil {
+		if cerr := q.listByUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listByUserStmt: %w", cerr)
+		}
+	}
+	if q.listDeliveriesStmt != nil {
+		if cerr := q.listDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.markDeliveryFailedStmt != nil {
+		if cerr := q.markDeliveryFailedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markDeliveryFailedStmt: %w", cerr)
+		}
+	}
+	if q.markDeliveryRetryStmt != nil {
+		if cerr := q.markDeliveryRetryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markDeliveryRetryStmt: %w", cerr)
+		}
+	}
+	if q.markDeliverySucceededStmt != nil {
+		if cerr := q.markDeliverySucceededStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markDeliverySucceededStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db                        DBTX
+	tx                        *sql.Tx
+	cancelDeliveriesStmt      *sql.Stmt
+	createStmt                *sql.Stmt
+	createDeliveryStmt        *sql.Stmt
+	deleteStmt                *sql.Stmt
+	getByIDStmt               *sql.Stmt
+	getDeliveryStmt           *sql.Stmt
+	getPendingDeliveryStmt    *sql.Stmt
+	listByUserStmt            *sql.Stmt
+	listDeliveriesStmt        *sql.Stmt
+	markDeliveryFailedStmt    *sql.Stmt
+	markDeliveryRetryStmt     *sql.Stmt
+	markDeliverySucceededStmt *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:                        tx,
+		tx:                        tx,
+		cancelDeliveriesStmt:      q.cancelDeliveriesStmt,
+		createStmt:                q.createStmt,
+		createDeliveryStmt:        q.createDeliveryStmt,
+		deleteStmt:                q.deleteStmt,
+		getByIDStmt:               q.getByIDStmt,
+		getDeliveryStmt:           q.getDeliveryStmt,
+		getPendingDeliveryStmt:    q.getPendingDeliveryStmt,
+		listByUserStmt:            q.listByUserStmt,
+		listDeliveriesStmt:        q.listDeliveriesStmt,
+		markDeliveryFailedStmt:    q.markDeliveryFailedStmt,
+		markDeliveryRetryStmt:     q.markDeliveryRetryStmt,
+		markDeliverySucceededStmt: q.markDeliverySucceededStmt,
+	}
+}
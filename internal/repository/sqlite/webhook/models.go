@@ -0,0 +1,35 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package webhook
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Webhook struct {
+	ID        int64        `json:"id"`
+	UserID    int64        `json:"userId"`
+	Url       string       `json:"url"`
+	Secret    string       `json:"secret"`
+	Events    string       `json:"events"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+	DeletedAt sql.NullTime `json:"deletedAt"`
+}
+
+type WebhookDelivery struct {
+	ID             int64          `json:"id"`
+	WebhookID      int64          `json:"webhookId"`
+	Event          string         `json:"event"`
+	Payload        string         `json:"payload"`
+	Status         string         `json:"status"`
+	Attempts       int64          `json:"attempts"`
+	NextAttemptAt  time.Time      `json:"nextAttemptAt"`
+	ResponseStatus sql.NullInt64  `json:"responseStatus"`
+	Error          sql.NullString `json:"error"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+}
@@ -0,0 +1,101 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package webhook
+
+import (
+	"context"
+)
+
+type Querier interface {
+	//CancelDeliveries
+	//
+	//  UPDATE webhook_deliveries
+	//  SET status = 'failed', error = 'webhook was deleted', updated_at = datetime('now')
+	//  WHERE webhook_id = ?
+	//    AND status = 'pending'
+	CancelDeliveries(ctx context.Context, webhookID int64) error
+	//Create
+	//
+	//  INSERT INTO webhooks (user_id, url, secret, events)
+	//  VALUES (?, ?, ?, ?)
+	//  RETURNING id, user_id, url, secret, events, created_at, updated_at, deleted_at
+	Create(ctx context.Context, arg CreateParams) (*Webhook, error)
+	//CreateDelivery
+	//
+	//  INSERT INTO webhook_deliveries (webhook_id, event, payload)
+	//  VALUES (?, ?, ?)
+	//  RETURNING id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status, error, created_at, updated_at
+	CreateDelivery(ctx context.Context, arg CreateDeliveryParams) (*WebhookDelivery, error)
+	//Delete
+	//
+	//  UPDATE webhooks
+	//  SET deleted_at = datetime('now')
+	//  WHERE id = ?
+	//    AND user_id = ?
+	//    AND deleted_at IS NULL
+	Delete(ctx context.Context, arg DeleteParams) (int64, error)
+	//GetByID
+	//
+	//  SELECT id, user_id, url, secret, events, created_at, updated_at, deleted_at
+	//  FROM webhooks
+	//  WHERE id = ?
+	//    AND user_id = ?
+	//    AND deleted_at IS NULL
+	GetByID(ctx context.Context, arg GetByIDParams) (*Webhook, error)
+	//GetDelivery
+	//
+	//  SELECT id, webhook_id, event, payload, status, attempts, next_attempt_at, response_status, error, created_at, updated_at
+	//  FROM webhook_deliveries
+	//  WHERE id = ?
+	//    AND webhook_id = ?
+	GetDelivery(ctx context.Context, arg GetDeliveryParams) (*WebhookDelivery, error)
+	//GetPendingDelivery
+	//
+	//  SELECT webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.event, webhook_deliveries.payload, webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at, webhook_deliveries.response_status, webhook_deliveries.error, webhook_deliveries.created_at, webhook_deliveries.updated_at, webhooks.url, webhooks.secret
+	//  FROM webhook_deliveries
+	//  JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+	//  WHERE webhook_deliveries.id = ?
+	//    AND webhook_deliveries.status = 'pending'
+	//    AND webhooks.deleted_at IS NULL
+	GetPendingDelivery(ctx context.Context, id int64) (*GetPendingDeliveryRow, error)
+	//ListByUser
+	//
+	//  SELECT id, user_id, url, secret, events, created_at, updated_at, deleted_at
+	//  FROM webhooks
+	//  WHERE user_id = ?
+	//    AND deleted_at IS NULL
+	//  ORDER BY id
+	ListByUser(ctx context.Context, userID int64) ([]*Webhook, error)
+	//ListDeliveries
+	//
+	//  SELECT webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.event, webhook_deliveries.payload, webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at, webhook_deliveries.response_status, webhook_deliveries.error, webhook_deliveries.created_at, webhook_deliveries.updated_at, count(*) over()
+	//  FROM webhook_deliveries
+	//  WHERE webhook_id = ?1
+	//  ORDER BY id DESC
+	//  LIMIT CASE WHEN CAST(?3 AS int) > 0 THEN ?3 ELSE 10 END
+	//  OFFSET ?2
+	ListDeliveries(ctx context.Context, arg ListDeliveriesParams) ([]*ListDeliveriesRow, error)
+	//MarkDeliveryFailed
+	//
+	//  UPDATE webhook_deliveries
+	//  SET status = 'failed', attempts = attempts + 1, response_status = ?, error = ?, updated_at = datetime('now')
+	//  WHERE id = ?
+	MarkDeliveryFailed(ctx context.Context, arg MarkDeliveryFailedParams) error
+	//MarkDeliveryRetry
+	//
+	//  UPDATE webhook_deliveries
+	//  SET attempts = attempts + 1, response_status = ?1, error = ?2,
+	//      next_attempt_at = datetime('now', CAST(?3 AS text)), updated_at = datetime('now')
+	//  WHERE id = ?4
+	MarkDeliveryRetry(ctx context.Context, arg MarkDeliveryRetryParams) error
+	//MarkDeliverySucceeded
+	//
+	//  UPDATE webhook_deliveries
+	//  SET status = 'succeeded', attempts = attempts + 1, response_status = ?, error = NULL, updated_at = datetime('now')
+	//  WHERE id = ?
+	MarkDeliverySucceeded(ctx context.Context, arg MarkDeliverySucceededParams) error
+}
+
+var _ Querier = (*Queries)(nil)
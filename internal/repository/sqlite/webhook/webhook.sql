@@ -0,0 +1,74 @@
+-- name: Create :one
+INSERT INTO webhooks (user_id, url, secret, events)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetByID :one
+SELECT *
+FROM webhooks
+WHERE id = ?
+  AND user_id = ?
+  AND deleted_at IS NULL;
+
+-- name: ListByUser :many
+SELECT *
+FROM webhooks
+WHERE user_id = ?
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: Delete :execrows
+UPDATE webhooks
+SET deleted_at = datetime('now')
+WHERE id = ?
+  AND user_id = ?
+  AND deleted_at IS NULL;
+
+-- name: CreateDelivery :one
+INSERT INTO webhook_deliveries (webhook_id, event, payload)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: GetDelivery :one
+SELECT *
+FROM webhook_deliveries
+WHERE id = ?
+  AND webhook_id = ?;
+
+-- name: ListDeliveries :many
+SELECT sqlc.embed(webhook_deliveries), count(*) over()
+FROM webhook_deliveries
+WHERE webhook_id = sqlc.arg(webhook_id)
+ORDER BY id DESC
+LIMIT CASE WHEN CAST(sqlc.arg(limit) AS int) > 0 THEN sqlc.arg(limit) ELSE 10 END
+OFFSET sqlc.arg(offset);
+
+-- name: GetPendingDelivery :one
+SELECT sqlc.embed(webhook_deliveries), webhooks.url, webhooks.secret
+FROM webhook_deliveries
+JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+WHERE webhook_deliveries.id = ?
+  AND webhook_deliveries.status = 'pending'
+  AND webhooks.deleted_at IS NULL;
+
+-- name: MarkDeliverySucceeded :exec
+UPDATE webhook_deliveries
+SET status = 'succeeded', attempts = attempts + 1, response_status = ?, error = NULL, updated_at = datetime('now')
+WHERE id = ?;
+
+-- name: MarkDeliveryRetry :exec
+UPDATE webhook_deliveries
+SET attempts = attempts + 1, response_status = sqlc.narg(response_status), error = sqlc.narg(error),
+    next_attempt_at = datetime('now', CAST(sqlc.arg(delay) AS text)), updated_at = datetime('now')
+WHERE id = sqlc.arg(id);
+
+-- name: MarkDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET status = 'failed', attempts = attempts + 1, response_status = ?, error = ?, updated_at = datetime('now')
+WHERE id = ?;
+
+-- name: CancelDeliveries :exec
+UPDATE webhook_deliveries
+SET status = 'failed', error = 'webhook was deleted', updated_at = datetime('now')
+WHERE webhook_id = ?
+  AND status = 'pending';
@@ -2,6 +2,7 @@ package server
 
 import (
 	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -9,7 +10,8 @@ import (
 	"github.com/HardDie/blog_engine/internal/config"
 	"github.com/HardDie/blog_engine/internal/dto"
 	"github.com/HardDie/blog_engine/internal/entity"
-	"github.com/HardDie/blog_engine/internal/logger"
+	"github.com/HardDie/blog_engine/internal/problem"
+	"github.com/HardDie/blog_engine/internal/ratelimit"
 	serviceAuth "github.com/HardDie/blog_engine/internal/service/auth"
 	"github.com/HardDie/blog_engine/internal/utils"
 )
@@ -17,18 +19,22 @@ import (
 type Auth struct {
 	authService serviceAuth.IAuth
 	cfg         *config.Config
+	limiter     *ratelimit.Limiter
 }
 
-func NewAuth(cfg *config.Config, auth serviceAuth.IAuth) *Auth {
+func NewAuth(cfg *config.Config, auth serviceAuth.IAuth, limiter *ratelimit.Limiter) *Auth {
 	return &Auth{
 		cfg:         cfg,
 		authService: auth,
+		limiter:     limiter,
 	}
 }
-func (s *Auth) RegisterPublicRouter(router *mux.Router) {
+func (s *Auth) RegisterPublicRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
 	authRouter := router.PathPrefix("").Subrouter()
 	authRouter.HandleFunc("/register", s.Register).Methods(http.MethodPost)
 	authRouter.HandleFunc("/login", s.Login).Methods(http.MethodPost)
+	authRouter.HandleFunc("/setup", s.Setup).Methods(http.MethodPost)
+	authRouter.Use(middleware...)
 }
 func (s *Auth) RegisterPrivateRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
 	authRouter := router.PathPrefix("").Subrouter()
@@ -59,54 +65,94 @@ type AuthRegisterResponse struct {
 //
 //	Responses:
 //	  200: AuthRegisterResponse
+//	  default: ProblemResponse
 func (s *Auth) Register(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	req := &dto.RegisterDTO{}
 	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
 	if err != nil {
-		logger.Error.Printf("Auth.Register() ParseJsonFromHTTPRequest: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		slog.ErrorContext(r.Context(), "Auth.Register() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
 		return
 	}
 
 	err = GetValidator().Struct(req)
 	if err != nil {
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Validation",
-			Data:  err.Error(),
-		})
+		problem.Write(w, r, problem.Validation(err))
 		return
 	}
 
 	user, err := s.authService.Register(ctx, req)
 	if err != nil {
-		switch {
-		case errors.Is(err, serviceAuth.ErrorInviteNotFound):
-			utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-				Error: "Invite not found",
-			})
-			return
-		case errors.Is(err, serviceAuth.ErrorInviteExpired):
-			utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-				Error: "Invite expired",
-			})
+		if writeError(w, r, err) {
 			return
-		case errors.Is(err, serviceAuth.ErrorUserExist):
-			utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-				Error: "User already exist",
-			})
+		}
+		slog.ErrorContext(r.Context(), "Auth.Register() Register", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	session, err := s.authService.GenerateCookie(ctx, user.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Auth.Register() GenerateCookie", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	utils.SetSessionCookie(session, w)
+}
+
+// swagger:parameters AuthSetupRequest
+type AuthSetupRequest struct {
+	// In: body
+	Body struct {
+		dto.SetupDTO
+	}
+}
+
+// swagger:response AuthSetupResponse
+type AuthSetupResponse struct {
+}
+
+// swagger:route POST /api/v1/auth/setup Auth AuthSetupRequest
+//
+// # Create the first account of a fresh instance with the one-time setup token
+//
+//	Responses:
+//	  200: AuthSetupResponse
+//	  default: ProblemResponse
+func (s *Auth) Setup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := &dto.SetupDTO{}
+	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Auth.Setup() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	user, err := s.authService.Setup(ctx, req)
+	if err != nil {
+		if writeError(w, r, err) {
 			return
 		}
-		logger.Error.Printf("Auth.Register() Register: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Auth.Setup() Setup", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
 	session, err := s.authService.GenerateCookie(ctx, user.ID)
 	if err != nil {
-		logger.Error.Printf("Auth.Register() GenerateCookie: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Auth.Setup() GenerateCookie", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
@@ -131,54 +177,48 @@ type AuthLoginResponse struct {
 //
 //	Responses:
 //	  200: AuthLoginResponse
+//	  default: ProblemResponse
 func (s *Auth) Login(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	req := &dto.LoginDTO{}
 	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
 	if err != nil {
-		logger.Error.Printf("Auth.Login() ParseJsonFromHTTPRequest: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		slog.ErrorContext(r.Context(), "Auth.Login() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
 		return
 	}
 
 	err = GetValidator().Struct(req)
 	if err != nil {
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Validation",
-			Data:  err.Error(),
-		})
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	// Failures are counted per username and IP, so a stranger exhausting the bucket doesn't lock out the owner
+	ip := ratelimit.ClientIP(r)
+	failureKey := req.Username + ":ip:" + ip
+	if !s.limiter.Allow(w, r, ratelimit.GroupLoginFailures, failureKey) {
 		return
 	}
 
-	user, err := s.authService.Login(ctx, req)
+	user, err := s.authService.Login(ctx, req, ip)
 	if err != nil {
-		switch {
-		case errors.Is(err, serviceAuth.ErrorUserNotFound):
-			utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-				Error: "User not found",
-			})
-			return
-		case errors.Is(err, serviceAuth.ErrorUserBlocked):
-			utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-				Error: "User blocked",
-			})
-			return
-		case errors.Is(err, serviceAuth.ErrorInvalidPassword):
-			utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-				Error: "Invalid password",
-			})
+		if errors.Is(err, serviceAuth.ErrorInvalidPassword) || errors.Is(err, serviceAuth.ErrorUserNotFound) {
+			s.limiter.Fail(ctx, ratelimit.GroupLoginFailures, failureKey)
+		}
+		if writeError(w, r, err) {
 			return
 		}
-		logger.Error.Printf("Auth.Login() Login: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Auth.Login() Login", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
 	session, err := s.authService.GenerateCookie(ctx, user.ID)
 	if err != nil {
-		logger.Error.Printf("Auth.Login() GenerateCookie: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Auth.Login() GenerateCookie", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
@@ -207,21 +247,18 @@ type AuthUserResponse struct {
 //
 //	Responses:
 //	  200: AuthUserResponse
+//	  default: ProblemResponse
 func (s *Auth) User(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := utils.GetUserIDFromContext(ctx)
 
 	user, err := s.authService.GetUserInfo(ctx, userID)
 	if err != nil {
-		switch {
-		case errors.Is(err, serviceAuth.ErrorUserNotFound):
-			utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-				Error: "User not found",
-			})
+		if writeError(w, r, err) {
 			return
 		}
-		logger.Error.Printf("Auth.User() GetUserInfo: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Auth.User() GetUserInfo", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
@@ -229,7 +266,7 @@ func (s *Auth) User(w http.ResponseWriter, r *http.Request) {
 		Data: user,
 	})
 	if err != nil {
-		logger.Error.Printf("Auth.User() WriteJSONHTTPResponse: %s", err.Error())
+		slog.ErrorContext(r.Context(), "Auth.User() WriteJSONHTTPResponse", "error", err)
 	}
 }
 
@@ -247,14 +284,15 @@ type AuthLogoutResponse struct {
 //
 //	Responses:
 //	  200: AuthLogoutResponse
+//	  default: ProblemResponse
 func (s *Auth) Logout(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	session := utils.GetSessionFromContext(ctx)
 
 	err := s.authService.Logout(ctx, session.SessionHash)
 	if err != nil {
-		logger.Error.Printf("Auth.Logout() Logout: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Auth.Logout() Logout", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 	utils.DeleteSessionCookie(w)
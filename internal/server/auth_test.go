@@ -0,0 +1,247 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+	"github.com/HardDie/blog_engine/internal/entity"
+)
+
+func TestAuth(t *testing.T) {
+	h := apptest.New(t)
+	f := h.Fixtures
+	anonymous := h.Client(t)
+	alice := h.Login(t, "alice")
+	newcomer := h.Client(t)
+
+	register := func(username, invite string) map[string]string {
+		return map[string]string{
+			"username":      username,
+			"password":      apptest.Password,
+			"displayedName": "Dave",
+			"invite":        invite,
+		}
+	}
+	login := func(username, password string) map[string]string {
+		return map[string]string{
+			"username": username,
+			"password": password,
+		}
+	}
+	userIs := func(username string) func(t *testing.T, res *apptest.Response) {
+		return func(t *testing.T, res *apptest.Response) {
+			user := &entity.User{}
+			res.Data(t, user)
+			if user.Username != username {
+				t.Fatalf("expected %q, got %q", username, user.Username)
+			}
+		}
+	}
+	apptest.Run(t, []apptest.Case{
+		{Name: "register invalid json", Client: newcomer, Method: http.MethodPost, Path: "/api/v1/auth/register",
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "register without invite", Client: newcomer, Method: http.MethodPost, Path: "/api/v1/auth/register",
+			Body: register("dave", ""), Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "register unknown invite", Client: newcomer, Method: http.MethodPost, Path: "/api/v1/auth/register",
+			Body: register("dave", "00000000-0000-4000-8000-000000000000"), Status: http.StatusBadRequest, Code: "invite_not_found"},
+		{Name: "register taken username", Client: newcomer, Method: http.MethodPost, Path: "/api/v1/auth/register",
+			Body: register("bob", f.Invite), Status: http.StatusBadRequest, Code: "user_exists"},
+		{Name: "register", Client: newcomer, Method: http.MethodPost, Path: "/api/v1/auth/register",
+			Body: register("dave", f.Invite), Status: http.StatusOK},
+		{Name: "registered user", Client: newcomer, Method: http.MethodGet, Path: "/api/v1/auth/user",
+			Status: http.StatusOK, Check: userIs("dave")},
+		{Name: "register used invite", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/register",
+			Body: register("erin", f.Invite), Status: http.StatusBadRequest, Code: "invite_not_found"},
+
+		{Name: "login invalid json", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: "[]", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "login without password", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("alice", ""), Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "login unknown user", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("nobody", apptest.Password), Status: http.StatusBadRequest, Code: "user_not_found"},
+		{Name: "login wrong password", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("alice", "wrong"), Status: http.StatusBadRequest, Code: "invalid_password"},
+		{Name: "login", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("carol", apptest.Password), Status: http.StatusOK},
+		{Name: "logged in user", Client: anonymous, Method: http.MethodGet, Path: "/api/v1/auth/user",
+			Status: http.StatusOK, Check: userIs("carol")},
+
+		{Name: "user", Client: alice, Method: http.MethodGet, Path: "/api/v1/auth/user",
+			Status: http.StatusOK, Check: userIs("alice")},
+		{Name: "logout", Client: alice, Method: http.MethodPost, Path: "/api/v1/auth/logout", Status: http.StatusOK},
+		{Name: "user after logout", Client: alice, Method: http.MethodGet, Path: "/api/v1/auth/user",
+			Status: http.StatusUnauthorized, Code: "session_missing"},
+		{Name: "logout without session", Client: alice, Method: http.MethodPost, Path: "/api/v1/auth/logout",
+			Status: http.StatusUnauthorized, Code: "session_missing"},
+
+		{Name: "setup after users are created", Client: h.Client(t), Method: http.MethodPost, Path: "/api/v1/auth/setup",
+			Body:   map[string]string{"token": setupToken, "username": "root", "password": apptest.Password, "displayedName": "Root"},
+			Status: http.StatusForbidden, Code: "invalid_setup_token"},
+	})
+}
+
+const setupToken = "setup-token-of-the-tests"
+
+func TestAuthSetup(t *testing.T) {
+	h := apptest.NewEmpty(t, "-setup-token="+setupToken)
+	owner := h.Client(t)
+
+	setup := func(token string) map[string]string {
+		return map[string]string{
+			"token":         token,
+			"username":      "owner",
+			"password":      apptest.Password,
+			"displayedName": "Owner",
+		}
+	}
+	apptest.Run(t, []apptest.Case{
+		{Name: "invalid json", Client: owner, Method: http.MethodPost, Path: "/api/v1/auth/setup",
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "without token", Client: owner, Method: http.MethodPost, Path: "/api/v1/auth/setup",
+			Body: setup(""), Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "wrong token", Client: owner, Method: http.MethodPost, Path: "/api/v1/auth/setup",
+			Body: setup("wrong-token"), Status: http.StatusForbidden, Code: "invalid_setup_token"},
+		{Name: "setup", Client: owner, Method: http.MethodPost, Path: "/api/v1/auth/setup",
+			Body: setup(setupToken), Status: http.StatusOK},
+		{Name: "owner", Client: owner, Method: http.MethodGet, Path: "/api/v1/auth/user", Status: http.StatusOK,
+			Check: func(t *testing.T, res *apptest.Response) {
+				user := &entity.User{}
+				res.Data(t, user)
+				if user.Username != "owner" {
+					t.Fatalf("unexpected user %+v", user)
+				}
+			}},
+		{Name: "repeated setup", Client: h.Client(t), Method: http.MethodPost, Path: "/api/v1/auth/setup",
+			Body: setup(setupToken), Status: http.StatusNotFound, Code: "setup_disabled"},
+	})
+}
+
+func TestAuthRateLimit(t *testing.T) {
+	login := func(username, password string) map[string]string {
+		return map[string]string{
+			"username": username,
+			"password": password,
+		}
+	}
+	// Every request comes from the loopback, the clients claim different addresses
+	forwardedFor := func(h *apptest.Harness, ip string) *apptest.Client {
+		c := h.Client(t)
+		c.Header().Set("X-Forwarded-For", ip)
+		return c
+	}
+
+	t.Run("untrusted forwarded for", func(t *testing.T) {
+		h := apptest.New(t, "-rate-limit-auth=2/1h")
+		apptest.Run(t, []apptest.Case{
+			{Name: "first", Client: forwardedFor(h, "192.0.2.1"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", apptest.Password), Status: http.StatusOK},
+			{Name: "second", Client: forwardedFor(h, "192.0.2.2"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", apptest.Password), Status: http.StatusOK},
+			{Name: "header is ignored", Client: forwardedFor(h, "192.0.2.3"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", apptest.Password), Status: http.StatusTooManyRequests, Code: "rate_limited"},
+		})
+	})
+
+	t.Run("trusted proxy", func(t *testing.T) {
+		h := apptest.New(t, "-rate-limit-auth=1/1h", "-trusted-proxies=127.0.0.0/8,::1/128")
+		apptest.Run(t, []apptest.Case{
+			{Name: "first client", Client: forwardedFor(h, "192.0.2.1"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", apptest.Password), Status: http.StatusOK},
+			{Name: "first client again", Client: forwardedFor(h, "192.0.2.1"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", apptest.Password), Status: http.StatusTooManyRequests, Code: "rate_limited"},
+			// The address added by the client is to the left of the one added by the proxy
+			{Name: "spoofed by the client", Client: forwardedFor(h, "198.51.100.1, 192.0.2.1"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", apptest.Password), Status: http.StatusTooManyRequests, Code: "rate_limited"},
+			{Name: "second client", Client: forwardedFor(h, "192.0.2.2"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", apptest.Password), Status: http.StatusOK},
+		})
+	})
+
+	t.Run("login failures", func(t *testing.T) {
+		h := apptest.New(t, "-rate-limit-login-failures=2/1h", "-trusted-proxies=127.0.0.0/8,::1/128")
+		apptest.Run(t, []apptest.Case{
+			{Name: "success is not counted", Client: forwardedFor(h, "192.0.2.1"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", apptest.Password), Status: http.StatusOK},
+			{Name: "first failure", Client: forwardedFor(h, "192.0.2.1"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", "wrong"), Status: http.StatusBadRequest, Code: "invalid_password"},
+			{Name: "second failure from another address", Client: forwardedFor(h, "192.0.2.2"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", "wrong"), Status: http.StatusBadRequest, Code: "invalid_password"},
+			{Name: "second failure", Client: forwardedFor(h, "192.0.2.1"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", "wrong"), Status: http.StatusBadRequest, Code: "invalid_password"},
+			{Name: "limited", Client: forwardedFor(h, "192.0.2.1"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", apptest.Password), Status: http.StatusTooManyRequests, Code: "rate_limited",
+				Check: func(t *testing.T, res *apptest.Response) {
+					if res.Header.Get("Retry-After") == "" {
+						t.Fatalf("no Retry-After")
+					}
+				}},
+			{Name: "not limited from another address", Client: forwardedFor(h, "192.0.2.2"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("alice", apptest.Password), Status: http.StatusOK},
+			{Name: "another username", Client: forwardedFor(h, "192.0.2.3"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+				Body: login("bob", apptest.Password), Status: http.StatusOK},
+		})
+	})
+}
+
+func TestAuthLoginRejected(t *testing.T) {
+	h := apptest.New(t, "-pwd-max-attempts=2")
+	anonymous := h.Client(t)
+
+	err := h.App.Services.Auth.Ban(context.Background(), h.Fixtures.Carol.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	login := func(username, password string) map[string]string {
+		return map[string]string{
+			"username": username,
+			"password": password,
+		}
+	}
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "banned", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("carol", apptest.Password), Status: http.StatusForbidden, Code: "user_banned"},
+		{Name: "first failure", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("bob", "wrong password"), Status: http.StatusBadRequest, Code: "invalid_password"},
+		{Name: "second failure", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("bob", "wrong password"), Status: http.StatusBadRequest, Code: "invalid_password"},
+		{Name: "blocked", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("bob", apptest.Password), Status: http.StatusBadRequest, Code: "user_blocked"},
+	})
+}
+
+func TestAuthLoginBlockedPerAddress(t *testing.T) {
+	h := apptest.New(t, "-pwd-max-attempts=2", "-trusted-proxies=127.0.0.0/8,::1/128")
+	forwardedFor := func(ip string) *apptest.Client {
+		c := h.Client(t)
+		c.Header().Set("X-Forwarded-For", ip)
+		return c
+	}
+	login := func(username, password string) map[string]string {
+		return map[string]string{
+			"username": username,
+			"password": password,
+		}
+	}
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "first failure", Client: forwardedFor("192.0.2.1"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("bob", "wrong password"), Status: http.StatusBadRequest, Code: "invalid_password"},
+		{Name: "second failure", Client: forwardedFor("192.0.2.1"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("bob", "wrong password"), Status: http.StatusBadRequest, Code: "invalid_password"},
+		{Name: "blocked", Client: forwardedFor("192.0.2.1"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("bob", apptest.Password), Status: http.StatusBadRequest, Code: "user_blocked"},
+		{Name: "another address", Client: forwardedFor("192.0.2.2"), Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body: login("bob", apptest.Password), Status: http.StatusOK},
+	})
+
+	count, err := h.App.Services.Auth.PurgeLoginFailures(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("purged %d fresh counters", count)
+	}
+}
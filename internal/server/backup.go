@@ -0,0 +1,111 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/HardDie/blog_engine/internal/problem"
+	serviceBackup "github.com/HardDie/blog_engine/internal/service/backup"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+type Backup struct {
+	backupService serviceBackup.IBackup
+}
+
+func NewBackup(backup serviceBackup.IBackup) *Backup {
+	return &Backup{
+		backupService: backup,
+	}
+}
+func (s *Backup) RegisterAdminRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	backupRouter := router.PathPrefix("").Subrouter()
+	backupRouter.HandleFunc("", s.List).Methods(http.MethodGet)
+	backupRouter.HandleFunc("", s.Create).Methods(http.MethodPost)
+	backupRouter.Use(middleware...)
+}
+
+/*
+ * Admin
+ */
+
+// swagger:parameters BackupCreateRequest
+type BackupCreateRequest struct {
+	// In: header
+	// Required: true
+	Authorization string `json:"Authorization"`
+}
+
+// swagger:response BackupCreateResponse
+type BackupCreateResponse struct {
+	// In: body
+	Body struct {
+		Data *serviceBackup.Manifest `json:"data"`
+	}
+}
+
+// swagger:route POST /api/v1/admin/backups Backup BackupCreateRequest
+//
+// # Create a backup of both databases, authorized by the ADMIN_TOKEN bearer token
+//
+//	Responses:
+//	  200: BackupCreateResponse
+//	  default: ProblemResponse
+func (s *Backup) Create(w http.ResponseWriter, r *http.Request) {
+	manifest, err := s.backupService.Create(r.Context())
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Backup.Create() Create", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: manifest,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Backup.Create() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters BackupListRequest
+type BackupListRequest struct {
+	// In: header
+	// Required: true
+	Authorization string `json:"Authorization"`
+}
+
+// swagger:response BackupListResponse
+type BackupListResponse struct {
+	// In: body
+	Body struct {
+		Data []*serviceBackup.Manifest `json:"data"`
+	}
+}
+
+// swagger:route GET /api/v1/admin/backups Backup BackupListRequest
+//
+// # Get backups, the newest first
+//
+//	Responses:
+//	  200: BackupListResponse
+//	  default: ProblemResponse
+func (s *Backup) List(w http.ResponseWriter, r *http.Request) {
+	list, err := s.backupService.List(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Backup.List() List", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: list,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Backup.List() WriteJSONHTTPResponse", "error", err)
+	}
+}
@@ -0,0 +1,86 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+	"github.com/HardDie/blog_engine/internal/db"
+	serviceBackup "github.com/HardDie/blog_engine/internal/service/backup"
+)
+
+func TestBackup(t *testing.T) {
+	h := apptest.New(t)
+	admin := h.Admin(t)
+	alice := h.Login(t, "alice")
+
+	var created serviceBackup.Manifest
+	apptest.Run(t, []apptest.Case{
+		{Name: "list without token", Client: alice, Method: http.MethodGet, Path: "/api/v1/admin/backups",
+			Status: http.StatusUnauthorized, Code: "admin_token_invalid"},
+		{Name: "create without token", Client: alice, Method: http.MethodPost, Path: "/api/v1/admin/backups",
+			Status: http.StatusUnauthorized, Code: "admin_token_invalid"},
+		{Name: "list empty", Client: admin, Method: http.MethodGet, Path: "/api/v1/admin/backups",
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				var got []*serviceBackup.Manifest
+				res.Data(t, &got)
+				if len(got) != 0 {
+					t.Fatalf("unexpected backups %+v", got)
+				}
+			}},
+		{Name: "create", Client: admin, Method: http.MethodPost, Path: "/api/v1/admin/backups",
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				res.Data(t, &created)
+				if created.Name == "" || created.Migration == 0 || len(created.Files) != 2 {
+					t.Fatalf("unexpected backup %+v", created)
+				}
+			}},
+		{Name: "list", Client: admin, Method: http.MethodGet, Path: "/api/v1/admin/backups",
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				var got []*serviceBackup.Manifest
+				res.Data(t, &got)
+				if len(got) != 1 || got[0].Name != created.Name {
+					t.Fatalf("unexpected backups %+v", got)
+				}
+			}},
+	})
+}
+
+func TestBackupRestore(t *testing.T) {
+	h := apptest.New(t)
+	manifest, err := h.App.Services.Backup.Create(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(h.App.Cfg.BackupDir, manifest.Name)
+
+	cfg := *h.App.Cfg
+	cfg.DBPath = filepath.Join(t.TempDir(), "blog.db")
+	cfg.SessionsDBPath = filepath.Join(t.TempDir(), "sessions.db")
+
+	// The running server holds the lock of DB_PATH
+	lock, err := db.Lock(cfg.DBPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = serviceBackup.Restore(context.Background(), &cfg, dir)
+	if !errors.Is(err, serviceBackup.ErrorServerRunning) {
+		t.Fatalf("expected %v, got %v", serviceBackup.ErrorServerRunning, err)
+	}
+	err = lock.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = serviceBackup.Restore(context.Background(), &cfg, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = serviceBackup.Restore(context.Background(), &cfg, dir)
+	if !errors.Is(err, serviceBackup.ErrorPreRestoreExists) {
+		t.Fatalf("expected %v, got %v", serviceBackup.ErrorPreRestoreExists, err)
+	}
+}
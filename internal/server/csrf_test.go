@@ -0,0 +1,143 @@
+package server_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+	"github.com/HardDie/blog_engine/internal/middleware"
+)
+
+// csrfToken returns the double-submit token, which the server issued to the client in the cookie.
+func csrfToken(t *testing.T, c *apptest.Client) string {
+	t.Helper()
+
+	u, err := url.Parse(c.URL("/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cookie := range c.HTTP.Jar.Cookies(u) {
+		if cookie.Name == middleware.CSRFCookieName {
+			return cookie.Value
+		}
+	}
+	t.Fatalf("no %s cookie", middleware.CSRFCookieName)
+	return ""
+}
+
+func TestCSRF(t *testing.T) {
+	const listed = "https://blog.example"
+	h := apptest.New(t, "-cors-allowed-origins=*,"+listed)
+
+	// Browsers send the fetch metadata and the origin of the page
+	browser := func(site, origin string) *apptest.Client {
+		c := h.Login(t, "alice")
+		c.Header().Set("Sec-Fetch-Site", site)
+		c.Header().Set("Origin", origin)
+		return c
+	}
+	crossSite := browser("cross-site", "https://evil.example")
+	listedSite := browser("cross-site", listed)
+	sameOrigin := browser("same-origin", h.Server.URL)
+	doubleSubmit := browser("cross-site", "https://evil.example")
+	doubleSubmit.Header().Set(middleware.CSRFHeaderName, csrfToken(t, doubleSubmit))
+	wrongToken := browser("same-origin", h.Server.URL)
+	wrongToken.Header().Set(middleware.CSRFHeaderName, "wrong")
+	anonymous := h.Client(t)
+	anonymous.Header().Set("Sec-Fetch-Site", "cross-site")
+	anonymous.Header().Set("Origin", "https://evil.example")
+
+	post := map[string]any{
+		"title":       "Title",
+		"short":       "Short",
+		"body":        "Body",
+		"isPublished": true,
+	}
+	apptest.Run(t, []apptest.Case{
+		{Name: "cross-site with session", Client: crossSite, Method: http.MethodPost, Path: "/api/v1/posts",
+			Body: post, Status: http.StatusForbidden, Code: "csrf_failed"},
+		{Name: "cross-site read", Client: crossSite, Method: http.MethodGet, Path: "/api/v1/auth/user",
+			Status: http.StatusOK},
+		{Name: "listed origin", Client: listedSite, Method: http.MethodPost, Path: "/api/v1/posts",
+			Body: post, Status: http.StatusCreated},
+		{Name: "same origin", Client: sameOrigin, Method: http.MethodPost, Path: "/api/v1/posts",
+			Body: post, Status: http.StatusCreated},
+		{Name: "double-submit", Client: doubleSubmit, Method: http.MethodPost, Path: "/api/v1/posts",
+			Body: post, Status: http.StatusCreated},
+		{Name: "wrong token", Client: wrongToken, Method: http.MethodPost, Path: "/api/v1/posts",
+			Body: post, Status: http.StatusForbidden, Code: "csrf_failed"},
+		{Name: "without session", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/posts",
+			Body: post, Status: http.StatusUnauthorized, Code: "session_missing"},
+	})
+}
+
+func TestCORS(t *testing.T) {
+	const listed = "https://blog.example"
+	h := apptest.New(t, "-cors-allowed-origins=*,"+listed)
+
+	preflight := func(origin string) *apptest.Client {
+		c := h.Client(t)
+		c.Header().Set("Origin", origin)
+		c.Header().Set("Access-Control-Request-Method", http.MethodPost)
+		return c
+	}
+	expectOrigin := func(origin string, credentials bool) func(t *testing.T, res *apptest.Response) {
+		return func(t *testing.T, res *apptest.Response) {
+			if got := res.Header.Get("Access-Control-Allow-Origin"); got != origin {
+				t.Fatalf("Access-Control-Allow-Origin = %q, expected %q", got, origin)
+			}
+			if got := res.Header.Get("Access-Control-Allow-Credentials") == "true"; got != credentials {
+				t.Fatalf("Access-Control-Allow-Credentials = %t, expected %t", got, credentials)
+			}
+		}
+	}
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "preflight of listed origin", Client: preflight(listed), Method: http.MethodOptions, Path: "/api/v1/posts",
+			Status: http.StatusNoContent, Check: func(t *testing.T, res *apptest.Response) {
+				expectOrigin(listed, true)(t, res)
+				if res.Header.Get("Access-Control-Allow-Methods") == "" {
+					t.Fatalf("no allowed methods in the preflight")
+				}
+			}},
+		{Name: "preflight of other origin", Client: preflight("https://evil.example"), Method: http.MethodOptions, Path: "/api/v1/posts",
+			Status: http.StatusNoContent, Check: expectOrigin("*", false)},
+		{Name: "read of other origin", Client: preflight("https://evil.example"), Method: http.MethodGet, Path: "/api/v1/posts/feed",
+			Status: http.StatusOK, Check: expectOrigin("*", false)},
+	})
+}
+
+func TestCORSNoOrigins(t *testing.T) {
+	for name, flags := range map[string][]string{
+		"default": nil,
+		// The empty flag overrides the origin of the environment
+		"cleared": {"-cors-allowed-origins="},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if flags != nil {
+				t.Setenv("CORS_ALLOWED_ORIGINS", "https://blog.example")
+			}
+			h := apptest.New(t, flags...)
+			c := h.Login(t, "alice")
+			c.Header().Set("Sec-Fetch-Site", "cross-site")
+			c.Header().Set("Origin", "https://blog.example")
+
+			noCredentials := func(t *testing.T, res *apptest.Response) {
+				if got := res.Header.Get("Access-Control-Allow-Origin"); got != "" {
+					t.Fatalf("Access-Control-Allow-Origin = %q, expected none", got)
+				}
+				if got := res.Header.Get("Access-Control-Allow-Credentials"); got != "" {
+					t.Fatalf("Access-Control-Allow-Credentials = %q, expected none", got)
+				}
+			}
+			apptest.Run(t, []apptest.Case{
+				{Name: "read", Client: c, Method: http.MethodGet, Path: "/api/v1/auth/user",
+					Status: http.StatusOK, Check: noCredentials},
+				{Name: "write", Client: c, Method: http.MethodPost, Path: "/api/v1/posts",
+					Body:   map[string]any{"title": "Title", "short": "Short", "body": "Body"},
+					Status: http.StatusForbidden, Code: "csrf_failed", Check: noCredentials},
+			})
+		})
+	}
+}
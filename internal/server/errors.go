@@ -0,0 +1,81 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HardDie/blog_engine/internal/problem"
+	serviceAuth "github.com/HardDie/blog_engine/internal/service/auth"
+	serviceBackup "github.com/HardDie/blog_engine/internal/service/backup"
+	serviceInvite "github.com/HardDie/blog_engine/internal/service/invite"
+	servicePost "github.com/HardDie/blog_engine/internal/service/post"
+	serviceReaction "github.com/HardDie/blog_engine/internal/service/reaction"
+	serviceReadingList "github.com/HardDie/blog_engine/internal/service/readinglist"
+	serviceSeries "github.com/HardDie/blog_engine/internal/service/series"
+	serviceUser "github.com/HardDie/blog_engine/internal/service/user"
+	serviceWebhook "github.com/HardDie/blog_engine/internal/service/webhook"
+)
+
+// Error response in the application/problem+json format
+// swagger:response ProblemResponse
+type ProblemResponse struct {
+	// In: body
+	Body problem.Problem
+}
+
+type serviceError struct {
+	err    error
+	status int
+	code   string
+	detail string
+}
+
+// serviceErrors maps sentinel errors of services to responses. Codes are a part of the API and must not be changed.
+var serviceErrors = []serviceError{
+	{serviceAuth.ErrorInviteNotFound, http.StatusBadRequest, "invite_not_found", "Invite not found"},
+	{serviceAuth.ErrorInviteExpired, http.StatusBadRequest, "invite_expired", "Invite expired"},
+	{serviceAuth.ErrorUserExist, http.StatusBadRequest, "user_exists", "User already exist"},
+	{serviceAuth.ErrorUserNotFound, http.StatusBadRequest, "user_not_found", "User not found"},
+	{serviceAuth.ErrorUserBlocked, http.StatusBadRequest, "user_blocked", "User blocked"},
+	{serviceAuth.ErrorUserBanned, http.StatusForbidden, "user_banned", "User banned"},
+	{serviceAuth.ErrorInvalidPassword, http.StatusBadRequest, "invalid_password", "Invalid password"},
+	{serviceAuth.ErrorSetupDisabled, http.StatusNotFound, "setup_disabled", "Setup is already completed"},
+	{serviceAuth.ErrorInvalidSetupToken, http.StatusForbidden, "invalid_setup_token", "Invalid setup token"},
+
+	{serviceBackup.ErrorNotSupported, http.StatusNotImplemented, "backup_not_supported", "Backups are made only for SQLite"},
+
+	{serviceInvite.ErrorInviteNotFound, http.StatusBadRequest, "invite_not_found", "Invite not found"},
+
+	{servicePost.ErrorPostNotFound, http.StatusNotFound, "post_not_found", "Post not found"},
+
+	{serviceReaction.ErrorPostNotFound, http.StatusNotFound, "post_not_found", "Post not found"},
+	{serviceReaction.ErrorUnknownReaction, http.StatusBadRequest, "unknown_reaction", "Unknown reaction"},
+
+	{serviceReadingList.ErrorReadingListNotFound, http.StatusNotFound, "reading_list_not_found", "Reading list not found"},
+	{serviceReadingList.ErrorItemNotFound, http.StatusBadRequest, "reading_list_item_not_found", "Post is not in the reading list"},
+	{serviceReadingList.ErrorPostNotFound, http.StatusBadRequest, "post_not_found", "Post not found"},
+
+	{serviceSeries.ErrorSeriesNotFound, http.StatusNotFound, "series_not_found", "Series not found"},
+	{serviceSeries.ErrorPostNotFound, http.StatusBadRequest, "post_not_found", "Post not found"},
+	{serviceSeries.ErrorPostInAnotherSeries, http.StatusBadRequest, "post_in_another_series", "Post already belongs to another series"},
+
+	{serviceUser.ErrorUserNotFound, http.StatusBadRequest, "user_not_found", "User not found"},
+	{serviceUser.ErrorInvalidPassword, http.StatusBadRequest, "invalid_old_password", "Invalid old password"},
+	{serviceUser.ErrorFollowYourself, http.StatusBadRequest, "follow_yourself", "You can't follow yourself"},
+	{serviceUser.ErrorNotFollowing, http.StatusBadRequest, "not_following", "You are not following this user"},
+
+	{serviceWebhook.ErrorWebhookNotFound, http.StatusNotFound, "webhook_not_found", "Webhook not found"},
+	{serviceWebhook.ErrorDeliveryNotFound, http.StatusNotFound, "delivery_not_found", "Delivery not found"},
+}
+
+// writeError writes the problem for known errors of services.
+// For unknown errors it returns false, the caller logs the error and writes problem.Internal.
+func writeError(w http.ResponseWriter, r *http.Request, err error) bool {
+	for _, el := range serviceErrors {
+		if errors.Is(err, el.err) {
+			problem.Write(w, r, problem.New(el.status, el.code, el.detail))
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/HardDie/blog_engine/internal/broker"
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/problem"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+type Event struct {
+	cfg    *config.Config
+	broker broker.IBroker
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func NewEvent(cfg *config.Config, broker broker.IBroker) *Event {
+	return &Event{
+		cfg:    cfg,
+		broker: broker,
+		closed: make(chan struct{}),
+	}
+}
+
+// RegisterPublicRouter the stream is long-lived, so the timeout middleware must not be passed here.
+func (s *Event) RegisterPublicRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	eventRouter := router.PathPrefix("").Subrouter()
+	eventRouter.HandleFunc("", s.Stream).Methods(http.MethodGet)
+	eventRouter.Use(middleware...)
+}
+
+// Close ends all open streams, otherwise the server shutdown would wait for them until the deadline.
+// Clients will reconnect with Last-Event-ID to another instance.
+func (s *Event) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+}
+
+/*
+ * Public
+ */
+
+// swagger:parameters EventStreamRequest
+type EventStreamRequest struct {
+	// ID of the last received event to resume the stream
+	// In: header
+	LastEventID string `json:"Last-Event-ID"`
+}
+
+// Stream of events in the text/event-stream format
+// swagger:response EventStreamResponse
+type EventStreamResponse struct {
+	// In: body
+	Body string
+}
+
+// swagger:route GET /api/v1/events Event EventStreamRequest
+//
+// # Stream of live updates: published and edited posts for everyone, notifications for the logged-in user
+//
+//	Produces:
+//	- text/event-stream
+//
+//	Responses:
+//	  200: EventStreamResponse
+//	  default: ProblemResponse
+func (s *Event) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetOptionalUserIDFromContext(ctx)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		slog.ErrorContext(ctx, "Event.Stream() streaming is not supported by the response writer")
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	// Browsers send the header on reconnect, other clients may use the query
+	lastEventIDStr := r.Header.Get("Last-Event-ID")
+	if lastEventIDStr == "" {
+		lastEventIDStr = r.URL.Query().Get("lastEventId")
+	}
+	var lastEventID uint64
+	if lastEventIDStr != "" {
+		var err error
+		lastEventID, err = strconv.ParseUint(lastEventIDStr, 10, 64)
+		if err != nil {
+			problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad Last-Event-ID"))
+			return
+		}
+	}
+
+	// The stream is long-lived, so the write timeout of the server doesn't apply to it
+	err := http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	if err != nil {
+		slog.ErrorContext(ctx, "Event.Stream() can't reset write deadline", "error", err)
+	}
+
+	sub, missed := s.broker.Subscribe(userID, lastEventID)
+	defer s.broker.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range missed {
+		if !s.writeEvent(w, event) {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(time.Duration(s.cfg.SSEHeartbeat) * time.Second)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.closed:
+			return
+		case <-heartbeat.C:
+			_, err := fmt.Fprint(w, ": heartbeat\n\n")
+			if err != nil {
+				return
+			}
+		case event, ok := <-sub.Events():
+			if !ok {
+				// The client was too slow, it will reconnect with Last-Event-ID
+				return
+			}
+			if !s.writeEvent(w, event) {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func (s *Event) writeEvent(w http.ResponseWriter, event *broker.Event) bool {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+	return err == nil
+}
@@ -0,0 +1,44 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+)
+
+func TestEvent(t *testing.T) {
+	h := apptest.New(t)
+
+	for _, tc := range []struct {
+		name   string
+		client *apptest.Client
+	}{
+		{"anonymous", h.Client(t)},
+		{"user", h.Login(t, "alice")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// The stream never ends, the response is checked by the headers
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, tc.client.URL("/api/v1/events"), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := tc.client.HTTP.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", resp.StatusCode)
+			}
+			if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+				t.Fatalf("unexpected content type %q", resp.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/HardDie/blog_engine/internal/boltdb"
+	"github.com/HardDie/blog_engine/internal/db"
+	"github.com/HardDie/blog_engine/internal/metrics"
+	"github.com/HardDie/blog_engine/internal/migration"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+const (
+	healthCheckTimeout = 2 * time.Second
+
+	healthStatusOK   = "ok"
+	healthStatusFail = "fail"
+)
+
+type Health struct {
+	db      *db.DB
+	boltDB  *boltdb.DB
+	migrate *migration.Migrate
+}
+
+func NewHealth(db *db.DB, boltDB *boltdb.DB, migrate *migration.Migrate) *Health {
+	return &Health{
+		db:      db,
+		boltDB:  boltDB,
+		migrate: migrate,
+	}
+}
+
+// RegisterPublicRouter the endpoints are served from the root, outside the API prefix.
+func (s *Health) RegisterPublicRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	healthRouter := router.PathPrefix("").Subrouter()
+	healthRouter.HandleFunc("/healthz", s.Healthz).Methods(http.MethodGet)
+	healthRouter.HandleFunc("/readyz", s.Readyz).Methods(http.MethodGet)
+	healthRouter.Use(middleware...)
+}
+
+// RegisterMetricsRouter the metrics are served from the root, the middleware decides who can scrape them.
+func (s *Health) RegisterMetricsRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	metricsRouter := router.PathPrefix("").Subrouter()
+	metricsRouter.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+	metricsRouter.Use(middleware...)
+}
+
+/*
+ * Public
+ */
+
+// swagger:parameters HealthzRequest
+type HealthzRequest struct {
+}
+
+type HealthStatus struct {
+	// ok or fail
+	Status string `json:"status"`
+	// Result of each check: ok or the error
+	Checks map[string]string `json:"checks"`
+	// Version of the DB compared to the embedded migrations
+	Migrations *migration.Status `json:"migrations,omitempty"`
+}
+
+// Result of the checks, the status code is 503 if any check has failed
+// swagger:response HealthzResponse
+type HealthzResponse struct {
+	// In: body
+	Body HealthStatus
+}
+
+// swagger:route GET /healthz Health HealthzRequest
+//
+// # Liveness probe: the DB and BoltDB of sessions, if used, are usable, migrations are only reported
+//
+//	Responses:
+//	  200: HealthzResponse
+//	  503: HealthzResponse
+func (s *Health) Healthz(w http.ResponseWriter, r *http.Request) {
+	s.writeStatus(w, s.check(r.Context(), false))
+}
+
+// swagger:parameters ReadyzRequest
+type ReadyzRequest struct {
+}
+
+// swagger:route GET /readyz Health ReadyzRequest
+//
+// # Readiness probe: the same checks as healthz, and there are no pending migrations
+//
+//	Responses:
+//	  200: HealthzResponse
+//	  503: HealthzResponse
+func (s *Health) Readyz(w http.ResponseWriter, r *http.Request) {
+	s.writeStatus(w, s.check(r.Context(), true))
+}
+
+func (s *Health) check(ctx context.Context, requireMigrations bool) *HealthStatus {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	status := &HealthStatus{
+		Status: healthStatusOK,
+		Checks: make(map[string]string),
+	}
+	result := func(name string, err error) {
+		if err != nil {
+			slog.ErrorContext(ctx, "Health.check() failed", "check", name, "error", err)
+			status.Status = healthStatusFail
+			status.Checks[name] = err.Error()
+			return
+		}
+		status.Checks[name] = healthStatusOK
+	}
+
+	result(s.db.Driver, s.db.Check(ctx))
+	if s.boltDB != nil {
+		result("boltdb", s.boltDB.CheckWritable())
+	}
+
+	migrations, err := s.migrate.Status()
+	switch {
+	case err != nil:
+		result("migrations", err)
+	case migrations.Pending > 0 && requireMigrations:
+		status.Status = healthStatusFail
+		status.Checks["migrations"] = "pending"
+	default:
+		status.Checks["migrations"] = healthStatusOK
+	}
+	status.Migrations = migrations
+	return status
+}
+func (s *Health) writeStatus(w http.ResponseWriter, status *HealthStatus) {
+	code := http.StatusOK
+	if status.Status != healthStatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	utils.WriteJSONHTTPResponse(w, code, status)
+}
@@ -0,0 +1,51 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+	"github.com/HardDie/blog_engine/internal/server"
+)
+
+func TestHealth(t *testing.T) {
+	h := apptest.New(t)
+	anonymous := h.Client(t)
+	admin := h.Admin(t)
+
+	ok := func(t *testing.T, res *apptest.Response) {
+		status := &server.HealthStatus{}
+		err := json.Unmarshal(res.Body, status)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Checks["sqlite"] != "ok" || status.Checks["boltdb"] != "ok" || status.Checks["migrations"] != "ok" {
+			t.Fatalf("unexpected checks %v", status.Checks)
+		}
+	}
+	apptest.Run(t, []apptest.Case{
+		{Name: "healthz", Client: anonymous, Method: http.MethodGet, Path: "/healthz", Status: http.StatusOK, Check: ok},
+		{Name: "readyz", Client: anonymous, Method: http.MethodGet, Path: "/readyz", Status: http.StatusOK, Check: ok},
+		{Name: "metrics without token", Client: anonymous, Method: http.MethodGet, Path: "/metrics",
+			Status: http.StatusUnauthorized, Code: "admin_token_invalid"},
+		{Name: "metrics", Client: admin, Method: http.MethodGet, Path: "/metrics", Status: http.StatusOK, Check: isMetrics},
+		{Name: "unknown route", Client: anonymous, Method: http.MethodGet, Path: "/api/v1/unknown", Status: http.StatusNotFound, Code: "not_found"},
+	})
+}
+
+func TestMetricsPublic(t *testing.T) {
+	h := apptest.New(t, "-metrics-public=true")
+	anonymous := h.Client(t)
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "metrics", Client: anonymous, Method: http.MethodGet, Path: "/metrics", Status: http.StatusOK, Check: isMetrics},
+	})
+}
+
+func isMetrics(t *testing.T, res *apptest.Response) {
+	if !strings.Contains(string(res.Body), "# TYPE") {
+		t.Fatalf("not the metrics: %.200s", res.Body)
+	}
+}
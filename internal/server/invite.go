@@ -1,12 +1,12 @@
 package server
 
 import (
-	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
-	"github.com/HardDie/blog_engine/internal/logger"
+	"github.com/HardDie/blog_engine/internal/problem"
 	serviceInvite "github.com/HardDie/blog_engine/internal/service/invite"
 	"github.com/HardDie/blog_engine/internal/utils"
 )
@@ -49,14 +49,15 @@ type InviteGenerateResponse struct {
 //
 //	Responses:
 //	  200: InviteGenerateResponse
+//	  default: ProblemResponse
 func (s *Invite) Generate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := utils.GetUserIDFromContext(ctx)
 
 	inviteCode, err := s.inviteService.Generate(ctx, userID)
 	if err != nil {
-		logger.Error.Printf("Invite.Invite() Generate: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Invite.Invite() Generate", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
@@ -64,7 +65,7 @@ func (s *Invite) Generate(w http.ResponseWriter, r *http.Request) {
 		Data: inviteCode,
 	})
 	if err != nil {
-		logger.Error.Printf("Invite.Invite() WriteJSONHTTPResponse: %s", err.Error())
+		slog.ErrorContext(r.Context(), "Invite.Invite() WriteJSONHTTPResponse", "error", err)
 	}
 }
 
@@ -82,21 +83,18 @@ type InviteRevokeResponse struct {
 //
 //	Responses:
 //	  200: InviteRevokeResponse
+//	  default: ProblemResponse
 func (s *Invite) Revoke(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := utils.GetUserIDFromContext(ctx)
 
 	err := s.inviteService.Revoke(ctx, userID)
 	if err != nil {
-		switch {
-		case errors.Is(err, serviceInvite.ErrorInviteNotFound):
-			utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-				Error: "Invite not found",
-			})
+		if writeError(w, r, err) {
 			return
 		}
-		logger.Error.Printf("Invite.Revoke() Revoke: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Invite.Revoke() Revoke", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 }
@@ -0,0 +1,40 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+)
+
+func TestInvite(t *testing.T) {
+	h := apptest.New(t)
+	anonymous := h.Client(t)
+	alice := h.Login(t, "alice")
+	bob := h.Login(t, "bob")
+
+	var invite string
+	apptest.Run(t, []apptest.Case{
+		{Name: "generate anonymous", Client: anonymous, Method: http.MethodGet, Path: "/api/v1/invites/generate",
+			Status: http.StatusUnauthorized, Code: "session_missing"},
+		{Name: "revoke without invite", Client: bob, Method: http.MethodDelete, Path: "/api/v1/invites/revoke",
+			Status: http.StatusBadRequest, Code: "invite_not_found"},
+		{Name: "generate", Client: alice, Method: http.MethodGet, Path: "/api/v1/invites/generate",
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				res.Data(t, &invite)
+				if invite == "" || invite == h.Fixtures.Invite {
+					t.Fatalf("unexpected invite %q", invite)
+				}
+			}},
+		{Name: "revoke", Client: alice, Method: http.MethodDelete, Path: "/api/v1/invites/revoke",
+			Status: http.StatusOK},
+		{Name: "revoke repeated", Client: alice, Method: http.MethodDelete, Path: "/api/v1/invites/revoke",
+			Status: http.StatusBadRequest, Code: "invite_not_found"},
+	})
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "register with revoked", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/register",
+			Body:   map[string]any{"username": "dave", "password": apptest.Password, "displayedName": "Dave", "invite": invite},
+			Status: http.StatusBadRequest, Code: "invite_not_found"},
+	})
+}
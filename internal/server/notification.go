@@ -0,0 +1,297 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+	"github.com/HardDie/blog_engine/internal/problem"
+	serviceNotification "github.com/HardDie/blog_engine/internal/service/notification"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+type Notification struct {
+	notificationService serviceNotification.INotification
+}
+
+func NewNotification(notification serviceNotification.INotification) *Notification {
+	return &Notification{
+		notificationService: notification,
+	}
+}
+func (s *Notification) RegisterPrivateRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	notificationRouter := router.PathPrefix("").Subrouter()
+	notificationRouter.HandleFunc("", s.List).Methods(http.MethodGet)
+	notificationRouter.HandleFunc("/unread", s.CountUnread).Methods(http.MethodGet)
+	notificationRouter.HandleFunc("/read", s.MarkRead).Methods(http.MethodPut)
+	notificationRouter.HandleFunc("/read/all", s.MarkAllRead).Methods(http.MethodPut)
+	notificationRouter.HandleFunc("/preferences", s.Preferences).Methods(http.MethodGet)
+	notificationRouter.HandleFunc("/preferences", s.UpdatePreferences).Methods(http.MethodPut)
+	notificationRouter.Use(middleware...)
+}
+
+/*
+ * Private
+ */
+
+// swagger:parameters NotificationListRequest
+type NotificationListRequest struct {
+	// In: query
+	dto.ListNotificationDTO
+}
+
+// swagger:response NotificationListResponse
+type NotificationListResponse struct {
+	// In: body
+	Body struct {
+		Data []*entity.Notification `json:"data"`
+	}
+}
+
+// swagger:route GET /api/v1/notifications Notification NotificationListRequest
+//
+// # Get notifications of the current user
+//
+//	Responses:
+//	  200: NotificationListResponse
+//	  default: ProblemResponse
+func (s *Notification) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.ListNotificationDTO{
+		Limit:  utils.GetInt32FromQuery(r, "limit", 0),
+		Page:   utils.GetInt32FromQuery(r, "page", 0),
+		Unread: utils.GetBoolFromQuery(r, "unread", false),
+	}
+
+	err := GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	notifications, total, err := s.notificationService.List(ctx, req, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.List() List", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	meta := &utils.Meta{
+		Total: int32(total),
+		Limit: req.Limit,
+		Page:  req.Page,
+	}
+	err = utils.ResponseWithMeta(w, notifications, meta)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.List() ResponseWithMeta", "error", err)
+	}
+}
+
+// swagger:parameters NotificationCountUnreadRequest
+type NotificationCountUnreadRequest struct {
+}
+
+// swagger:response NotificationCountUnreadResponse
+type NotificationCountUnreadResponse struct {
+	// In: body
+	Body struct {
+		Data int64 `json:"data"`
+	}
+}
+
+// swagger:route GET /api/v1/notifications/unread Notification NotificationCountUnreadRequest
+//
+// # Get the number of unread notifications
+//
+//	Responses:
+//	  200: NotificationCountUnreadResponse
+//	  default: ProblemResponse
+func (s *Notification) CountUnread(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	count, err := s.notificationService.CountUnread(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.CountUnread() CountUnread", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: count,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.CountUnread() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters NotificationMarkReadRequest
+type NotificationMarkReadRequest struct {
+	// In: body
+	Body struct {
+		dto.MarkReadNotificationDTO
+	}
+}
+
+// swagger:response NotificationMarkReadResponse
+type NotificationMarkReadResponse struct {
+}
+
+// swagger:route PUT /api/v1/notifications/read Notification NotificationMarkReadRequest
+//
+// # Mark the passed notifications as read
+//
+//	Responses:
+//	  200: NotificationMarkReadResponse
+//	  default: ProblemResponse
+func (s *Notification) MarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.MarkReadNotificationDTO{}
+	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.MarkRead() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	err = s.notificationService.MarkRead(ctx, req, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.MarkRead() MarkRead", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+}
+
+// swagger:parameters NotificationMarkAllReadRequest
+type NotificationMarkAllReadRequest struct {
+}
+
+// swagger:response NotificationMarkAllReadResponse
+type NotificationMarkAllReadResponse struct {
+}
+
+// swagger:route PUT /api/v1/notifications/read/all Notification NotificationMarkAllReadRequest
+//
+// # Mark all notifications as read
+//
+//	Responses:
+//	  200: NotificationMarkAllReadResponse
+//	  default: ProblemResponse
+func (s *Notification) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	err := s.notificationService.MarkAllRead(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.MarkAllRead() MarkAllRead", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+}
+
+// swagger:parameters NotificationPreferencesRequest
+type NotificationPreferencesRequest struct {
+}
+
+// swagger:response NotificationPreferencesResponse
+type NotificationPreferencesResponse struct {
+	// In: body
+	Body struct {
+		Data []*entity.NotificationPreference `json:"data"`
+	}
+}
+
+// swagger:route GET /api/v1/notifications/preferences Notification NotificationPreferencesRequest
+//
+// # Get notification channels for each type of events
+//
+//	Responses:
+//	  200: NotificationPreferencesResponse
+//	  default: ProblemResponse
+func (s *Notification) Preferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	prefs, err := s.notificationService.Preferences(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.Preferences() Preferences", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: prefs,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.Preferences() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters NotificationUpdatePreferencesRequest
+type NotificationUpdatePreferencesRequest struct {
+	// In: body
+	Body struct {
+		dto.UpdateNotificationPreferencesDTO
+	}
+}
+
+// swagger:response NotificationUpdatePreferencesResponse
+type NotificationUpdatePreferencesResponse struct {
+	// In: body
+	Body struct {
+		Data []*entity.NotificationPreference `json:"data"`
+	}
+}
+
+// swagger:route PUT /api/v1/notifications/preferences Notification NotificationUpdatePreferencesRequest
+//
+// # Update notification channels for the passed types of events
+//
+//	Responses:
+//	  200: NotificationUpdatePreferencesResponse
+//	  default: ProblemResponse
+func (s *Notification) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.UpdateNotificationPreferencesDTO{}
+	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.UpdatePreferences() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	prefs, err := s.notificationService.UpdatePreferences(ctx, req, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.UpdatePreferences() UpdatePreferences", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: prefs,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Notification.UpdatePreferences() WriteJSONHTTPResponse", "error", err)
+	}
+}
@@ -0,0 +1,107 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+	"github.com/HardDie/blog_engine/internal/entity"
+)
+
+func TestNotification(t *testing.T) {
+	h := apptest.New(t)
+	f := h.Fixtures
+	anonymous := h.Client(t)
+	alice := h.Login(t, "alice")
+	bob := h.Login(t, "bob")
+	carol := h.Login(t, "carol")
+
+	expectUnread := func(expected int64) func(t *testing.T, res *apptest.Response) {
+		return func(t *testing.T, res *apptest.Response) {
+			var got int64
+			res.Data(t, &got)
+			if got != expected {
+				t.Fatalf("expected %d unread, got %d", expected, got)
+			}
+		}
+	}
+	var notifications []*entity.Notification
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "list anonymous", Client: anonymous, Method: http.MethodGet, Path: "/api/v1/notifications",
+			Status: http.StatusUnauthorized, Code: "session_missing"},
+		{Name: "follow", Client: bob, Method: http.MethodPost, Path: fmt.Sprintf("/api/v1/user/%d/follow", f.Alice.ID),
+			Status: http.StatusOK},
+		{Name: "react", Client: carol, Method: http.MethodPost, Path: fmt.Sprintf("/api/v1/posts/%d/reactions", f.AlicePost.ID),
+			Body: map[string]string{"reaction": "👍"}, Status: http.StatusOK},
+		{Name: "list", Client: alice, Method: http.MethodGet, Path: "/api/v1/notifications",
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				res.Data(t, &notifications)
+				if len(notifications) != 2 {
+					t.Fatalf("expected 2 notifications, got %d", len(notifications))
+				}
+				if notifications[0].Type != entity.NotificationTypeReaction || notifications[0].ActorID != f.Carol.ID {
+					t.Fatalf("unexpected notification %+v", notifications[0])
+				}
+				if notifications[1].Type != entity.NotificationTypeFollow || notifications[1].ActorID != f.Bob.ID {
+					t.Fatalf("unexpected notification %+v", notifications[1])
+				}
+			}},
+		{Name: "list of another user", Client: bob, Method: http.MethodGet, Path: "/api/v1/notifications",
+			Status: http.StatusOK, Check: expectIDs()},
+		{Name: "unread", Client: alice, Method: http.MethodGet, Path: "/api/v1/notifications/unread",
+			Status: http.StatusOK, Check: expectUnread(2)},
+	})
+	if len(notifications) != 2 {
+		t.FailNow()
+	}
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "mark read invalid json", Client: alice, Method: http.MethodPut, Path: "/api/v1/notifications/read",
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "mark read without ids", Client: alice, Method: http.MethodPut, Path: "/api/v1/notifications/read",
+			Body: map[string]any{"ids": []int64{}}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "mark read by another user", Client: bob, Method: http.MethodPut, Path: "/api/v1/notifications/read",
+			Body: map[string]any{"ids": []int64{notifications[0].ID}}, Status: http.StatusOK},
+		{Name: "unread after another user", Client: alice, Method: http.MethodGet, Path: "/api/v1/notifications/unread",
+			Status: http.StatusOK, Check: expectUnread(2)},
+		{Name: "mark read", Client: alice, Method: http.MethodPut, Path: "/api/v1/notifications/read",
+			Body: map[string]any{"ids": []int64{notifications[0].ID}}, Status: http.StatusOK},
+		{Name: "list unread", Client: alice, Method: http.MethodGet, Path: "/api/v1/notifications?unread=true",
+			Status: http.StatusOK, Check: expectIDs(notifications[1].ID)},
+		{Name: "mark all read", Client: alice, Method: http.MethodPut, Path: "/api/v1/notifications/read/all",
+			Status: http.StatusOK},
+		{Name: "unread after all", Client: alice, Method: http.MethodGet, Path: "/api/v1/notifications/unread",
+			Status: http.StatusOK, Check: expectUnread(0)},
+
+		{Name: "preferences", Client: alice, Method: http.MethodGet, Path: "/api/v1/notifications/preferences",
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				var got []*entity.NotificationPreference
+				res.Data(t, &got)
+				for _, pref := range got {
+					if !pref.InApp {
+						t.Fatalf("in app notifications are disabled by default: %+v", pref)
+					}
+				}
+			}},
+		{Name: "update preferences unknown type", Client: alice, Method: http.MethodPut, Path: "/api/v1/notifications/preferences",
+			Body:   map[string]any{"preferences": []map[string]any{{"type": "unknown", "inApp": false}}},
+			Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "update preferences", Client: alice, Method: http.MethodPut, Path: "/api/v1/notifications/preferences",
+			Body:   map[string]any{"preferences": []map[string]any{{"type": entity.NotificationTypeFollow, "inApp": false}}},
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				var got []*entity.NotificationPreference
+				res.Data(t, &got)
+				for _, pref := range got {
+					if pref.Type == entity.NotificationTypeFollow && pref.InApp {
+						t.Fatalf("the preference is not updated: %+v", pref)
+					}
+				}
+			}},
+		{Name: "follow with disabled notifications", Client: carol, Method: http.MethodPost, Path: fmt.Sprintf("/api/v1/user/%d/follow", f.Alice.ID),
+			Status: http.StatusOK},
+		{Name: "unread after disabled", Client: alice, Method: http.MethodGet, Path: "/api/v1/notifications/unread",
+			Status: http.StatusOK, Check: expectUnread(0)},
+	})
+}
@@ -1,14 +1,15 @@
 package server
 
 import (
-	"errors"
+	"html/template"
+	"log/slog"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
 	"github.com/HardDie/blog_engine/internal/dto"
 	"github.com/HardDie/blog_engine/internal/entity"
-	"github.com/HardDie/blog_engine/internal/logger"
+	"github.com/HardDie/blog_engine/internal/problem"
 	servicePost "github.com/HardDie/blog_engine/internal/service/post"
 	"github.com/HardDie/blog_engine/internal/utils"
 )
@@ -26,13 +27,16 @@ func (s *Post) RegisterPublicRouter(router *mux.Router, middleware ...mux.Middle
 	postRouter := router.PathPrefix("").Subrouter()
 	postRouter.HandleFunc("/feed", s.Feed).Methods(http.MethodGet)
 	postRouter.HandleFunc("/{id:[0-9]+}", s.PublicGet).Methods(http.MethodGet)
+	postRouter.HandleFunc("/{id:[0-9]+}/meta", s.PublicMeta).Methods(http.MethodGet)
 	postRouter.Use(middleware...)
 }
 func (s *Post) RegisterPrivateRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
 	postRouter := router.PathPrefix("").Subrouter()
 	postRouter.HandleFunc("", s.Create).Methods(http.MethodPost)
 	postRouter.HandleFunc("/{id:[0-9]+}", s.Edit).Methods(http.MethodPut)
+	postRouter.HandleFunc("/{id:[0-9]+}", s.Delete).Methods(http.MethodDelete)
 	postRouter.HandleFunc("", s.List).Methods(http.MethodGet)
+	postRouter.HandleFunc("/feed/following", s.FeedFollowing).Methods(http.MethodGet)
 	postRouter.Use(middleware...)
 }
 
@@ -60,8 +64,10 @@ type PostFeedResponse struct {
 //
 //	Responses:
 //	  200: PostFeedResponse
+//	  default: ProblemResponse
 func (s *Post) Feed(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	userID := utils.GetOptionalUserIDFromContext(ctx)
 
 	req := &dto.FeedPostDTO{
 		Limit: utils.GetInt32FromQuery(r, "limit", 0),
@@ -71,17 +77,14 @@ func (s *Post) Feed(w http.ResponseWriter, r *http.Request) {
 
 	err := GetValidator().Struct(req)
 	if err != nil {
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Validation",
-			Data:  err.Error(),
-		})
+		problem.Write(w, r, problem.Validation(err))
 		return
 	}
 
-	posts, total, err := s.postService.Feed(ctx, req)
+	posts, total, err := s.postService.Feed(ctx, req, userID)
 	if err != nil {
-		logger.Error.Printf("Post.Feed() Feed: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Post.Feed() Feed", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
@@ -92,7 +95,7 @@ func (s *Post) Feed(w http.ResponseWriter, r *http.Request) {
 	}
 	err = utils.ResponseWithMeta(w, posts, meta)
 	if err != nil {
-		logger.Error.Printf("Post.Feed() ResponseWithMeta: %s", err.Error())
+		slog.ErrorContext(r.Context(), "Post.Feed() ResponseWithMeta", "error", err)
 	}
 }
 
@@ -116,14 +119,14 @@ type PostPublicGetResponse struct {
 //
 //	Responses:
 //	  200: PostPublicGetResponse
+//	  default: ProblemResponse
 func (s *Post) PublicGet(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	userID := utils.GetOptionalUserIDFromContext(ctx)
 	postID, err := utils.GetInt64FromPath(r, "id")
 	if err != nil {
-		logger.Error.Printf("Post.PublicGet() GetInt32FromPath: %s", err.Error())
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Bad id in path",
-		})
+		slog.ErrorContext(r.Context(), "Post.PublicGet() GetInt32FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
 		return
 	}
 	req := &dto.PublicGetDTO{
@@ -132,24 +135,17 @@ func (s *Post) PublicGet(w http.ResponseWriter, r *http.Request) {
 
 	err = GetValidator().Struct(req)
 	if err != nil {
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Validation",
-			Data:  err.Error(),
-		})
+		problem.Write(w, r, problem.Validation(err))
 		return
 	}
 
-	post, err := s.postService.PublicGet(ctx, postID)
+	post, err := s.postService.PublicGet(ctx, postID, userID)
 	if err != nil {
-		switch {
-		case errors.Is(err, servicePost.ErrorPostNotFound):
-			utils.WriteJSONHTTPResponse(w, http.StatusNotFound, JSONResponse{
-				Error: "Post not found",
-			})
+		if writeError(w, r, err) {
 			return
 		}
-		logger.Error.Printf("Post.PublicGet() PublicGet: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Post.PublicGet() PublicGet", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
@@ -157,7 +153,72 @@ func (s *Post) PublicGet(w http.ResponseWriter, r *http.Request) {
 		Data: post,
 	})
 	if err != nil {
-		logger.Error.Printf("Post.PublicGet() WriteJSONHTTPResponse: %s", err.Error())
+		slog.ErrorContext(r.Context(), "Post.PublicGet() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters PostPublicMetaRequest
+type PostPublicMetaRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+}
+
+// HTML fragment with <meta> and <link> tags for the <head> of the post page
+// swagger:response PostPublicMetaResponse
+type PostPublicMetaResponse struct {
+	// In: body
+	Body string
+}
+
+var metaTagsTemplate = template.Must(template.New("meta").Parse(
+	`{{range .}}{{if .Rel}}<link rel="{{.Rel}}" href="{{.Content}}">` +
+		`{{else if .Property}}<meta property="{{.Property}}" content="{{.Content}}">` +
+		`{{else}}<meta name="{{.Name}}" content="{{.Content}}">{{end}}
+{{end}}`,
+))
+
+// swagger:route GET /api/v1/posts/{id}/meta Post PostPublicMetaRequest
+//
+// # Get ready-made meta tags of a public post for SSR
+//
+//	Produces:
+//	- text/html
+//
+//	Responses:
+//	  200: PostPublicMetaResponse
+//	  default: ProblemResponse
+func (s *Post) PublicMeta(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	postID, err := utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Post.PublicMeta() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+	req := &dto.PublicGetDTO{
+		ID: postID,
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	tags, err := s.postService.PublicMeta(ctx, postID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Post.PublicMeta() PublicMeta", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = metaTagsTemplate.Execute(w, tags)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Post.PublicMeta() Execute", "error", err)
 	}
 }
 
@@ -165,6 +226,60 @@ func (s *Post) PublicGet(w http.ResponseWriter, r *http.Request) {
  * Private
  */
 
+// swagger:parameters PostFeedFollowingRequest
+type PostFeedFollowingRequest struct {
+	// In: query
+	dto.FeedFollowingPostDTO
+}
+
+// swagger:response PostFeedFollowingResponse
+type PostFeedFollowingResponse struct {
+	// In: body
+	Body struct {
+		Data []*entity.Post `json:"data"`
+	}
+}
+
+// swagger:route GET /api/v1/posts/feed/following Post PostFeedFollowingRequest
+//
+// # Get feed of the followed authors
+//
+//	Responses:
+//	  200: PostFeedFollowingResponse
+//	  default: ProblemResponse
+func (s *Post) FeedFollowing(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.FeedFollowingPostDTO{
+		Limit: utils.GetInt32FromQuery(r, "limit", 0),
+		Page:  utils.GetInt32FromQuery(r, "page", 0),
+	}
+
+	err := GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	posts, total, err := s.postService.FeedFollowing(ctx, req, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Post.FeedFollowing() FeedFollowing", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	meta := &utils.Meta{
+		Total: int32(total),
+		Limit: req.Limit,
+		Page:  req.Page,
+	}
+	err = utils.ResponseWithMeta(w, posts, meta)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Post.FeedFollowing() ResponseWithMeta", "error", err)
+	}
+}
+
 // swagger:parameters PostCreateRequest
 type PostCreateRequest struct {
 	// In: body
@@ -187,6 +302,7 @@ type PostCreateResponse struct {
 //
 //	Responses:
 //	  201: PostCreateResponse
+//	  default: ProblemResponse
 func (s *Post) Create(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := utils.GetUserIDFromContext(ctx)
@@ -194,24 +310,21 @@ func (s *Post) Create(w http.ResponseWriter, r *http.Request) {
 	req := &dto.CreatePostDTO{}
 	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
 	if err != nil {
-		logger.Error.Printf("Post.Create() ParseJsonFromHTTPRequest: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		slog.ErrorContext(r.Context(), "Post.Create() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
 		return
 	}
 
 	err = GetValidator().Struct(req)
 	if err != nil {
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Validation",
-			Data:  err.Error(),
-		})
+		problem.Write(w, r, problem.Validation(err))
 		return
 	}
 
 	post, err := s.postService.Create(ctx, req, userID)
 	if err != nil {
-		logger.Error.Printf("Post.Create() Create: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Post.Create() Create", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
@@ -219,7 +332,7 @@ func (s *Post) Create(w http.ResponseWriter, r *http.Request) {
 		Data: post,
 	})
 	if err != nil {
-		logger.Error.Printf("Post.Create() WriteJSONHTTPResponse: %s", err.Error())
+		slog.ErrorContext(r.Context(), "Post.Create() WriteJSONHTTPResponse", "error", err)
 	}
 }
 
@@ -247,6 +360,7 @@ type PostEditResponse struct {
 //
 //	Responses:
 //	  200: PostEditResponse
+//	  default: ProblemResponse
 func (s *Post) Edit(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := utils.GetUserIDFromContext(ctx)
@@ -254,33 +368,31 @@ func (s *Post) Edit(w http.ResponseWriter, r *http.Request) {
 	req := &dto.EditPostDTO{}
 	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
 	if err != nil {
-		logger.Error.Printf("Post.Edit() ParseJsonFromHTTPRequest: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		slog.ErrorContext(r.Context(), "Post.Edit() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
 		return
 	}
 
 	req.ID, err = utils.GetInt64FromPath(r, "id")
 	if err != nil {
-		logger.Error.Printf("Post.Edit() GetInt32FromPath: %s", err.Error())
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Bad id in path",
-		})
+		slog.ErrorContext(r.Context(), "Post.Edit() GetInt32FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
 		return
 	}
 
 	err = GetValidator().Struct(req)
 	if err != nil {
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Validation",
-			Data:  err.Error(),
-		})
+		problem.Write(w, r, problem.Validation(err))
 		return
 	}
 
 	post, err := s.postService.Edit(ctx, req, userID)
 	if err != nil {
-		logger.Error.Printf("Post.Edit() Edit: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Post.Edit() Edit", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
@@ -288,7 +400,55 @@ func (s *Post) Edit(w http.ResponseWriter, r *http.Request) {
 		Data: post,
 	})
 	if err != nil {
-		logger.Error.Printf("Post.Edit() WriteJSONHTTPResponse: %s", err.Error())
+		slog.ErrorContext(r.Context(), "Post.Edit() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters PostDeleteRequest
+type PostDeleteRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+}
+
+// swagger:response PostDeleteResponse
+type PostDeleteResponse struct {
+}
+
+// swagger:route DELETE /api/v1/posts/{id} Post PostDeleteRequest
+//
+// # Delete post
+//
+//	Responses:
+//	  200: PostDeleteResponse
+//	  default: ProblemResponse
+func (s *Post) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	postID, err := utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Post.Delete() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+	req := &dto.PublicGetDTO{
+		ID: postID,
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	err = s.postService.Delete(ctx, req.ID, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Post.Delete() Delete", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
 	}
 }
 
@@ -312,6 +472,7 @@ type PostListResponse struct {
 //
 //	Responses:
 //	  200: PostListResponse
+//	  default: ProblemResponse
 func (s *Post) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := utils.GetUserIDFromContext(ctx)
@@ -324,17 +485,14 @@ func (s *Post) List(w http.ResponseWriter, r *http.Request) {
 
 	err := GetValidator().Struct(req)
 	if err != nil {
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Validation",
-			Data:  err.Error(),
-		})
+		problem.Write(w, r, problem.Validation(err))
 		return
 	}
 
 	posts, total, err := s.postService.List(ctx, req, userID)
 	if err != nil {
-		logger.Error.Printf("Post.List() List: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "Post.List() List", "error", err)
+		problem.Write(w, r, problem.Internal())
 	}
 
 	meta := &utils.Meta{
@@ -344,6 +502,6 @@ func (s *Post) List(w http.ResponseWriter, r *http.Request) {
 	}
 	err = utils.ResponseWithMeta(w, posts, meta)
 	if err != nil {
-		logger.Error.Printf("Post.List() ResponseWithMeta: %s", err.Error())
+		slog.ErrorContext(r.Context(), "Post.List() ResponseWithMeta", "error", err)
 	}
 }
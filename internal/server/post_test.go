@@ -0,0 +1,124 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+	"github.com/HardDie/blog_engine/internal/entity"
+)
+
+// expectIDs checks the IDs of the list in the data field, in order.
+func expectIDs(expected ...int64) func(t *testing.T, res *apptest.Response) {
+	return func(t *testing.T, res *apptest.Response) {
+		var list []struct {
+			ID int64 `json:"id"`
+		}
+		res.Data(t, &list)
+		got := make([]int64, 0, len(list))
+		for _, el := range list {
+			got = append(got, el.ID)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(expected) {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestPost(t *testing.T) {
+	h := apptest.New(t)
+	f := h.Fixtures
+	anonymous := h.Client(t)
+	alice := h.Login(t, "alice")
+	bob := h.Login(t, "bob")
+
+	post := func(title string, isPublished bool) map[string]any {
+		return map[string]any{
+			"title":       title,
+			"short":       "Short of " + title,
+			"body":        "Body of " + title,
+			"tags":        []string{"go"},
+			"isPublished": isPublished,
+		}
+	}
+	var created entity.Post
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "feed of published posts", Client: anonymous, Method: http.MethodGet, Path: "/api/v1/posts/feed",
+			Status: http.StatusOK, Check: expectIDs(f.BobPost.ID, f.AlicePost.ID)},
+		{Name: "feed search", Client: anonymous, Method: http.MethodGet, Path: "/api/v1/posts/feed?query=%25sql%25",
+			Status: http.StatusOK, Check: expectIDs(f.BobPost.ID)},
+		{Name: "feed bad page", Client: anonymous, Method: http.MethodGet, Path: "/api/v1/posts/feed?page=-1",
+			Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "get published", Client: anonymous, Method: http.MethodGet, Path: fmt.Sprintf("/api/v1/posts/%d", f.AlicePost.ID),
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				var got entity.Post
+				res.Data(t, &got)
+				if got.Title != f.AlicePost.Title || got.UserID != f.Alice.ID {
+					t.Fatalf("unexpected post %+v", got)
+				}
+			}},
+		{Name: "get draft", Client: anonymous, Method: http.MethodGet, Path: fmt.Sprintf("/api/v1/posts/%d", f.AliceDraft.ID),
+			Status: http.StatusNotFound, Code: "post_not_found"},
+		{Name: "get not a number", Client: anonymous, Method: http.MethodGet, Path: "/api/v1/posts/abc",
+			Status: http.StatusNotFound, Code: "not_found"},
+		{Name: "meta", Client: anonymous, Method: http.MethodGet, Path: fmt.Sprintf("/api/v1/posts/%d/meta", f.AlicePost.ID),
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				if !strings.HasPrefix(res.Header.Get("Content-Type"), "text/html") {
+					t.Fatalf("unexpected content type %q", res.Header.Get("Content-Type"))
+				}
+				if !strings.Contains(string(res.Body), f.AlicePost.Title) {
+					t.Fatalf("no title in the meta: %s", res.Body)
+				}
+			}},
+		{Name: "meta of draft", Client: anonymous, Method: http.MethodGet, Path: fmt.Sprintf("/api/v1/posts/%d/meta", f.AliceDraft.ID),
+			Status: http.StatusNotFound, Code: "post_not_found"},
+
+		{Name: "create anonymous", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/posts",
+			Body: post("Anonymous", true), Status: http.StatusUnauthorized, Code: "session_missing"},
+		{Name: "create invalid json", Client: alice, Method: http.MethodPost, Path: "/api/v1/posts",
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "create without title", Client: alice, Method: http.MethodPost, Path: "/api/v1/posts",
+			Body: post("", true), Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "create", Client: alice, Method: http.MethodPost, Path: "/api/v1/posts",
+			Body: post("Alice writes again", true), Status: http.StatusCreated, Check: func(t *testing.T, res *apptest.Response) {
+				res.Data(t, &created)
+				if created.ID == 0 || created.UserID != f.Alice.ID || !created.IsPublished {
+					t.Fatalf("unexpected post %+v", created)
+				}
+			}},
+	})
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "edit", Client: alice, Method: http.MethodPut, Path: fmt.Sprintf("/api/v1/posts/%d", created.ID),
+			Body: post("Alice edits", true), Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				var got entity.Post
+				res.Data(t, &got)
+				if got.Title != "Alice edits" {
+					t.Fatalf("the post is not edited: %+v", got)
+				}
+			}},
+		{Name: "edit of another user", Client: bob, Method: http.MethodPut, Path: fmt.Sprintf("/api/v1/posts/%d", created.ID),
+			Body: post("Bob edits", true), Status: http.StatusNotFound, Code: "post_not_found"},
+		{Name: "edit unknown", Client: alice, Method: http.MethodPut, Path: "/api/v1/posts/100000",
+			Body: post("Alice edits", true), Status: http.StatusNotFound, Code: "post_not_found"},
+		{Name: "edit invalid json", Client: alice, Method: http.MethodPut, Path: fmt.Sprintf("/api/v1/posts/%d", created.ID),
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "own list with drafts", Client: alice, Method: http.MethodGet, Path: "/api/v1/posts",
+			Status: http.StatusOK, Check: expectIDs(created.ID, f.AliceDraft.ID, f.AlicePost.ID)},
+		{Name: "following feed is empty", Client: bob, Method: http.MethodGet, Path: "/api/v1/posts/feed/following",
+			Status: http.StatusOK, Check: expectIDs()},
+		{Name: "follow", Client: bob, Method: http.MethodPost, Path: fmt.Sprintf("/api/v1/user/%d/follow", f.Alice.ID),
+			Status: http.StatusOK},
+		{Name: "following feed", Client: bob, Method: http.MethodGet, Path: "/api/v1/posts/feed/following",
+			Status: http.StatusOK, Check: expectIDs(created.ID, f.AlicePost.ID)},
+		{Name: "delete of another user", Client: bob, Method: http.MethodDelete, Path: fmt.Sprintf("/api/v1/posts/%d", created.ID),
+			Status: http.StatusNotFound, Code: "post_not_found"},
+		{Name: "delete", Client: alice, Method: http.MethodDelete, Path: fmt.Sprintf("/api/v1/posts/%d", created.ID),
+			Status: http.StatusOK},
+		{Name: "get deleted", Client: anonymous, Method: http.MethodGet, Path: fmt.Sprintf("/api/v1/posts/%d", created.ID),
+			Status: http.StatusNotFound, Code: "post_not_found"},
+	})
+}
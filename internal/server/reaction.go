@@ -0,0 +1,201 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+	"github.com/HardDie/blog_engine/internal/problem"
+	serviceReaction "github.com/HardDie/blog_engine/internal/service/reaction"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+type Reaction struct {
+	reactionService serviceReaction.IReaction
+}
+
+func NewReaction(reaction serviceReaction.IReaction) *Reaction {
+	return &Reaction{
+		reactionService: reaction,
+	}
+}
+func (s *Reaction) RegisterPublicRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	reactionRouter := router.PathPrefix("").Subrouter()
+	reactionRouter.HandleFunc("/reactions", s.Available).Methods(http.MethodGet)
+	reactionRouter.Use(middleware...)
+}
+func (s *Reaction) RegisterPrivateRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	reactionRouter := router.PathPrefix("").Subrouter()
+	reactionRouter.HandleFunc("/{id:[0-9]+}/reactions", s.Add).Methods(http.MethodPost)
+	reactionRouter.HandleFunc("/{id:[0-9]+}/reactions", s.Remove).Methods(http.MethodDelete)
+	reactionRouter.Use(middleware...)
+}
+
+/*
+ * Public
+ */
+
+// swagger:parameters ReactionAvailableRequest
+type ReactionAvailableRequest struct {
+}
+
+// swagger:response ReactionAvailableResponse
+type ReactionAvailableResponse struct {
+	// In: body
+	Body struct {
+		Data []string `json:"data"`
+	}
+}
+
+// swagger:route GET /api/v1/posts/reactions Reaction ReactionAvailableRequest
+//
+// # Get a list of available reactions
+//
+//	Responses:
+//	  200: ReactionAvailableResponse
+//	  default: ProblemResponse
+func (s *Reaction) Available(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: s.reactionService.Available(ctx),
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Reaction.Available() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+/*
+ * Private
+ */
+
+// swagger:parameters ReactionAddRequest
+type ReactionAddRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+	// In: body
+	Body struct {
+		dto.ReactPostDTO
+	}
+}
+
+// swagger:response ReactionAddResponse
+type ReactionAddResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.PostReactions `json:"data"`
+	}
+}
+
+// swagger:route POST /api/v1/posts/{id}/reactions Reaction ReactionAddRequest
+//
+// # Add a reaction to the post
+//
+//	Responses:
+//	  200: ReactionAddResponse
+//	  default: ProblemResponse
+func (s *Reaction) Add(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req, ok := s.parseRequest("Add", w, r)
+	if !ok {
+		return
+	}
+
+	reactions, err := s.reactionService.Add(ctx, req, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Reaction.Add() Add", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: reactions,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Reaction.Add() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters ReactionRemoveRequest
+type ReactionRemoveRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+	// In: body
+	Body struct {
+		dto.ReactPostDTO
+	}
+}
+
+// swagger:response ReactionRemoveResponse
+type ReactionRemoveResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.PostReactions `json:"data"`
+	}
+}
+
+// swagger:route DELETE /api/v1/posts/{id}/reactions Reaction ReactionRemoveRequest
+//
+// # Remove a reaction from the post
+//
+//	Responses:
+//	  200: ReactionRemoveResponse
+//	  default: ProblemResponse
+func (s *Reaction) Remove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req, ok := s.parseRequest("Remove", w, r)
+	if !ok {
+		return
+	}
+
+	reactions, err := s.reactionService.Remove(ctx, req, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Reaction.Remove() Remove", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: reactions,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Reaction.Remove() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+func (s *Reaction) parseRequest(method string, w http.ResponseWriter, r *http.Request) (*dto.ReactPostDTO, bool) {
+	req := &dto.ReactPostDTO{}
+	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Reaction.parseRequest() ParseJsonFromHTTPRequest", "method", method, "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
+		return nil, false
+	}
+
+	req.ID, err = utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Reaction.parseRequest() GetInt64FromPath", "method", method, "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return nil, false
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return nil, false
+	}
+	return req, true
+}
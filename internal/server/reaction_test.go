@@ -0,0 +1,58 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+	"github.com/HardDie/blog_engine/internal/entity"
+)
+
+func TestReaction(t *testing.T) {
+	h := apptest.New(t, "-reactions=👍,🔥")
+	f := h.Fixtures
+	anonymous := h.Client(t)
+	alice := h.Login(t, "alice")
+	bob := h.Login(t, "bob")
+
+	path := fmt.Sprintf("/api/v1/posts/%d/reactions", f.BobPost.ID)
+	expect := func(count int64, userReactions ...string) func(t *testing.T, res *apptest.Response) {
+		return func(t *testing.T, res *apptest.Response) {
+			var got entity.PostReactions
+			res.Data(t, &got)
+			if got.PostID != f.BobPost.ID || got.Reactions["👍"] != count || fmt.Sprint(got.UserReactions) != fmt.Sprint(userReactions) {
+				t.Fatalf("unexpected reactions %+v", got)
+			}
+		}
+	}
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "available", Client: anonymous, Method: http.MethodGet, Path: "/api/v1/posts/reactions",
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				var got []string
+				res.Data(t, &got)
+				if fmt.Sprint(got) != "[👍 🔥]" {
+					t.Fatalf("unexpected reactions %v", got)
+				}
+			}},
+		{Name: "add anonymous", Client: anonymous, Method: http.MethodPost, Path: path,
+			Body: map[string]string{"reaction": "👍"}, Status: http.StatusUnauthorized, Code: "session_missing"},
+		{Name: "add invalid json", Client: alice, Method: http.MethodPost, Path: path,
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "add empty", Client: alice, Method: http.MethodPost, Path: path,
+			Body: map[string]string{"reaction": ""}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "add unknown", Client: alice, Method: http.MethodPost, Path: path,
+			Body: map[string]string{"reaction": "😢"}, Status: http.StatusBadRequest, Code: "unknown_reaction"},
+		{Name: "add to draft", Client: bob, Method: http.MethodPost, Path: fmt.Sprintf("/api/v1/posts/%d/reactions", f.AliceDraft.ID),
+			Body: map[string]string{"reaction": "👍"}, Status: http.StatusNotFound, Code: "post_not_found"},
+		{Name: "add", Client: alice, Method: http.MethodPost, Path: path,
+			Body: map[string]string{"reaction": "👍"}, Status: http.StatusOK, Check: expect(1, "👍")},
+		{Name: "add repeated", Client: alice, Method: http.MethodPost, Path: path,
+			Body: map[string]string{"reaction": "👍"}, Status: http.StatusOK, Check: expect(1, "👍")},
+		{Name: "add by another user", Client: bob, Method: http.MethodPost, Path: path,
+			Body: map[string]string{"reaction": "👍"}, Status: http.StatusOK, Check: expect(2, "👍")},
+		{Name: "remove", Client: alice, Method: http.MethodDelete, Path: path,
+			Body: map[string]string{"reaction": "👍"}, Status: http.StatusOK, Check: expect(1)},
+	})
+}
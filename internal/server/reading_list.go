@@ -0,0 +1,668 @@
+package server
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+	"github.com/HardDie/blog_engine/internal/problem"
+	serviceReadingList "github.com/HardDie/blog_engine/internal/service/readinglist"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+type ReadingList struct {
+	readingListService serviceReadingList.IReadingList
+}
+
+func NewReadingList(readingList serviceReadingList.IReadingList) *ReadingList {
+	return &ReadingList{
+		readingListService: readingList,
+	}
+}
+func (s *ReadingList) RegisterPublicRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	listRouter := router.PathPrefix("").Subrouter()
+	listRouter.HandleFunc("/{id:[0-9]+}", s.PublicGet).Methods(http.MethodGet)
+	listRouter.HandleFunc("/{id:[0-9]+}/export", s.Export).Methods(http.MethodGet)
+	listRouter.Use(middleware...)
+}
+func (s *ReadingList) RegisterPrivateRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	listRouter := router.PathPrefix("").Subrouter()
+	listRouter.HandleFunc("", s.List).Methods(http.MethodGet)
+	listRouter.HandleFunc("", s.Create).Methods(http.MethodPost)
+	listRouter.HandleFunc("/{id:[0-9]+}", s.Edit).Methods(http.MethodPut)
+	listRouter.HandleFunc("/{id:[0-9]+}", s.Delete).Methods(http.MethodDelete)
+	listRouter.HandleFunc("/{id:[0-9]+}/items", s.AddItem).Methods(http.MethodPost)
+	listRouter.HandleFunc("/{id:[0-9]+}/items", s.Reorder).Methods(http.MethodPut)
+	listRouter.HandleFunc("/{id:[0-9]+}/items/{postId:[0-9]+}", s.RemoveItem).Methods(http.MethodDelete)
+	listRouter.Use(middleware...)
+}
+
+/*
+ * Public
+ */
+
+// swagger:parameters ReadingListPublicGetRequest
+type ReadingListPublicGetRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+}
+
+// swagger:response ReadingListPublicGetResponse
+type ReadingListPublicGetResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.ReadingList `json:"data"`
+	}
+}
+
+// swagger:route GET /api/v1/lists/{id} ReadingList ReadingListPublicGetRequest
+//
+// # Get a public reading list or a private list of the current user
+//
+//	Responses:
+//	  200: ReadingListPublicGetResponse
+//	  default: ProblemResponse
+func (s *ReadingList) PublicGet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetOptionalUserIDFromContext(ctx)
+
+	listID, err := utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.PublicGet() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+	req := &dto.GetReadingListDTO{
+		ID: listID,
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	list, err := s.readingListService.PublicGet(ctx, req.ID, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "ReadingList.PublicGet() PublicGet", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: list,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.PublicGet() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters ReadingListExportRequest
+type ReadingListExportRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+	// In: query
+	// Enum: json,markdown,csv
+	Format string `json:"format"`
+}
+
+// File with the reading list in the requested format
+// swagger:response ReadingListExportResponse
+type ReadingListExportResponse struct {
+	// In: body
+	Body []byte
+}
+
+// swagger:route GET /api/v1/lists/{id}/export ReadingList ReadingListExportRequest
+//
+// # Export a reading list as json, markdown or csv file
+//
+//	Produces:
+//	- application/json
+//	- text/markdown
+//	- text/csv
+//
+//	Responses:
+//	  200: ReadingListExportResponse
+//	  default: ProblemResponse
+func (s *ReadingList) Export(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetOptionalUserIDFromContext(ctx)
+
+	listID, err := utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Export() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+	req := &dto.ExportReadingListDTO{
+		ID:     listID,
+		Format: r.URL.Query().Get("format"),
+	}
+	if req.Format == "" {
+		req.Format = "json"
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	list, err := s.readingListService.PublicGet(ctx, req.ID, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "ReadingList.Export() PublicGet", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	var data []byte
+	var contentType, ext string
+	switch req.Format {
+	case "markdown":
+		data, contentType, ext = exportReadingListMarkdown(list), "text/markdown; charset=utf-8", "md"
+	case "csv":
+		data, err = exportReadingListCSV(list)
+		contentType, ext = "text/csv; charset=utf-8", "csv"
+	default:
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="reading-list-%d.json"`, list.ID))
+		err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+			Data: list,
+		})
+		if err != nil {
+			slog.ErrorContext(r.Context(), "ReadingList.Export() WriteJSONHTTPResponse", "error", err)
+		}
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Export() write", "format", req.Format, "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="reading-list-%d.%s"`, list.ID, ext))
+	_, err = w.Write(data)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Export() Write", "error", err)
+	}
+}
+
+/*
+ * Private
+ */
+
+// swagger:parameters ReadingListListRequest
+type ReadingListListRequest struct {
+	// In: query
+	dto.ListReadingListDTO
+}
+
+// swagger:response ReadingListListResponse
+type ReadingListListResponse struct {
+	// In: body
+	Body struct {
+		Data []*entity.ReadingList `json:"data"`
+	}
+}
+
+// swagger:route GET /api/v1/lists ReadingList ReadingListListRequest
+//
+// # Get reading lists of the current user
+//
+//	Responses:
+//	  200: ReadingListListResponse
+//	  default: ProblemResponse
+func (s *ReadingList) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.ListReadingListDTO{
+		Limit: utils.GetInt32FromQuery(r, "limit", 0),
+		Page:  utils.GetInt32FromQuery(r, "page", 0),
+	}
+
+	err := GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	lists, total, err := s.readingListService.List(ctx, req, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.List() List", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	meta := &utils.Meta{
+		Total: int32(total),
+		Limit: req.Limit,
+		Page:  req.Page,
+	}
+	err = utils.ResponseWithMeta(w, lists, meta)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.List() ResponseWithMeta", "error", err)
+	}
+}
+
+// swagger:parameters ReadingListCreateRequest
+type ReadingListCreateRequest struct {
+	// In: body
+	Body struct {
+		dto.CreateReadingListDTO
+	}
+}
+
+// swagger:response ReadingListCreateResponse
+type ReadingListCreateResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.ReadingList `json:"data"`
+	}
+}
+
+// swagger:route POST /api/v1/lists ReadingList ReadingListCreateRequest
+//
+// # Reading list creation form
+//
+//	Responses:
+//	  201: ReadingListCreateResponse
+//	  default: ProblemResponse
+func (s *ReadingList) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.CreateReadingListDTO{}
+	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Create() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	list, err := s.readingListService.Create(ctx, req, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Create() Create", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusCreated, JSONResponse{
+		Data: list,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Create() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters ReadingListEditRequest
+type ReadingListEditRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+	// In: body
+	Body struct {
+		dto.EditReadingListDTO
+	}
+}
+
+// swagger:response ReadingListEditResponse
+type ReadingListEditResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.ReadingList `json:"data"`
+	}
+}
+
+// swagger:route PUT /api/v1/lists/{id} ReadingList ReadingListEditRequest
+//
+// # Edit reading list form
+//
+//	Responses:
+//	  200: ReadingListEditResponse
+//	  default: ProblemResponse
+func (s *ReadingList) Edit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.EditReadingListDTO{}
+	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Edit() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
+		return
+	}
+
+	req.ID, err = utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Edit() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	list, err := s.readingListService.Edit(ctx, req, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "ReadingList.Edit() Edit", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: list,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Edit() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters ReadingListDeleteRequest
+type ReadingListDeleteRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+}
+
+// swagger:response ReadingListDeleteResponse
+type ReadingListDeleteResponse struct {
+}
+
+// swagger:route DELETE /api/v1/lists/{id} ReadingList ReadingListDeleteRequest
+//
+// # Delete reading list
+//
+//	Responses:
+//	  200: ReadingListDeleteResponse
+//	  default: ProblemResponse
+func (s *ReadingList) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	listID, err := utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Delete() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+	req := &dto.GetReadingListDTO{
+		ID: listID,
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	err = s.readingListService.Delete(ctx, req.ID, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "ReadingList.Delete() Delete", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+}
+
+// swagger:parameters ReadingListAddItemRequest
+type ReadingListAddItemRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+	// In: body
+	Body struct {
+		dto.AddReadingListItemDTO
+	}
+}
+
+// swagger:response ReadingListAddItemResponse
+type ReadingListAddItemResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.ReadingListItem `json:"data"`
+	}
+}
+
+// swagger:route POST /api/v1/lists/{id}/items ReadingList ReadingListAddItemRequest
+//
+// # Bookmark a post into the reading list or update the note of the bookmark
+//
+//	Responses:
+//	  200: ReadingListAddItemResponse
+//	  default: ProblemResponse
+func (s *ReadingList) AddItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.AddReadingListItemDTO{}
+	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.AddItem() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
+		return
+	}
+
+	req.ID, err = utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.AddItem() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	item, err := s.readingListService.AddItem(ctx, req, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "ReadingList.AddItem() AddItem", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: item,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.AddItem() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters ReadingListRemoveItemRequest
+type ReadingListRemoveItemRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+	// In: path
+	PostID int32 `json:"postId"`
+}
+
+// swagger:response ReadingListRemoveItemResponse
+type ReadingListRemoveItemResponse struct {
+}
+
+// swagger:route DELETE /api/v1/lists/{id}/items/{postId} ReadingList ReadingListRemoveItemRequest
+//
+// # Remove a post from the reading list
+//
+//	Responses:
+//	  200: ReadingListRemoveItemResponse
+//	  default: ProblemResponse
+func (s *ReadingList) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.RemoveReadingListItemDTO{}
+	var err error
+	req.ID, err = utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.RemoveItem() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+	req.PostID, err = utils.GetInt64FromPath(r, "postId")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.RemoveItem() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad postId in path"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	err = s.readingListService.RemoveItem(ctx, req, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "ReadingList.RemoveItem() RemoveItem", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+}
+
+// swagger:parameters ReadingListReorderRequest
+type ReadingListReorderRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+	// In: body
+	Body struct {
+		dto.ReorderReadingListDTO
+	}
+}
+
+// swagger:response ReadingListReorderResponse
+type ReadingListReorderResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.ReadingList `json:"data"`
+	}
+}
+
+// swagger:route PUT /api/v1/lists/{id}/items ReadingList ReadingListReorderRequest
+//
+// # Move the passed posts to the top of the reading list in the passed order
+//
+//	Responses:
+//	  200: ReadingListReorderResponse
+//	  default: ProblemResponse
+func (s *ReadingList) Reorder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.ReorderReadingListDTO{}
+	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Reorder() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
+		return
+	}
+
+	req.ID, err = utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Reorder() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	list, err := s.readingListService.Reorder(ctx, req, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "ReadingList.Reorder() Reorder", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: list,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "ReadingList.Reorder() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+func exportReadingListMarkdown(list *entity.ReadingList) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("# " + list.Title + "\n\n")
+	for i, item := range list.Items {
+		fmt.Fprintf(&buf, "%d. [%s](/posts/%d)\n", i+1, item.Post.Title, item.PostID)
+		if item.Note != nil && *item.Note != "" {
+			for _, line := range strings.Split(*item.Note, "\n") {
+				buf.WriteString("   > " + line + "\n")
+			}
+		}
+	}
+	return buf.Bytes()
+}
+func exportReadingListCSV(list *entity.ReadingList) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	err := writer.Write([]string{"position", "post_id", "title", "note", "added_at"})
+	if err != nil {
+		return nil, fmt.Errorf("csv header: %w", err)
+	}
+	for i, item := range list.Items {
+		var note string
+		if item.Note != nil {
+			note = *item.Note
+		}
+		err = writer.Write([]string{
+			strconv.Itoa(i + 1),
+			strconv.FormatInt(item.PostID, 10),
+			item.Post.Title,
+			note,
+			item.CreatedAt.Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("csv row: %w", err)
+		}
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return nil, fmt.Errorf("csv flush: %w", err)
+	}
+	return buf.Bytes(), nil
+}
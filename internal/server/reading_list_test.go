@@ -0,0 +1,127 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+	"github.com/HardDie/blog_engine/internal/entity"
+)
+
+func TestReadingList(t *testing.T) {
+	h := apptest.New(t)
+	f := h.Fixtures
+	anonymous := h.Client(t)
+	alice := h.Login(t, "alice")
+	bob := h.Login(t, "bob")
+
+	expectItems := func(expected ...int64) func(t *testing.T, res *apptest.Response) {
+		return func(t *testing.T, res *apptest.Response) {
+			var got entity.ReadingList
+			res.Data(t, &got)
+			ids := make([]int64, 0, len(got.Items))
+			for _, item := range got.Items {
+				ids = append(ids, item.PostID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(expected) {
+				t.Fatalf("expected items %v, got %v", expected, ids)
+			}
+		}
+	}
+	export := func(contentType, content string) func(t *testing.T, res *apptest.Response) {
+		return func(t *testing.T, res *apptest.Response) {
+			if !strings.HasPrefix(res.Header.Get("Content-Type"), contentType) {
+				t.Fatalf("unexpected content type %q", res.Header.Get("Content-Type"))
+			}
+			if !strings.Contains(res.Header.Get("Content-Disposition"), "attachment") {
+				t.Fatalf("unexpected content disposition %q", res.Header.Get("Content-Disposition"))
+			}
+			if !strings.Contains(string(res.Body), content) {
+				t.Fatalf("no %q in the export: %s", content, res.Body)
+			}
+		}
+	}
+	var created entity.ReadingList
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "create anonymous", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/lists",
+			Body: map[string]any{"title": "Later"}, Status: http.StatusUnauthorized, Code: "session_missing"},
+		{Name: "create invalid json", Client: alice, Method: http.MethodPost, Path: "/api/v1/lists",
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "create without title", Client: alice, Method: http.MethodPost, Path: "/api/v1/lists",
+			Body: map[string]any{"title": ""}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "create", Client: alice, Method: http.MethodPost, Path: "/api/v1/lists",
+			Body: map[string]any{"title": "Later"}, Status: http.StatusCreated, Check: func(t *testing.T, res *apptest.Response) {
+				res.Data(t, &created)
+				if created.ID == 0 || created.UserID != f.Alice.ID || created.IsPublic {
+					t.Fatalf("unexpected list %+v", created)
+				}
+			}},
+	})
+
+	path := fmt.Sprintf("/api/v1/lists/%d", created.ID)
+	apptest.Run(t, []apptest.Case{
+		{Name: "own list", Client: alice, Method: http.MethodGet, Path: "/api/v1/lists",
+			Status: http.StatusOK, Check: expectIDs(created.ID)},
+		{Name: "list of another user", Client: bob, Method: http.MethodGet, Path: "/api/v1/lists",
+			Status: http.StatusOK, Check: expectIDs()},
+		{Name: "add item", Client: alice, Method: http.MethodPost, Path: path + "/items",
+			Body: map[string]any{"postId": f.BobPost.ID, "note": "About SQL"}, Status: http.StatusOK},
+		{Name: "add to the list of another user", Client: bob, Method: http.MethodPost, Path: fmt.Sprintf("/api/v1/lists/%d/items", created.ID),
+			Body: map[string]any{"postId": f.BobPost.ID}, Status: http.StatusNotFound, Code: "reading_list_not_found"},
+		{Name: "add missing post", Client: alice, Method: http.MethodPost, Path: path + "/items",
+			Body: map[string]any{"postId": 1000}, Status: http.StatusBadRequest, Code: "post_not_found"},
+		{Name: "add item invalid json", Client: alice, Method: http.MethodPost, Path: path + "/items",
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "add second item", Client: alice, Method: http.MethodPost, Path: path + "/items",
+			Body: map[string]any{"postId": f.AlicePost.ID}, Status: http.StatusOK},
+		{Name: "get own private", Client: alice, Method: http.MethodGet, Path: path,
+			Status: http.StatusOK, Check: expectItems(f.BobPost.ID, f.AlicePost.ID)},
+		{Name: "get private", Client: anonymous, Method: http.MethodGet, Path: path,
+			Status: http.StatusNotFound, Code: "reading_list_not_found"},
+		{Name: "export private", Client: anonymous, Method: http.MethodGet, Path: path + "/export",
+			Status: http.StatusNotFound, Code: "reading_list_not_found"},
+		{Name: "reorder", Client: alice, Method: http.MethodPut, Path: path + "/items",
+			Body:   map[string]any{"postIds": []int64{f.AlicePost.ID, f.BobPost.ID}},
+			Status: http.StatusOK, Check: expectItems(f.AlicePost.ID, f.BobPost.ID)},
+		{Name: "reorder without posts", Client: alice, Method: http.MethodPut, Path: path + "/items",
+			Body: map[string]any{}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "edit by another user", Client: bob, Method: http.MethodPut, Path: path,
+			Body: map[string]any{"title": "Mine", "isPublic": true}, Status: http.StatusNotFound, Code: "reading_list_not_found"},
+		{Name: "edit invalid json", Client: alice, Method: http.MethodPut, Path: path,
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "make public", Client: alice, Method: http.MethodPut, Path: path,
+			Body: map[string]any{"title": "Read later", "isPublic": true}, Status: http.StatusOK,
+			Check: func(t *testing.T, res *apptest.Response) {
+				var got entity.ReadingList
+				res.Data(t, &got)
+				if got.Title != "Read later" || !got.IsPublic {
+					t.Fatalf("the list is not edited: %+v", got)
+				}
+			}},
+		{Name: "get public", Client: anonymous, Method: http.MethodGet, Path: path,
+			Status: http.StatusOK, Check: expectItems(f.AlicePost.ID, f.BobPost.ID)},
+		{Name: "export json", Client: anonymous, Method: http.MethodGet, Path: path + "/export",
+			Status: http.StatusOK, Check: export("application/json", `"title":"Read later"`)},
+		{Name: "export markdown", Client: anonymous, Method: http.MethodGet, Path: path + "/export?format=markdown",
+			Status: http.StatusOK, Check: export("text/markdown", f.BobPost.Title)},
+		{Name: "export csv", Client: anonymous, Method: http.MethodGet, Path: path + "/export?format=csv",
+			Status: http.StatusOK, Check: export("text/csv", "About SQL")},
+		{Name: "export unknown format", Client: anonymous, Method: http.MethodGet, Path: path + "/export?format=pdf",
+			Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "remove item", Client: alice, Method: http.MethodDelete, Path: fmt.Sprintf("%s/items/%d", path, f.BobPost.ID),
+			Status: http.StatusOK},
+		{Name: "remove missing item", Client: alice, Method: http.MethodDelete, Path: fmt.Sprintf("%s/items/%d", path, f.BobPost.ID),
+			Status: http.StatusBadRequest, Code: "reading_list_item_not_found"},
+		{Name: "get after remove", Client: alice, Method: http.MethodGet, Path: path,
+			Status: http.StatusOK, Check: expectItems(f.AlicePost.ID)},
+		{Name: "delete by another user", Client: bob, Method: http.MethodDelete, Path: path,
+			Status: http.StatusNotFound, Code: "reading_list_not_found"},
+		{Name: "delete", Client: alice, Method: http.MethodDelete, Path: path,
+			Status: http.StatusOK},
+		{Name: "get deleted", Client: alice, Method: http.MethodGet, Path: path,
+			Status: http.StatusNotFound, Code: "reading_list_not_found"},
+	})
+}
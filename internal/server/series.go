@@ -0,0 +1,227 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+	"github.com/HardDie/blog_engine/internal/problem"
+	serviceSeries "github.com/HardDie/blog_engine/internal/service/series"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+type Series struct {
+	seriesService serviceSeries.ISeries
+}
+
+func NewSeries(series serviceSeries.ISeries) *Series {
+	return &Series{
+		seriesService: series,
+	}
+}
+func (s *Series) RegisterPublicRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	seriesRouter := router.PathPrefix("").Subrouter()
+	seriesRouter.HandleFunc("/{id:[0-9]+}", s.PublicGet).Methods(http.MethodGet)
+	seriesRouter.Use(middleware...)
+}
+func (s *Series) RegisterPrivateRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	seriesRouter := router.PathPrefix("").Subrouter()
+	seriesRouter.HandleFunc("", s.Create).Methods(http.MethodPost)
+	seriesRouter.HandleFunc("/{id:[0-9]+}/posts", s.Reorder).Methods(http.MethodPut)
+	seriesRouter.Use(middleware...)
+}
+
+/*
+ * Public
+ */
+
+// swagger:parameters SeriesPublicGetRequest
+type SeriesPublicGetRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+}
+
+// swagger:response SeriesPublicGetResponse
+type SeriesPublicGetResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.Series `json:"data"`
+	}
+}
+
+// swagger:route GET /api/v1/series/{id} Series SeriesPublicGetRequest
+//
+// # Get a series with its published parts
+//
+//	Responses:
+//	  200: SeriesPublicGetResponse
+//	  default: ProblemResponse
+func (s *Series) PublicGet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seriesID, err := utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Series.PublicGet() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+	req := &dto.PublicGetSeriesDTO{
+		ID: seriesID,
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	series, err := s.seriesService.PublicGet(ctx, seriesID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Series.PublicGet() PublicGet", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: series,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Series.PublicGet() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+/*
+ * Private
+ */
+
+// swagger:parameters SeriesCreateRequest
+type SeriesCreateRequest struct {
+	// In: body
+	Body struct {
+		dto.CreateSeriesDTO
+	}
+}
+
+// swagger:response SeriesCreateResponse
+type SeriesCreateResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.Series `json:"data"`
+	}
+}
+
+// swagger:route POST /api/v1/series Series SeriesCreateRequest
+//
+// # Series creation form
+//
+//	Responses:
+//	  201: SeriesCreateResponse
+//	  default: ProblemResponse
+func (s *Series) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.CreateSeriesDTO{}
+	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Series.Create() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	series, err := s.seriesService.Create(ctx, req, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Series.Create() Create", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusCreated, JSONResponse{
+		Data: series,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Series.Create() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters SeriesReorderRequest
+type SeriesReorderRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+	// In: body
+	Body struct {
+		dto.ReorderSeriesDTO
+	}
+}
+
+// swagger:response SeriesReorderResponse
+type SeriesReorderResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.Series `json:"data"`
+	}
+}
+
+// swagger:route PUT /api/v1/series/{id}/posts Series SeriesReorderRequest
+//
+// # Set the ordered list of posts in the series
+//
+//	Responses:
+//	  200: SeriesReorderResponse
+//	  default: ProblemResponse
+func (s *Series) Reorder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.ReorderSeriesDTO{}
+	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Series.Reorder() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
+		return
+	}
+
+	req.ID, err = utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Series.Reorder() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	series, err := s.seriesService.Reorder(ctx, req, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Series.Reorder() Reorder", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: series,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Series.Reorder() WriteJSONHTTPResponse", "error", err)
+	}
+}
@@ -0,0 +1,83 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+	"github.com/HardDie/blog_engine/internal/entity"
+)
+
+func TestSeries(t *testing.T) {
+	h := apptest.New(t)
+	f := h.Fixtures
+	anonymous := h.Client(t)
+	alice := h.Login(t, "alice")
+	bob := h.Login(t, "bob")
+
+	expectPosts := func(expected ...int64) func(t *testing.T, res *apptest.Response) {
+		return func(t *testing.T, res *apptest.Response) {
+			var got entity.Series
+			res.Data(t, &got)
+			ids := make([]int64, 0, len(got.Posts))
+			for _, post := range got.Posts {
+				ids = append(ids, post.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(expected) {
+				t.Fatalf("expected posts %v, got %v", expected, ids)
+			}
+		}
+	}
+	var created entity.Series
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "create anonymous", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/series",
+			Body: map[string]any{"title": "Go"}, Status: http.StatusUnauthorized, Code: "session_missing"},
+		{Name: "create invalid json", Client: alice, Method: http.MethodPost, Path: "/api/v1/series",
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "create without title", Client: alice, Method: http.MethodPost, Path: "/api/v1/series",
+			Body: map[string]any{"title": ""}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "create with post of another user", Client: alice, Method: http.MethodPost, Path: "/api/v1/series",
+			Body:   map[string]any{"title": "Go", "postIds": []int64{f.BobPost.ID}},
+			Status: http.StatusBadRequest, Code: "post_not_found"},
+		{Name: "create", Client: alice, Method: http.MethodPost, Path: "/api/v1/series",
+			Body:   map[string]any{"title": "Go", "postIds": []int64{f.AlicePost.ID, f.AliceDraft.ID}},
+			Status: http.StatusCreated, Check: func(t *testing.T, res *apptest.Response) {
+				res.Data(t, &created)
+				if created.ID == 0 || created.UserID != f.Alice.ID || created.Title != "Go" {
+					t.Fatalf("unexpected series %+v", created)
+				}
+			}},
+		{Name: "create with post of another series", Client: alice, Method: http.MethodPost, Path: "/api/v1/series",
+			Body:   map[string]any{"title": "Go again", "postIds": []int64{f.AlicePost.ID}},
+			Status: http.StatusBadRequest, Code: "post_in_another_series"},
+	})
+
+	path := fmt.Sprintf("/api/v1/series/%d", created.ID)
+	apptest.Run(t, []apptest.Case{
+		{Name: "get without drafts", Client: anonymous, Method: http.MethodGet, Path: path,
+			Status: http.StatusOK, Check: expectPosts(f.AlicePost.ID)},
+		{Name: "get missing", Client: anonymous, Method: http.MethodGet, Path: "/api/v1/series/1000",
+			Status: http.StatusNotFound, Code: "series_not_found"},
+		{Name: "reorder", Client: alice, Method: http.MethodPut, Path: path + "/posts",
+			Body:   map[string]any{"postIds": []int64{f.AliceDraft.ID, f.AlicePost.ID}},
+			Status: http.StatusOK, Check: expectPosts(f.AliceDraft.ID, f.AlicePost.ID)},
+		{Name: "reorder duplicates", Client: alice, Method: http.MethodPut, Path: path + "/posts",
+			Body:   map[string]any{"postIds": []int64{f.AlicePost.ID, f.AlicePost.ID}},
+			Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "reorder by another user", Client: bob, Method: http.MethodPut, Path: path + "/posts",
+			Body:   map[string]any{"postIds": []int64{f.BobPost.ID}},
+			Status: http.StatusNotFound, Code: "series_not_found"},
+		{Name: "reorder invalid json", Client: alice, Method: http.MethodPut, Path: path + "/posts",
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "post shows the series", Client: anonymous, Method: http.MethodGet, Path: fmt.Sprintf("/api/v1/posts/%d", f.AlicePost.ID),
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				var got entity.Post
+				res.Data(t, &got)
+				if got.Series == nil || got.Series.ID != created.ID {
+					t.Fatalf("unexpected series of the post %+v", got.Series)
+				}
+			}},
+	})
+}
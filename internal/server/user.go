@@ -1,14 +1,14 @@
 package server
 
 import (
-	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
 	"github.com/HardDie/blog_engine/internal/dto"
 	"github.com/HardDie/blog_engine/internal/entity"
-	"github.com/HardDie/blog_engine/internal/logger"
+	"github.com/HardDie/blog_engine/internal/problem"
 	serviceUser "github.com/HardDie/blog_engine/internal/service/user"
 	"github.com/HardDie/blog_engine/internal/utils"
 )
@@ -31,6 +31,8 @@ func (s *User) RegisterPrivateRouter(router *mux.Router, middleware ...mux.Middl
 	userRouter := router.PathPrefix("").Subrouter()
 	userRouter.HandleFunc("/password", s.Password).Methods(http.MethodPut)
 	userRouter.HandleFunc("/profile", s.Profile).Methods(http.MethodPut)
+	userRouter.HandleFunc("/{id:[0-9]+}/follow", s.Follow).Methods(http.MethodPost)
+	userRouter.HandleFunc("/{id:[0-9]+}/follow", s.Unfollow).Methods(http.MethodDelete)
 	userRouter.Use(middleware...)
 }
 
@@ -58,15 +60,15 @@ type UserGetResponse struct {
 //
 //	Responses:
 //	  200: UserGetResponse
+//	  default: ProblemResponse
 func (s *User) Get(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	currentUserID := utils.GetOptionalUserIDFromContext(ctx)
 
 	userID, err := utils.GetInt64FromPath(r, "id")
 	if err != nil {
-		logger.Error.Printf("User.Get() GetInt32FromPath: %s", err.Error())
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Bad id in path",
-		})
+		slog.ErrorContext(r.Context(), "User.Get() GetInt32FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
 		return
 	}
 	req := dto.GetUserDTO{
@@ -75,24 +77,17 @@ func (s *User) Get(w http.ResponseWriter, r *http.Request) {
 
 	err = GetValidator().Struct(req)
 	if err != nil {
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Validation",
-			Data:  err.Error(),
-		})
+		problem.Write(w, r, problem.Validation(err))
 		return
 	}
 
-	user, err := s.user.Get(ctx, userID)
+	user, err := s.user.Get(ctx, userID, currentUserID)
 	if err != nil {
-		switch {
-		case errors.Is(err, serviceUser.ErrorUserNotFound):
-			utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-				Error: "User not found",
-			})
+		if writeError(w, r, err) {
 			return
 		}
-		logger.Error.Printf("User.Get() Get: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "User.Get() Get", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
@@ -100,7 +95,7 @@ func (s *User) Get(w http.ResponseWriter, r *http.Request) {
 		Data: user,
 	})
 	if err != nil {
-		logger.Error.Printf("User.Get() WriteJSONHTTPResponse: %s", err.Error())
+		slog.ErrorContext(r.Context(), "User.Get() WriteJSONHTTPResponse", "error", err)
 	}
 }
 
@@ -126,6 +121,7 @@ type UserPasswordResponse struct {
 //
 //	Responses:
 //	  200: UserPasswordResponse
+//	  default: ProblemResponse
 func (s *User) Password(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := utils.GetUserIDFromContext(ctx)
@@ -133,31 +129,24 @@ func (s *User) Password(w http.ResponseWriter, r *http.Request) {
 	req := &dto.UpdatePasswordDTO{}
 	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
 	if err != nil {
-		logger.Error.Printf("User.Password() ParseJsonFromHTTPRequest: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		slog.ErrorContext(r.Context(), "User.Password() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
 		return
 	}
 
 	err = GetValidator().Struct(req)
 	if err != nil {
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Validation",
-			Data:  err.Error(),
-		})
+		problem.Write(w, r, problem.Validation(err))
 		return
 	}
 
 	err = s.user.Password(ctx, req, userID)
 	if err != nil {
-		switch {
-		case errors.Is(err, serviceUser.ErrorInvalidPassword):
-			utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-				Error: "Invalid old password",
-			})
+		if writeError(w, r, err) {
 			return
 		}
-		logger.Error.Printf("User.Password() Password: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "User.Password() Password", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 }
@@ -180,6 +169,7 @@ type UserProfileResponse struct {
 //
 //	Responses:
 //	  200: UserProfileResponse
+//	  default: ProblemResponse
 func (s *User) Profile(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := utils.GetUserIDFromContext(ctx)
@@ -187,24 +177,21 @@ func (s *User) Profile(w http.ResponseWriter, r *http.Request) {
 	req := &dto.UpdateProfileDTO{}
 	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
 	if err != nil {
-		logger.Error.Printf("User.Profile() ParseJsonFromHTTPRequest: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		slog.ErrorContext(r.Context(), "User.Profile() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
 		return
 	}
 
 	err = GetValidator().Struct(req)
 	if err != nil {
-		utils.WriteJSONHTTPResponse(w, http.StatusBadRequest, JSONResponse{
-			Error: "Validation",
-			Data:  err.Error(),
-		})
+		problem.Write(w, r, problem.Validation(err))
 		return
 	}
 
 	user, err := s.user.Profile(ctx, req, userID)
 	if err != nil {
-		logger.Error.Printf("User.Profile() Profile: %s", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		slog.ErrorContext(r.Context(), "User.Profile() Profile", "error", err)
+		problem.Write(w, r, problem.Internal())
 		return
 	}
 
@@ -212,6 +199,99 @@ func (s *User) Profile(w http.ResponseWriter, r *http.Request) {
 		Data: user,
 	})
 	if err != nil {
-		logger.Error.Printf("User.Profile() WriteJSONHTTPResponse: %s", err.Error())
+		slog.ErrorContext(r.Context(), "User.Profile() WriteJSONHTTPResponse", "error", err)
 	}
 }
+
+// swagger:parameters UserFollowRequest
+type UserFollowRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+}
+
+// swagger:response UserFollowResponse
+type UserFollowResponse struct {
+}
+
+// swagger:route POST /api/v1/user/{id}/follow User UserFollowRequest
+//
+// # Follow the user to see their posts in the following feed
+//
+//	Responses:
+//	  200: UserFollowResponse
+//	  default: ProblemResponse
+func (s *User) Follow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req, ok := s.parseFollowRequest("Follow", w, r)
+	if !ok {
+		return
+	}
+
+	err := s.user.Follow(ctx, req.ID, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "User.Follow() Follow", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+}
+
+// swagger:parameters UserUnfollowRequest
+type UserUnfollowRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+}
+
+// swagger:response UserUnfollowResponse
+type UserUnfollowResponse struct {
+}
+
+// swagger:route DELETE /api/v1/user/{id}/follow User UserUnfollowRequest
+//
+// # Stop following the user
+//
+//	Responses:
+//	  200: UserUnfollowResponse
+//	  default: ProblemResponse
+func (s *User) Unfollow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req, ok := s.parseFollowRequest("Unfollow", w, r)
+	if !ok {
+		return
+	}
+
+	err := s.user.Unfollow(ctx, req.ID, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "User.Unfollow() Unfollow", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+}
+
+func (s *User) parseFollowRequest(method string, w http.ResponseWriter, r *http.Request) (*dto.GetUserDTO, bool) {
+	id, err := utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "User.parseFollowRequest() GetInt64FromPath", "method", method, "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return nil, false
+	}
+	req := &dto.GetUserDTO{
+		ID: id,
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return nil, false
+	}
+	return req, true
+}
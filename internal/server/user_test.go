@@ -0,0 +1,90 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+	"github.com/HardDie/blog_engine/internal/entity"
+)
+
+func TestUser(t *testing.T) {
+	h := apptest.New(t)
+	f := h.Fixtures
+	anonymous := h.Client(t)
+	alice := h.Login(t, "alice")
+	bob := h.Login(t, "bob")
+
+	expectFollowers := func(count int64, isFollowed bool) func(t *testing.T, res *apptest.Response) {
+		return func(t *testing.T, res *apptest.Response) {
+			var got entity.User
+			res.Data(t, &got)
+			if got.ID != f.Alice.ID || got.FollowersCount == nil || *got.FollowersCount != count {
+				t.Fatalf("unexpected user %+v", got)
+			}
+			if got.IsFollowed != nil && *got.IsFollowed != isFollowed {
+				t.Fatalf("expected followed %t, got %t", isFollowed, *got.IsFollowed)
+			}
+		}
+	}
+	alicePath := fmt.Sprintf("/api/v1/user/%d", f.Alice.ID)
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "get", Client: anonymous, Method: http.MethodGet, Path: alicePath,
+			Status: http.StatusOK, Check: expectFollowers(0, false)},
+		{Name: "get missing", Client: anonymous, Method: http.MethodGet, Path: "/api/v1/user/1000",
+			Status: http.StatusBadRequest, Code: "user_not_found"},
+
+		{Name: "follow anonymous", Client: anonymous, Method: http.MethodPost, Path: alicePath + "/follow",
+			Status: http.StatusUnauthorized, Code: "session_missing"},
+		{Name: "follow yourself", Client: alice, Method: http.MethodPost, Path: alicePath + "/follow",
+			Status: http.StatusBadRequest, Code: "follow_yourself"},
+		{Name: "follow missing", Client: alice, Method: http.MethodPost, Path: "/api/v1/user/1000/follow",
+			Status: http.StatusBadRequest, Code: "user_not_found"},
+		{Name: "unfollow not followed", Client: bob, Method: http.MethodDelete, Path: alicePath + "/follow",
+			Status: http.StatusBadRequest, Code: "not_following"},
+		{Name: "follow", Client: bob, Method: http.MethodPost, Path: alicePath + "/follow",
+			Status: http.StatusOK},
+		{Name: "get followed", Client: bob, Method: http.MethodGet, Path: alicePath,
+			Status: http.StatusOK, Check: expectFollowers(1, true)},
+		{Name: "unfollow", Client: bob, Method: http.MethodDelete, Path: alicePath + "/follow",
+			Status: http.StatusOK},
+		{Name: "get unfollowed", Client: bob, Method: http.MethodGet, Path: alicePath,
+			Status: http.StatusOK, Check: expectFollowers(0, false)},
+
+		{Name: "profile invalid json", Client: alice, Method: http.MethodPut, Path: "/api/v1/user/profile",
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "profile bad email", Client: alice, Method: http.MethodPut, Path: "/api/v1/user/profile",
+			Body:   map[string]any{"displayedName": "Alice", "email": "alice"},
+			Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "profile", Client: alice, Method: http.MethodPut, Path: "/api/v1/user/profile",
+			Body:   map[string]any{"displayedName": "Alice Liddell", "email": "alice@example.com"},
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				var got entity.User
+				res.Data(t, &got)
+				if got.DisplayedName != "Alice Liddell" || got.Email == nil || *got.Email != "alice@example.com" {
+					t.Fatalf("the profile is not updated: %+v", got)
+				}
+			}},
+
+		{Name: "password anonymous", Client: anonymous, Method: http.MethodPut, Path: "/api/v1/user/password",
+			Body:   map[string]any{"oldPassword": apptest.Password, "newPassword": "new-password"},
+			Status: http.StatusUnauthorized, Code: "session_missing"},
+		{Name: "password the same", Client: alice, Method: http.MethodPut, Path: "/api/v1/user/password",
+			Body:   map[string]any{"oldPassword": apptest.Password, "newPassword": apptest.Password},
+			Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "password wrong old", Client: alice, Method: http.MethodPut, Path: "/api/v1/user/password",
+			Body:   map[string]any{"oldPassword": "wrong", "newPassword": "new-password"},
+			Status: http.StatusBadRequest, Code: "invalid_old_password"},
+		{Name: "password", Client: alice, Method: http.MethodPut, Path: "/api/v1/user/password",
+			Body:   map[string]any{"oldPassword": apptest.Password, "newPassword": "new-password"},
+			Status: http.StatusOK},
+		{Name: "login with old password", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body:   map[string]any{"username": "alice", "password": apptest.Password},
+			Status: http.StatusBadRequest, Code: "invalid_password"},
+		{Name: "login with new password", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body:   map[string]any{"username": "alice", "password": "new-password"},
+			Status: http.StatusOK},
+	})
+}
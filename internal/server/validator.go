@@ -1,6 +1,11 @@
 package server
 
-import "github.com/go-playground/validator/v10"
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
 
 var (
 	v *validator.Validate
@@ -9,6 +14,14 @@ var (
 func GetValidator() *validator.Validate {
 	if v == nil {
 		v = validator.New()
+		// Report fields as they are named in JSON
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
 	}
 	return v
 }
@@ -16,5 +29,4 @@ func GetValidator() *validator.Validate {
 type JSONResponse struct {
 	Message any `json:"message,omitempty"`
 	Data    any `json:"data,omitempty"`
-	Error   any `json:"error,omitempty"`
 }
@@ -0,0 +1,311 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+	"github.com/HardDie/blog_engine/internal/problem"
+	serviceWebhook "github.com/HardDie/blog_engine/internal/service/webhook"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+type Webhook struct {
+	webhookService serviceWebhook.IWebhook
+}
+
+func NewWebhook(webhook serviceWebhook.IWebhook) *Webhook {
+	return &Webhook{
+		webhookService: webhook,
+	}
+}
+func (s *Webhook) RegisterPrivateRouter(router *mux.Router, middleware ...mux.MiddlewareFunc) {
+	webhookRouter := router.PathPrefix("").Subrouter()
+	webhookRouter.HandleFunc("", s.List).Methods(http.MethodGet)
+	webhookRouter.HandleFunc("", s.Create).Methods(http.MethodPost)
+	webhookRouter.HandleFunc("/{id:[0-9]+}", s.Delete).Methods(http.MethodDelete)
+	webhookRouter.HandleFunc("/{id:[0-9]+}/deliveries", s.Deliveries).Methods(http.MethodGet)
+	webhookRouter.HandleFunc("/{id:[0-9]+}/deliveries/{deliveryId:[0-9]+}/redeliver", s.Redeliver).Methods(http.MethodPost)
+	webhookRouter.Use(middleware...)
+}
+
+/*
+ * Private
+ */
+
+// swagger:parameters WebhookListRequest
+type WebhookListRequest struct {
+}
+
+// swagger:response WebhookListResponse
+type WebhookListResponse struct {
+	// In: body
+	Body struct {
+		Data []*entity.Webhook `json:"data"`
+	}
+}
+
+// swagger:route GET /api/v1/webhooks Webhook WebhookListRequest
+//
+// # Get webhooks of the current user
+//
+//	Responses:
+//	  200: WebhookListResponse
+//	  default: ProblemResponse
+func (s *Webhook) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	webhooks, err := s.webhookService.List(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Webhook.List() List", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: webhooks,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Webhook.List() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters WebhookCreateRequest
+type WebhookCreateRequest struct {
+	// In: body
+	Body struct {
+		dto.CreateWebhookDTO
+	}
+}
+
+// swagger:response WebhookCreateResponse
+type WebhookCreateResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.Webhook `json:"data"`
+	}
+}
+
+// swagger:route POST /api/v1/webhooks Webhook WebhookCreateRequest
+//
+// # Register a webhook, the secret for signature verification is returned only in this response
+//
+//	Responses:
+//	  201: WebhookCreateResponse
+//	  default: ProblemResponse
+func (s *Webhook) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.CreateWebhookDTO{}
+	err := utils.ParseJsonFromHTTPRequest(r.Body, req)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Webhook.Create() ParseJsonFromHTTPRequest", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidBody, "Invalid JSON body"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	webhook, err := s.webhookService.Create(ctx, req, userID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Webhook.Create() Create", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusCreated, JSONResponse{
+		Data: webhook,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Webhook.Create() WriteJSONHTTPResponse", "error", err)
+	}
+}
+
+// swagger:parameters WebhookDeleteRequest
+type WebhookDeleteRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+}
+
+// swagger:response WebhookDeleteResponse
+type WebhookDeleteResponse struct {
+}
+
+// swagger:route DELETE /api/v1/webhooks/{id} Webhook WebhookDeleteRequest
+//
+// # Delete webhook, its pending deliveries are canceled
+//
+//	Responses:
+//	  200: WebhookDeleteResponse
+//	  default: ProblemResponse
+func (s *Webhook) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	webhookID, err := utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Webhook.Delete() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+	req := &dto.GetWebhookDTO{
+		ID: webhookID,
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	err = s.webhookService.Delete(ctx, req.ID, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Webhook.Delete() Delete", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+}
+
+// swagger:parameters WebhookDeliveriesRequest
+type WebhookDeliveriesRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+	// In: query
+	dto.ListWebhookDeliveriesDTO
+}
+
+// swagger:response WebhookDeliveriesResponse
+type WebhookDeliveriesResponse struct {
+	// In: body
+	Body struct {
+		Data []*entity.WebhookDelivery `json:"data"`
+	}
+}
+
+// swagger:route GET /api/v1/webhooks/{id}/deliveries Webhook WebhookDeliveriesRequest
+//
+// # Get the delivery log of the webhook
+//
+//	Responses:
+//	  200: WebhookDeliveriesResponse
+//	  default: ProblemResponse
+func (s *Webhook) Deliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.ListWebhookDeliveriesDTO{
+		Limit: utils.GetInt32FromQuery(r, "limit", 0),
+		Page:  utils.GetInt32FromQuery(r, "page", 0),
+	}
+	var err error
+	req.ID, err = utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Webhook.Deliveries() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	deliveries, total, err := s.webhookService.Deliveries(ctx, req, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Webhook.Deliveries() Deliveries", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	meta := &utils.Meta{
+		Total: int32(total),
+		Limit: req.Limit,
+		Page:  req.Page,
+	}
+	err = utils.ResponseWithMeta(w, deliveries, meta)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Webhook.Deliveries() ResponseWithMeta", "error", err)
+	}
+}
+
+// swagger:parameters WebhookRedeliverRequest
+type WebhookRedeliverRequest struct {
+	// In: path
+	ID int32 `json:"id"`
+	// In: path
+	DeliveryID int32 `json:"deliveryId"`
+}
+
+// swagger:response WebhookRedeliverResponse
+type WebhookRedeliverResponse struct {
+	// In: body
+	Body struct {
+		Data *entity.WebhookDelivery `json:"data"`
+	}
+}
+
+// swagger:route POST /api/v1/webhooks/{id}/deliveries/{deliveryId}/redeliver Webhook WebhookRedeliverRequest
+//
+// # Queue the payload of the delivery once again
+//
+//	Responses:
+//	  200: WebhookRedeliverResponse
+//	  default: ProblemResponse
+func (s *Webhook) Redeliver(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.GetUserIDFromContext(ctx)
+
+	req := &dto.RedeliverWebhookDTO{}
+	var err error
+	req.ID, err = utils.GetInt64FromPath(r, "id")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Webhook.Redeliver() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad id in path"))
+		return
+	}
+	req.DeliveryID, err = utils.GetInt64FromPath(r, "deliveryId")
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Webhook.Redeliver() GetInt64FromPath", "error", err)
+		problem.Write(w, r, problem.New(http.StatusBadRequest, problem.CodeInvalidParameter, "Bad deliveryId in path"))
+		return
+	}
+
+	err = GetValidator().Struct(req)
+	if err != nil {
+		problem.Write(w, r, problem.Validation(err))
+		return
+	}
+
+	delivery, err := s.webhookService.Redeliver(ctx, req, userID)
+	if err != nil {
+		if writeError(w, r, err) {
+			return
+		}
+		slog.ErrorContext(r.Context(), "Webhook.Redeliver() Redeliver", "error", err)
+		problem.Write(w, r, problem.Internal())
+		return
+	}
+
+	err = utils.WriteJSONHTTPResponse(w, http.StatusOK, JSONResponse{
+		Data: delivery,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Webhook.Redeliver() WriteJSONHTTPResponse", "error", err)
+	}
+}
@@ -0,0 +1,115 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/apptest"
+	"github.com/HardDie/blog_engine/internal/entity"
+)
+
+func TestWebhook(t *testing.T) {
+	// The receiver listens on the loopback
+	h := apptest.New(t, "-webhook-allow-private=true")
+	anonymous := h.Client(t)
+	alice := h.Login(t, "alice")
+	bob := h.Login(t, "bob")
+
+	received := make(chan string, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case received <- r.Header.Get("X-Webhook-Event"):
+		default:
+		}
+	}))
+	t.Cleanup(receiver.Close)
+
+	var created entity.Webhook
+	var deliveries []*entity.WebhookDelivery
+
+	apptest.Run(t, []apptest.Case{
+		{Name: "create anonymous", Client: anonymous, Method: http.MethodPost, Path: "/api/v1/webhooks",
+			Body:   map[string]any{"url": receiver.URL, "events": []string{entity.WebhookEventPostPublished}},
+			Status: http.StatusUnauthorized, Code: "session_missing"},
+		{Name: "create invalid json", Client: alice, Method: http.MethodPost, Path: "/api/v1/webhooks",
+			Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{Name: "create bad url", Client: alice, Method: http.MethodPost, Path: "/api/v1/webhooks",
+			Body:   map[string]any{"url": "ftp://example.com", "events": []string{entity.WebhookEventPostPublished}},
+			Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "create unknown event", Client: alice, Method: http.MethodPost, Path: "/api/v1/webhooks",
+			Body:   map[string]any{"url": receiver.URL, "events": []string{"post.read"}},
+			Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "create", Client: alice, Method: http.MethodPost, Path: "/api/v1/webhooks",
+			Body:   map[string]any{"url": receiver.URL, "events": []string{entity.WebhookEventPostPublished}},
+			Status: http.StatusCreated, Check: func(t *testing.T, res *apptest.Response) {
+				res.Data(t, &created)
+				if created.ID == 0 || created.URL != receiver.URL || created.Secret == "" {
+					t.Fatalf("unexpected webhook %+v", created)
+				}
+			}},
+		{Name: "list hides the secret", Client: alice, Method: http.MethodGet, Path: "/api/v1/webhooks",
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				var got []*entity.Webhook
+				res.Data(t, &got)
+				if len(got) != 1 || got[0].ID != created.ID || got[0].Secret != "" {
+					t.Fatalf("unexpected webhooks %+v", got)
+				}
+			}},
+		{Name: "list of another user", Client: bob, Method: http.MethodGet, Path: "/api/v1/webhooks",
+			Status: http.StatusOK, Check: expectIDs()},
+		{Name: "publish", Client: alice, Method: http.MethodPost, Path: "/api/v1/posts",
+			Body:   map[string]any{"title": "Hooked", "short": "Short", "body": "Body", "isPublished": true},
+			Status: http.StatusCreated},
+	})
+
+	// The delivery is sent by the job queue of the server
+	select {
+	case event := <-received:
+		if event != entity.WebhookEventPostPublished {
+			t.Fatalf("unexpected event %q", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the delivery is not sent")
+	}
+
+	path := fmt.Sprintf("/api/v1/webhooks/%d", created.ID)
+	apptest.Run(t, []apptest.Case{
+		{Name: "deliveries", Client: alice, Method: http.MethodGet, Path: path + "/deliveries",
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				res.Data(t, &deliveries)
+				if len(deliveries) != 1 || deliveries[0].Event != entity.WebhookEventPostPublished {
+					t.Fatalf("unexpected deliveries %+v", deliveries)
+				}
+			}},
+		{Name: "deliveries of another user", Client: bob, Method: http.MethodGet, Path: path + "/deliveries",
+			Status: http.StatusNotFound, Code: "webhook_not_found"},
+	})
+	if len(deliveries) != 1 {
+		t.FailNow()
+	}
+
+	redeliver := fmt.Sprintf("%s/deliveries/%d/redeliver", path, deliveries[0].ID)
+	apptest.Run(t, []apptest.Case{
+		{Name: "redeliver", Client: alice, Method: http.MethodPost, Path: redeliver,
+			Status: http.StatusOK, Check: func(t *testing.T, res *apptest.Response) {
+				var got entity.WebhookDelivery
+				res.Data(t, &got)
+				if got.ID == deliveries[0].ID || string(got.Payload) != string(deliveries[0].Payload) {
+					t.Fatalf("unexpected delivery %+v", got)
+				}
+			}},
+		{Name: "redeliver missing", Client: alice, Method: http.MethodPost, Path: path + "/deliveries/1000/redeliver",
+			Status: http.StatusNotFound, Code: "delivery_not_found"},
+		{Name: "redeliver by another user", Client: bob, Method: http.MethodPost, Path: redeliver,
+			Status: http.StatusNotFound, Code: "webhook_not_found"},
+		{Name: "delete by another user", Client: bob, Method: http.MethodDelete, Path: path,
+			Status: http.StatusNotFound, Code: "webhook_not_found"},
+		{Name: "delete", Client: alice, Method: http.MethodDelete, Path: path,
+			Status: http.StatusOK},
+		{Name: "deliveries of deleted", Client: alice, Method: http.MethodGet, Path: path + "/deliveries",
+			Status: http.StatusNotFound, Code: "webhook_not_found"},
+	})
+}
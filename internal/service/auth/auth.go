@@ -2,37 +2,68 @@ package auth
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
 	"errors"
 	"fmt"
-	"sync"
+	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/db"
 	"github.com/HardDie/blog_engine/internal/dto"
 	"github.com/HardDie/blog_engine/internal/entity"
-	"github.com/HardDie/blog_engine/internal/logger"
+	"github.com/HardDie/blog_engine/internal/metrics"
 	"github.com/HardDie/blog_engine/internal/models"
-	repositorySession "github.com/HardDie/blog_engine/internal/repository/boltdb/session"
+	repositorySession "github.com/HardDie/blog_engine/internal/repository/session"
 	repositoryInvite "github.com/HardDie/blog_engine/internal/repository/sqlite/invite"
 	repositoryPassword "github.com/HardDie/blog_engine/internal/repository/sqlite/password"
 	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+	serviceNotification "github.com/HardDie/blog_engine/internal/service/notification"
+	serviceWebhook "github.com/HardDie/blog_engine/internal/service/webhook"
+	"github.com/HardDie/blog_engine/internal/tracing"
+	"github.com/HardDie/blog_engine/internal/transaction"
 	"github.com/HardDie/blog_engine/internal/utils"
 )
 
+const (
+	// JobPurgeLoginFailures is the periodic job of the queue
+	JobPurgeLoginFailures = "auth.purge_login_failures"
+
+	sessionLifetime = 24 * time.Hour
+
+	// rootUserID is seeded by the users migration, it invites users created by the administrator
+	rootUserID = 0
+)
+
 type IAuth interface {
 	Register(ctx context.Context, req *dto.RegisterDTO) (*entity.User, error)
-	Login(ctx context.Context, req *dto.LoginDTO) (*entity.User, error)
+	Login(ctx context.Context, req *dto.LoginDTO, ip string) (*entity.User, error)
 	Logout(ctx context.Context, sessionHash string) error
 	GenerateCookie(ctx context.Context, userID int64) (string, error)
 	ValidateCookie(ctx context.Context, session string) (*entity.Session, error)
 	GetUserInfo(ctx context.Context, userID int64) (*entity.User, error)
+	CountActiveSessions(ctx context.Context) (int64, error)
+
+	InitSetup(ctx context.Context) error
+	Setup(ctx context.Context, req *dto.SetupDTO) (*entity.User, error)
+
+	Create(ctx context.Context, req *dto.CreateUserDTO) (*entity.User, error)
+	GetByName(ctx context.Context, username string) (*entity.User, error)
+	ResetPassword(ctx context.Context, userID int64, newPassword string) error
+	Ban(ctx context.Context, userID int64) error
+	PurgeSessions(ctx context.Context, userID int64) (int64, error)
+	PurgeLoginFailures(ctx context.Context) (int64, error)
 }
 
 type Session interface {
 	CreateOrUpdate(_ context.Context, userID int64, sessionHash string) (*models.Session, error)
 	DeleteBySessionHash(_ context.Context, sessionHash string) error
 	GetBySessionHash(_ context.Context, sessionHash string) (*models.Session, error)
+	CountCreatedAfter(_ context.Context, createdAfter time.Time) (int64, error)
+	DeleteByUserID(_ context.Context, userID int64) (int64, error)
+	DeleteCreatedBefore(_ context.Context, createdBefore time.Time) (int64, error)
 }
 
 type Auth struct {
@@ -41,8 +72,13 @@ type Auth struct {
 	sessionRepository  Session
 	inviteRepository   repositoryInvite.Querier
 
-	cfg   *config.Config
-	mutex sync.Mutex
+	transaction         transaction.IManager
+	notificationService serviceNotification.INotification
+	webhookService      serviceWebhook.IWebhook
+
+	cfg *config.Config
+	// setupHash of the one-time setup token, it is nil when the setup is disabled
+	setupHash atomic.Pointer[string]
 }
 
 func New(
@@ -51,21 +87,25 @@ func New(
 	password repositoryPassword.Querier,
 	session Session,
 	invite repositoryInvite.Querier,
+	transaction transaction.IManager,
+	notification serviceNotification.INotification,
+	webhook serviceWebhook.IWebhook,
 ) *Auth {
 	return &Auth{
-		cfg:                cfg,
-		userRepository:     user,
-		passwordRepository: password,
-		sessionRepository:  session,
-		inviteRepository:   invite,
+		cfg:                 cfg,
+		userRepository:      user,
+		passwordRepository:  password,
+		sessionRepository:   session,
+		inviteRepository:    invite,
+		transaction:         transaction,
+		notificationService: notification,
+		webhookService:      webhook,
 	}
 }
 
 func (s *Auth) Register(ctx context.Context, req *dto.RegisterDTO) (*entity.User, error) {
-	s.mutex.Lock()
-	defer func() {
-		s.mutex.Unlock()
-	}()
+	ctx, span := tracing.Start(ctx, "Auth.Register")
+	defer span.End()
 
 	// Hashing invite
 	hashInvite := utils.HashSha256(req.Invite)
@@ -84,76 +124,63 @@ func (s *Auth) Register(ctx context.Context, req *dto.RegisterDTO) (*entity.User
 	if time.Now().Sub(invite.UpdatedAt) > time.Hour*24 {
 		err = s.inviteRepository.Delete(ctx, invite.ID)
 		if err != nil {
-			logger.Error.Printf("Auth.Register(): Can't delete expired invite [%d]: %v", invite.ID, err.Error())
+			slog.ErrorContext(ctx, "Auth.Register() can't delete expired invite", "invite_id", invite.ID, "error", err)
 		}
 		return nil, ErrorInviteExpired
 	}
 
-	// Check if username is not busy
-	u, err := s.userRepository.GetByName(ctx, req.Username)
-	switch {
-	case errors.Is(err, sql.ErrNoRows):
-		u = nil
-		// continue
-	default:
-		return nil, fmt.Errorf("Auth.Register() FindByName: %w", err)
-	}
-	if u != nil {
-		return nil, ErrorUserExist
-	}
-
-	// Activating invite
-	invite, err = s.inviteRepository.Activate(ctx, invite.ID)
+	err = s.checkUsername(ctx, req.Username)
 	if err != nil {
-		return nil, fmt.Errorf("Auth.Register() Activate: %w", err)
+		return nil, err
 	}
 
-	// Hashing password
-	hashPassword, err := utils.HashBcrypt(req.Password)
+	user, err := s.createUser(ctx, req.Username, req.DisplayedName, req.Password, invite.ID)
 	if err != nil {
-		return nil, fmt.Errorf("Auth.Register() HashBcrypt: %w", err)
+		return nil, fmt.Errorf("Auth.Register() %w", err)
 	}
 
-	// Create a user
-	resp, err := s.userRepository.Create(ctx, repositoryUser.CreateParams{
-		Username:      req.Username,
-		DisplayedName: req.DisplayedName,
-		InvitedByUser: invite.UserID,
+	err = s.notificationService.Notify(ctx, &entity.Notification{
+		UserID:  user.InvitedByUserID,
+		Type:    entity.NotificationTypeInviteUsed,
+		ActorID: user.ID,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("Auth.Register() user.Create: %w", err)
-	}
-	user := &entity.User{
-		ID:              resp.ID,
-		Username:        resp.Username,
-		DisplayedName:   resp.DisplayedName,
-		Email:           utils.SqlStringToString(resp.Email),
-		InvitedByUserID: resp.InvitedByUser,
-		CreatedAt:       resp.CreatedAt,
-		UpdatedAt:       resp.UpdatedAt,
+		slog.ErrorContext(ctx, "Auth.Register() can't notify about used invite", "recipient_id", user.InvitedByUserID, "error", err)
 	}
-
-	// Create a password
-	_, err = s.passwordRepository.Create(ctx, repositoryPassword.CreateParams{
-		UserID:       user.ID,
-		PasswordHash: hashPassword,
+	err = s.webhookService.Dispatch(ctx, entity.WebhookEventUserRegistered, user.InvitedByUserID, &entity.User{
+		ID:              user.ID,
+		DisplayedName:   user.DisplayedName,
+		InvitedByUserID: user.InvitedByUserID,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("Auth.Register() password.Create: %w", err)
+		slog.ErrorContext(ctx, "Auth.Register() can't dispatch webhooks", "recipient_id", user.InvitedByUserID, "error", err)
 	}
 
 	return user, nil
 }
-func (s *Auth) Login(ctx context.Context, req *dto.LoginDTO) (*entity.User, error) {
+
+// Login checks the password. Failed attempts are counted per user and client IP, so the lockout after PWD_MAX_ATTEMPTS
+// blocks only the address of the attacker, not the owner of the account.
+func (s *Auth) Login(ctx context.Context, req *dto.LoginDTO, ip string) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "Auth.Login")
+	defer span.End()
+
 	// Check if such user exist
 	resp, err := s.userRepository.GetByName(ctx, req.Username)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
+			metrics.LoginFailure(metrics.LoginFailureUnknownUser)
 			return nil, ErrorUserNotFound
 		}
 		return nil, fmt.Errorf("Auth.Login() user.GetByName: %w", err)
 	}
+	if resp.BannedAt.Valid {
+		metrics.LoginFailure(metrics.LoginFailureBanned)
+		return nil, ErrorUserBanned
+	}
 	user := &entity.User{
 		ID:              resp.ID,
 		Username:        resp.Username,
@@ -170,39 +197,55 @@ func (s *Auth) Login(ctx context.Context, req *dto.LoginDTO) (*entity.User, erro
 		return nil, fmt.Errorf("Auth.Login() password.GetByUserID: %w", err)
 	}
 
-	// Check if the password is locked after failed attempts
-	if password.FailedAttempts >= int64(s.cfg.PwdMaxAttempts) {
+	// Check if the password is locked for the address after failed attempts, the lockout is disabled by zero attempts
+	failureKey := repositoryPassword.GetLoginFailureParams{
+		UserID: user.ID,
+		Ip:     ip,
+	}
+	failure, err := s.passwordRepository.GetLoginFailure(ctx, failureKey)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("Auth.Login() GetLoginFailure: %w", err)
+	}
+	if failure != nil && s.cfg.PwdMaxAttempts > 0 && failure.Attempts >= int64(s.cfg.PwdMaxAttempts) {
 		// Check if the password block time has expired
-		if time.Now().Sub(password.UpdatedAt) <= time.Hour*time.Duration(s.cfg.PwdBlockTime) {
+		if time.Now().Sub(failure.UpdatedAt) <= time.Hour*time.Duration(s.cfg.PwdBlockTime) {
+			metrics.LoginFailure(metrics.LoginFailureBlocked)
 			return nil, ErrorUserBlocked
 		}
 		// If the blocking time has expired, reset the counter of failed attempts
-		password, err = s.passwordRepository.ResetFailedAttempts(ctx, password.ID)
+		err = s.passwordRepository.ResetLoginFailures(ctx, repositoryPassword.ResetLoginFailuresParams(failureKey))
 		if err != nil {
-			return nil, fmt.Errorf("Auth.Login() ResetFailedAttempts: %w", err)
+			return nil, fmt.Errorf("Auth.Login() ResetLoginFailures: %w", err)
 		}
+		failure = nil
 	}
 
 	// Check if password is correct
 	if !utils.HashBcryptCompare(req.Password, password.PasswordHash) {
+		metrics.LoginFailure(metrics.LoginFailureInvalidPassword)
 		// Increased number of failed attempts
-		_, err = s.passwordRepository.IncreaseFailedAttempts(ctx, password.ID)
+		failure, err = s.passwordRepository.IncreaseLoginFailures(ctx, repositoryPassword.IncreaseLoginFailuresParams(failureKey))
 		if err != nil {
-			logger.Error.Println("Auth.Login() IncreaseFailedAttempts:", err.Error())
+			slog.ErrorContext(ctx, "Auth.Login() IncreaseLoginFailures", "error", err)
+		} else if s.cfg.PwdMaxAttempts > 0 && failure.Attempts == int64(s.cfg.PwdMaxAttempts) {
+			metrics.Lockout()
 		}
 		return nil, ErrorInvalidPassword
 	}
 
 	// Reset the failed attempts counter after the first successful attempt
-	if password.FailedAttempts > 0 {
-		_, err = s.passwordRepository.ResetFailedAttempts(ctx, password.ID)
+	if failure != nil {
+		err = s.passwordRepository.ResetLoginFailures(ctx, repositoryPassword.ResetLoginFailuresParams(failureKey))
 		if err != nil {
-			logger.Error.Println("Auth.Login() ResetFailedAttempts:", err.Error())
+			slog.ErrorContext(ctx, "Auth.Login() ResetLoginFailures", "error", err)
 		}
 	}
 	return user, nil
 }
 func (s *Auth) Logout(ctx context.Context, sessionHash string) error {
+	ctx, span := tracing.Start(ctx, "Auth.Logout")
+	defer span.End()
+
 	err := s.sessionRepository.DeleteBySessionHash(ctx, sessionHash)
 	if err != nil {
 		return fmt.Errorf("Auth.Logout() DeleteByID: %w", err)
@@ -210,6 +253,9 @@ func (s *Auth) Logout(ctx context.Context, sessionHash string) error {
 	return nil
 }
 func (s *Auth) GenerateCookie(ctx context.Context, userID int64) (string, error) {
+	ctx, span := tracing.Start(ctx, "Auth.GenerateCookie")
+	defer span.End()
+
 	// Generate session key
 	sessionKey, err := utils.GenerateSessionKey()
 	if err != nil {
@@ -225,6 +271,9 @@ func (s *Auth) GenerateCookie(ctx context.Context, userID int64) (string, error)
 	return sessionKey, nil
 }
 func (s *Auth) ValidateCookie(ctx context.Context, sessionToken string) (*entity.Session, error) {
+	ctx, span := tracing.Start(ctx, "Auth.ValidateCookie")
+	defer span.End()
+
 	// Check if session exist
 	sessionHash := utils.HashSha256(sessionToken)
 	resp, err := s.sessionRepository.GetBySessionHash(ctx, sessionHash)
@@ -242,12 +291,15 @@ func (s *Auth) ValidateCookie(ctx context.Context, sessionToken string) (*entity
 	}
 
 	// Check if session is not expired
-	if time.Now().Sub(session.CreatedAt) > time.Hour*24 {
+	if time.Now().Sub(session.CreatedAt) > sessionLifetime {
 		return nil, ErrorSessionHasExpired
 	}
 	return session, nil
 }
 func (s *Auth) GetUserInfo(ctx context.Context, userID int64) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "Auth.GetUserInfo")
+	defer span.End()
+
 	resp, err := s.userRepository.GetByIDPrivate(ctx, userID)
 	if err != nil {
 		switch {
@@ -267,6 +319,301 @@ func (s *Auth) GetUserInfo(ctx context.Context, userID int64) (*entity.User, err
 	}
 	return user, nil
 }
+func (s *Auth) CountActiveSessions(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "Auth.CountActiveSessions")
+	defer span.End()
+
+	count, err := s.sessionRepository.CountCreatedAfter(ctx, time.Now().Add(-sessionLifetime))
+	if err != nil {
+		return 0, fmt.Errorf("Auth.CountActiveSessions() %w", err)
+	}
+	return count, nil
+}
+
+/*
+ * Bootstrap of a fresh instance
+ */
+
+// InitSetup enables the setup while nobody is registered. The token is taken from SETUP_TOKEN,
+// or generated and printed to the log, because there is no other way to deliver it to the owner.
+func (s *Auth) InitSetup(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "Auth.InitSetup")
+	defer span.End()
+
+	count, err := s.userRepository.CountRegistered(ctx)
+	if err != nil {
+		return fmt.Errorf("Auth.InitSetup() CountRegistered: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	token := s.cfg.SetupToken
+	if token == "" {
+		token, err = utils.GenerateSessionKey()
+		if err != nil {
+			return fmt.Errorf("Auth.InitSetup() GenerateSessionKey: %w", err)
+		}
+		slog.WarnContext(ctx, "No users are registered, create the first account with POST /api/v1/auth/setup and the one-time token "+token)
+	} else {
+		slog.WarnContext(ctx, "No users are registered, create the first account with POST /api/v1/auth/setup and SETUP_TOKEN")
+	}
+
+	hash := utils.HashSha256(token)
+	s.setupHash.Store(&hash)
+	return nil
+}
+
+// Setup creates the first account and disables itself.
+func (s *Auth) Setup(ctx context.Context, req *dto.SetupDTO) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "Auth.Setup")
+	defer span.End()
+
+	hash := s.setupHash.Load()
+	if hash == nil {
+		return nil, ErrorSetupDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(utils.HashSha256(req.Token)), []byte(*hash)) != 1 {
+		return nil, ErrorInvalidSetupToken
+	}
+	// Claim the token, so concurrent requests with it get ErrorSetupDisabled. It is returned if the setup fails.
+	if !s.setupHash.CompareAndSwap(hash, nil) {
+		return nil, ErrorSetupDisabled
+	}
+	user, err := s.setup(ctx, req)
+	switch {
+	case errors.Is(err, ErrorSetupDisabled):
+		return nil, err
+	case err != nil:
+		// Let the owner retry, e.g. with another username
+		s.setupHash.Store(hash)
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Setup is completed, the setup token is disabled", "user_id", user.ID)
+	return user, nil
+}
+func (s *Auth) setup(ctx context.Context, req *dto.SetupDTO) (*entity.User, error) {
+	// The account could be created by the CLI after the start
+	count, err := s.userRepository.CountRegistered(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Auth.Setup() CountRegistered: %w", err)
+	}
+	if count > 0 {
+		return nil, ErrorSetupDisabled
+	}
+
+	err = s.checkUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.createUser(ctx, req.Username, req.DisplayedName, req.Password, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Auth.Setup() %w", err)
+	}
+	return user, nil
+}
+
+/*
+ * Administration, these methods are used by the CLI
+ */
+
+// Create a user without an invite, the user is invited by root.
+func (s *Auth) Create(ctx context.Context, req *dto.CreateUserDTO) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "Auth.Create")
+	defer span.End()
+
+	err := s.checkUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.createUser(ctx, req.Username, req.DisplayedName, req.Password, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Auth.Create() %w", err)
+	}
+	return user, nil
+}
+func (s *Auth) GetByName(ctx context.Context, username string) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "Auth.GetByName")
+	defer span.End()
+
+	resp, err := s.userRepository.GetByName(ctx, username)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrorUserNotFound
+		}
+		return nil, fmt.Errorf("Auth.GetByName() %w", err)
+	}
+	return userEntity(resp), nil
+}
+
+// ResetPassword sets the password without the old one, unlocks the account for all addresses and closes all sessions of the user.
+func (s *Auth) ResetPassword(ctx context.Context, userID int64, newPassword string) error {
+	ctx, span := tracing.Start(ctx, "Auth.ResetPassword")
+	defer span.End()
+
+	password, err := s.passwordRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrorUserNotFound
+		}
+		return fmt.Errorf("Auth.ResetPassword() GetByUserID: %w", err)
+	}
+	hashPassword, err := utils.HashBcrypt(newPassword)
+	if err != nil {
+		return fmt.Errorf("Auth.ResetPassword() HashBcrypt: %w", err)
+	}
+	_, err = s.passwordRepository.Update(ctx, repositoryPassword.UpdateParams{
+		ID:           password.ID,
+		PasswordHash: hashPassword,
+	})
+	if err != nil {
+		return fmt.Errorf("Auth.ResetPassword() Update: %w", err)
+	}
+	err = s.passwordRepository.ResetAllLoginFailures(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("Auth.ResetPassword() ResetAllLoginFailures: %w", err)
+	}
+	_, err = s.sessionRepository.DeleteByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("Auth.ResetPassword() DeleteByUserID: %w", err)
+	}
+	return nil
+}
+
+// Ban forbids the login and closes all sessions of the user.
+func (s *Auth) Ban(ctx context.Context, userID int64) error {
+	ctx, span := tracing.Start(ctx, "Auth.Ban")
+	defer span.End()
+
+	_, err := s.userRepository.Ban(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrorUserNotFound
+		}
+		return fmt.Errorf("Auth.Ban() Ban: %w", err)
+	}
+	_, err = s.sessionRepository.DeleteByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("Auth.Ban() DeleteByUserID: %w", err)
+	}
+	return nil
+}
+
+// PurgeLoginFailures deletes counters of failed logins, which are older than PWD_BLOCK_TIME. They would be reset
+// by the next login anyway, so the table doesn't grow with the number of addresses trying passwords.
+func (s *Auth) PurgeLoginFailures(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "Auth.PurgeLoginFailures")
+	defer span.End()
+
+	age := time.Hour * time.Duration(s.cfg.PwdBlockTime)
+	count, err := s.passwordRepository.PurgeLoginFailures(ctx, fmt.Sprintf("-%d seconds", int64(age/time.Second)))
+	if err != nil {
+		return 0, fmt.Errorf("Auth.PurgeLoginFailures() PurgeLoginFailures: %w", err)
+	}
+	return count, nil
+}
+
+// PurgeSessions deletes expired sessions, or all sessions of the user if userID is not zero.
+func (s *Auth) PurgeSessions(ctx context.Context, userID int64) (int64, error) {
+	ctx, span := tracing.Start(ctx, "Auth.PurgeSessions")
+	defer span.End()
+
+	if userID != 0 {
+		count, err := s.sessionRepository.DeleteByUserID(ctx, userID)
+		if err != nil {
+			return 0, fmt.Errorf("Auth.PurgeSessions() DeleteByUserID: %w", err)
+		}
+		return count, nil
+	}
+	count, err := s.sessionRepository.DeleteCreatedBefore(ctx, time.Now().Add(-sessionLifetime))
+	if err != nil {
+		return 0, fmt.Errorf("Auth.PurgeSessions() DeleteCreatedBefore: %w", err)
+	}
+	return count, nil
+}
+
+// checkUsername gives a clear error in the common case, concurrent registrations of the same name
+// are caught by the unique index in createUser.
+func (s *Auth) checkUsername(ctx context.Context, username string) error {
+	_, err := s.userRepository.GetByName(ctx, username)
+	switch {
+	case err == nil:
+		return ErrorUserExist
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	}
+	return fmt.Errorf("Auth.checkUsername() GetByName: %w", err)
+}
+
+// createUser activates the invite, creates the user and the password in one transaction, so a failed step
+// doesn't leave a burned invite or an account without a password. Without the invite the user is invited by root.
+func (s *Auth) createUser(ctx context.Context, username, displayedName, password string, inviteID int64) (*entity.User, error) {
+	// Hashing password before the transaction, bcrypt is slow and the transaction holds the writer
+	hashPassword, err := utils.HashBcrypt(password)
+	if err != nil {
+		return nil, fmt.Errorf("createUser() HashBcrypt: %w", err)
+	}
+
+	var user *entity.User
+	err = s.transaction.Do(ctx, func(repos *transaction.Repositories) error {
+		invitedBy := int64(rootUserID)
+		if inviteID != 0 {
+			// Activating invite
+			invite, err := repos.Invite.Activate(ctx, inviteID)
+			if err != nil {
+				switch {
+				case errors.Is(err, sql.ErrNoRows):
+					// Used by a concurrent registration
+					return ErrorInviteNotFound
+				}
+				return fmt.Errorf("invite.Activate: %w", err)
+			}
+			invitedBy = invite.UserID
+		}
+
+		// Create a user
+		resp, err := repos.User.Create(ctx, repositoryUser.CreateParams{
+			Username:      username,
+			DisplayedName: displayedName,
+			InvitedByUser: invitedBy,
+		})
+		if err != nil {
+			if db.IsUniqueViolation(err) {
+				return ErrorUserExist
+			}
+			return fmt.Errorf("user.Create: %w", err)
+		}
+		user = userEntity(resp)
+
+		// Create a password
+		_, err = repos.Password.Create(ctx, repositoryPassword.CreateParams{
+			UserID:       user.ID,
+			PasswordHash: hashPassword,
+		})
+		if err != nil {
+			return fmt.Errorf("password.Create: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("createUser() %w", err)
+	}
+	return user, nil
+}
+func userEntity(resp *repositoryUser.User) *entity.User {
+	return &entity.User{
+		ID:              resp.ID,
+		Username:        resp.Username,
+		DisplayedName:   resp.DisplayedName,
+		Email:           utils.SqlStringToString(resp.Email),
+		InvitedByUserID: resp.InvitedByUser,
+		CreatedAt:       resp.CreatedAt,
+		UpdatedAt:       resp.UpdatedAt,
+	}
+}
 
 var (
 	ErrorInviteNotFound    = errors.New("invite not found")
@@ -274,7 +621,10 @@ var (
 	ErrorUserExist         = errors.New("user exist")
 	ErrorUserNotFound      = errors.New("user not found")
 	ErrorUserBlocked       = errors.New("user blocked")
+	ErrorUserBanned        = errors.New("user banned")
 	ErrorInvalidPassword   = errors.New("invalid password")
 	ErrorSessionNotFound   = errors.New("session not found")
 	ErrorSessionHasExpired = errors.New("session has expired")
+	ErrorSetupDisabled     = errors.New("setup is disabled")
+	ErrorInvalidSetupToken = errors.New("invalid setup token")
 )
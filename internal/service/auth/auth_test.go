@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/db"
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/migration"
+	repositoryInvite "github.com/HardDie/blog_engine/internal/repository/sqlite/invite"
+	repositoryPassword "github.com/HardDie/blog_engine/internal/repository/sqlite/password"
+	repositorySeries "github.com/HardDie/blog_engine/internal/repository/sqlite/series"
+	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+	"github.com/HardDie/blog_engine/internal/transaction"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+var errFailed = errors.New("failed")
+
+// failingPassword fails the last step of createUser, after the invite is activated and the user is created.
+type failingPassword struct {
+	repositoryPassword.Querier
+}
+
+func (failingPassword) Create(context.Context, repositoryPassword.CreateParams) (*repositoryPassword.Password, error) {
+	return nil, errFailed
+}
+
+func TestRegisterRollback(t *testing.T) {
+	ctx := context.Background()
+	database, err := db.Get(filepath.Join(t.TempDir(), "blog.db"), 2, 5000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+	err = migration.NewMigrate(database).Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users := repositoryUser.New(database)
+	invites := repositoryInvite.New(database)
+	inviter, err := users.Create(ctx, repositoryUser.CreateParams{
+		Username:      "alice",
+		DisplayedName: "Alice",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const code = "invite-code"
+	_, err = invites.CreateOrUpdate(ctx, repositoryInvite.CreateOrUpdateParams{
+		UserID:     inviter.ID,
+		InviteHash: utils.HashSha256(code),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager := transaction.New(database, func(tx *sql.Tx) *transaction.Repositories {
+		return &transaction.Repositories{
+			Invite:   repositoryInvite.New(tx),
+			Password: failingPassword{repositoryPassword.New(tx)},
+			Series:   repositorySeries.New(tx),
+			User:     repositoryUser.New(tx),
+		}
+	})
+	s := New(&config.Config{}, users, repositoryPassword.New(database), nil, invites, manager, nil, nil)
+
+	_, err = s.Register(ctx, &dto.RegisterDTO{
+		Invite:        code,
+		Username:      "bob",
+		DisplayedName: "Bob",
+		Password:      "password",
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected the error of the password, got %v", err)
+	}
+
+	_, err = invites.GetByInviteHash(ctx, utils.HashSha256(code))
+	if err != nil {
+		t.Fatalf("the invite is used: %v", err)
+	}
+	_, err = users.GetByName(ctx, "bob")
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("the user is created: %v", err)
+	}
+}
@@ -0,0 +1,424 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/boltdb"
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/db"
+	"github.com/HardDie/blog_engine/internal/migration"
+	"github.com/HardDie/blog_engine/internal/tracing"
+)
+
+const (
+	// JobCreate is the periodic job of the queue
+	JobCreate = "backup.create"
+
+	namePrefix   = "blog-"
+	nameLayout   = "20060102T150405Z"
+	tmpSuffix    = ".tmp"
+	manifestName = "manifest.json"
+
+	fileDB       = "blog.db"
+	fileSessions = "sessions.db"
+
+	// preRestoreSuffix the replaced databases are kept next to the restored ones
+	preRestoreSuffix = ".pre-restore"
+)
+
+type IBackup interface {
+	Create(ctx context.Context) (*Manifest, error)
+	List(ctx context.Context) ([]*Manifest, error)
+}
+
+type File struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest is written next to the copies, restore refuses files which don't match it.
+type Manifest struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Migration version of the SQLite copy
+	Migration int64  `json:"migration"`
+	Files     []File `json:"files"`
+}
+
+type Backup struct {
+	cfg *config.Config
+	db  *db.DB
+	// boltDB is nil unless sessions are kept in it
+	boltDB  *boltdb.DB
+	migrate *migration.Migrate
+
+	// mutex one backup at a time, the scheduled one can meet the one requested by the admin
+	mutex sync.Mutex
+}
+
+func New(cfg *config.Config, db *db.DB, boltDB *boltdb.DB, migrate *migration.Migrate) *Backup {
+	return &Backup{
+		cfg:     cfg,
+		db:      db,
+		boltDB:  boltDB,
+		migrate: migrate,
+	}
+}
+
+// Create copies the databases while the server is running and deletes backups exceeding BACKUP_KEEP.
+// The copies are written to a temporary directory, which is renamed when the manifest is complete.
+func (s *Backup) Create(ctx context.Context) (*Manifest, error) {
+	ctx, span := tracing.Start(ctx, "Backup.Create")
+	defer span.End()
+
+	if s.db.Driver != db.DriverSQLite {
+		return nil, fmt.Errorf("Backup.Create() %w", ErrorNotSupported)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	manifest := &Manifest{
+		Name:      namePrefix + time.Now().UTC().Format(nameLayout),
+		CreatedAt: time.Now().UTC(),
+	}
+	dir := filepath.Join(s.cfg.BackupDir, manifest.Name)
+	tmp := dir + tmpSuffix
+	err := os.MkdirAll(tmp, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("Backup.Create() MkdirAll: %w", err)
+	}
+
+	err = s.write(ctx, tmp, manifest)
+	if err != nil {
+		if removeErr := os.RemoveAll(tmp); removeErr != nil {
+			slog.ErrorContext(ctx, "Backup.Create() can't remove incomplete backup", "dir", tmp, "error", removeErr)
+		}
+		return nil, fmt.Errorf("Backup.Create() %w", err)
+	}
+	err = os.Rename(tmp, dir)
+	if err != nil {
+		return nil, fmt.Errorf("Backup.Create() Rename: %w", err)
+	}
+
+	err = s.prune(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Backup.Create() can't delete old backups", "error", err)
+	}
+	slog.InfoContext(ctx, "Backup is created", "name", manifest.Name)
+	return manifest, nil
+}
+func (s *Backup) List(ctx context.Context) ([]*Manifest, error) {
+	_, span := tracing.Start(ctx, "Backup.List")
+	defer span.End()
+
+	return List(s.cfg.BackupDir)
+}
+
+func (s *Backup) write(ctx context.Context, dir string, manifest *Manifest) error {
+	status, err := s.migrate.Status()
+	if err != nil {
+		return fmt.Errorf("migration status: %w", err)
+	}
+	manifest.Migration = status.Current
+
+	err = s.db.Backup(ctx, filepath.Join(dir, fileDB))
+	if err != nil {
+		return fmt.Errorf("sqlite: %w", err)
+	}
+	files := []string{fileDB}
+	// Other stores keep sessions in the main DB or don't persist them
+	if s.boltDB != nil {
+		err = s.writeSessions(filepath.Join(dir, fileSessions))
+		if err != nil {
+			return fmt.Errorf("boltdb: %w", err)
+		}
+		files = append(files, fileSessions)
+	}
+
+	for _, name := range files {
+		f, err := describe(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, *f)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("manifest: %w", err)
+	}
+	err = os.WriteFile(filepath.Join(dir, manifestName), data, 0600)
+	if err != nil {
+		return fmt.Errorf("manifest: %w", err)
+	}
+	return nil
+}
+
+func (s *Backup) writeSessions(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	err = s.boltDB.Backup(file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return errors.Join(file.Sync(), file.Close())
+}
+
+// prune deletes the oldest backups, names sort by the creation time.
+func (s *Backup) prune(ctx context.Context) error {
+	list, err := List(s.cfg.BackupDir)
+	if err != nil {
+		return err
+	}
+	for i := s.cfg.BackupKeep; i < len(list); i++ {
+		err = os.RemoveAll(filepath.Join(s.cfg.BackupDir, list[i].Name))
+		if err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "Old backup is deleted", "name", list[i].Name)
+	}
+	return nil
+}
+
+/*
+ * Offline operations, they don't need open databases
+ */
+
+// List returns complete backups of the directory, the newest first.
+func List(dir string) ([]*Manifest, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*Manifest{}, nil
+		}
+		return nil, fmt.Errorf("backup.List() ReadDir: %w", err)
+	}
+	list := make([]*Manifest, 0, len(entries))
+	for _, el := range entries {
+		if !el.IsDir() || !strings.HasPrefix(el.Name(), namePrefix) || strings.HasSuffix(el.Name(), tmpSuffix) {
+			continue
+		}
+		manifest, err := readManifest(filepath.Join(dir, el.Name()))
+		if err != nil {
+			slog.Warn("backup.List() skip the backup without a valid manifest", "name", el.Name(), "error", err)
+			continue
+		}
+		list = append(list, manifest)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name > list[j].Name
+	})
+	return list, nil
+}
+
+// Verify compares the files with the manifest and checks the consistency of the databases.
+func Verify(ctx context.Context, dir string) (*Manifest, error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return nil, fmt.Errorf("backup.Verify() %w", err)
+	}
+	for _, expected := range manifest.Files {
+		actual, err := describe(filepath.Join(dir, expected.Name))
+		if err != nil {
+			return nil, fmt.Errorf("backup.Verify() %w", err)
+		}
+		if actual.Size != expected.Size || actual.SHA256 != expected.SHA256 {
+			return nil, fmt.Errorf("backup.Verify() %s: %w", expected.Name, ErrorChecksumMismatch)
+		}
+	}
+
+	sqlite, err := db.GetReadOnly(filepath.Join(dir, fileDB))
+	if err != nil {
+		return nil, fmt.Errorf("backup.Verify() %s: %w", fileDB, err)
+	}
+	err = sqlite.IntegrityCheck(ctx)
+	err = errors.Join(err, sqlite.Close())
+	if err != nil {
+		return nil, fmt.Errorf("backup.Verify() %s: %w", fileDB, err)
+	}
+	if manifest.hasFile(fileSessions) {
+		err = boltdb.Verify(filepath.Join(dir, fileSessions))
+		if err != nil {
+			return nil, fmt.Errorf("backup.Verify() %s: %w", fileSessions, err)
+		}
+	}
+	return manifest, nil
+}
+
+// Restore verifies the backup and replaces the databases of the config, the server must be stopped.
+// The replaced files are kept with the .pre-restore suffix, the restore refuses to overwrite the ones of the previous restore.
+func Restore(ctx context.Context, cfg *config.Config, dir string) (*Manifest, error) {
+	if cfg.DBDriver != db.DriverSQLite {
+		return nil, fmt.Errorf("backup.Restore() %w", ErrorNotSupported)
+	}
+	manifest, err := Verify(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// The server holds the lock while running, holding it here also keeps the server from starting in the middle
+	lock, err := db.Lock(cfg.DBPath)
+	if errors.Is(err, db.ErrorLocked) {
+		return nil, fmt.Errorf("backup.Restore() %w", ErrorServerRunning)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("backup.Restore() %w", err)
+	}
+	defer lock.Unlock()
+
+	files := map[string]string{
+		fileDB: cfg.DBPath,
+	}
+	// Backups made with SESSION_STORE=boltdb have the sessions DB, a running server holds its lock
+	if manifest.hasFile(fileSessions) {
+		sessions, err := boltdb.Get(cfg.SessionsDBPath)
+		if errors.Is(err, boltdb.ErrorLocked) {
+			return nil, fmt.Errorf("backup.Restore() %w", ErrorServerRunning)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("backup.Restore() %w", err)
+		}
+		err = sessions.Close()
+		if err != nil {
+			return nil, fmt.Errorf("backup.Restore() Close: %w", err)
+		}
+		files[fileSessions] = cfg.SessionsDBPath
+	}
+
+	// Nothing is replaced until all files are checked, otherwise the restore would stop half done
+	for _, dst := range files {
+		err = checkPreRestore(dst)
+		if err != nil {
+			return nil, fmt.Errorf("backup.Restore() %w", err)
+		}
+	}
+	for src, dst := range files {
+		err = replaceFile(filepath.Join(dir, src), dst)
+		if err != nil {
+			return nil, fmt.Errorf("backup.Restore() %w", err)
+		}
+	}
+	return manifest, nil
+}
+
+func (m *Manifest) hasFile(name string) bool {
+	for _, file := range m.Files {
+		if file.Name == name {
+			return true
+		}
+	}
+	return false
+}
+func readManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestName))
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	manifest := &Manifest{}
+	err = json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	return manifest, nil
+}
+func describe(path string) (*File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", filepath.Base(path), err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, fmt.Errorf("hash %s: %w", filepath.Base(path), err)
+	}
+	return &File{
+		Name:   filepath.Base(path),
+		Size:   size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// checkPreRestore fails if the files kept by the previous restore are still there, the admin removes them
+// once the restored DB is fine.
+func checkPreRestore(dst string) error {
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		path := dst + preRestoreSuffix + suffix
+		_, err := os.Stat(path)
+		if err == nil {
+			return fmt.Errorf("%w: %s", ErrorPreRestoreExists, path)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("stat %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// replaceFile copies the file next to the destination and renames it over, so the destination is never half written.
+// SQLite journal files belong to the replaced DB, so they are moved together with it.
+func replaceFile(src, dst string) error {
+	tmp := dst + tmpSuffix
+	err := copyFile(src, tmp)
+	if err != nil {
+		return err
+	}
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		err = os.Rename(dst+suffix, dst+preRestoreSuffix+suffix)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("keep %s: %w", dst+suffix, err)
+		}
+	}
+	err = os.Rename(tmp, dst)
+	if err != nil {
+		return fmt.Errorf("rename %s: %w", tmp, err)
+	}
+	return nil
+}
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", dst, err)
+	}
+	_, err = io.Copy(out, in)
+	if err != nil {
+		out.Close()
+		return fmt.Errorf("copy %s: %w", dst, err)
+	}
+	err = errors.Join(out.Sync(), out.Close())
+	if err != nil {
+		return fmt.Errorf("sync %s: %w", dst, err)
+	}
+	return nil
+}
+
+var (
+	ErrorChecksumMismatch = errors.New("checksum mismatch")
+	ErrorServerRunning    = errors.New("the DB is locked, stop the server before the restore")
+	ErrorPreRestoreExists = errors.New("files of the previous restore are kept, remove them before the next restore")
+	ErrorNotSupported     = errors.New("backups are made only for SQLite, use pg_dump for PostgreSQL")
+)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	repositoryInvite "github.com/HardDie/blog_engine/internal/repository/sqlite/invite"
+	"github.com/HardDie/blog_engine/internal/tracing"
 	"github.com/HardDie/blog_engine/internal/utils"
 )
 
@@ -26,6 +27,9 @@ func New(invite repositoryInvite.Querier) *Invite {
 }
 
 func (s *Invite) Generate(ctx context.Context, userID int64) (string, error) {
+	ctx, span := tracing.Start(ctx, "Invite.Generate")
+	defer span.End()
+
 	// Generate invite
 	inviteCode, err := utils.UUIDGenerate()
 	if err != nil {
@@ -44,6 +48,9 @@ func (s *Invite) Generate(ctx context.Context, userID int64) (string, error) {
 	return inviteCode, nil
 }
 func (s *Invite) Revoke(ctx context.Context, userID int64) error {
+	ctx, span := tracing.Start(ctx, "Invite.Revoke")
+	defer span.End()
+
 	invite, err := s.inviteRepository.GetActiveByUserID(ctx, userID)
 	if err != nil {
 		switch {
@@ -0,0 +1,298 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/HardDie/blog_engine/internal/broker"
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+	"github.com/HardDie/blog_engine/internal/job"
+	"github.com/HardDie/blog_engine/internal/mailer"
+	repositoryNotification "github.com/HardDie/blog_engine/internal/repository/sqlite/notification"
+	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+	"github.com/HardDie/blog_engine/internal/tracing"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+type INotification interface {
+	// Notify is called by other services when something happens to the content of the user
+	Notify(ctx context.Context, notification *entity.Notification) error
+
+	List(ctx context.Context, req *dto.ListNotificationDTO, userID int64) ([]*entity.Notification, int64, error)
+	CountUnread(ctx context.Context, userID int64) (int64, error)
+	MarkRead(ctx context.Context, req *dto.MarkReadNotificationDTO, userID int64) error
+	MarkAllRead(ctx context.Context, userID int64) error
+	Preferences(ctx context.Context, userID int64) ([]*entity.NotificationPreference, error)
+	UpdatePreferences(ctx context.Context, req *dto.UpdateNotificationPreferencesDTO, userID int64) ([]*entity.NotificationPreference, error)
+}
+
+type Notification struct {
+	notificationRepository repositoryNotification.Querier
+	userRepository         repositoryUser.Querier
+
+	queue  job.IQueue
+	broker broker.IBroker
+}
+
+func New(
+	notification repositoryNotification.Querier,
+	user repositoryUser.Querier,
+	queue job.IQueue,
+	broker broker.IBroker,
+) *Notification {
+	return &Notification{
+		notificationRepository: notification,
+		userRepository:         user,
+		queue:                  queue,
+		broker:                 broker,
+	}
+}
+
+func (s *Notification) Notify(ctx context.Context, notification *entity.Notification) error {
+	ctx, span := tracing.Start(ctx, "Notification.Notify")
+	defer span.End()
+
+	// Nobody to notify or the user did it himself
+	if notification.UserID == 0 || notification.UserID == notification.ActorID {
+		return nil
+	}
+
+	pref, err := s.preference(ctx, notification.UserID, notification.Type)
+	if err != nil {
+		return fmt.Errorf("Notification.Notify() %w", err)
+	}
+
+	if pref.InApp {
+		resp, err := s.notificationRepository.Create(ctx, repositoryNotification.CreateParams{
+			UserID:  notification.UserID,
+			Type:    notification.Type,
+			ActorID: notification.ActorID,
+			PostID:  utils.NewSqlInt64(notification.PostID),
+		})
+		if err != nil {
+			return fmt.Errorf("Notification.Notify() Create: %w", err)
+		}
+		err = s.broker.Publish(broker.EventNotificationCreated, resp.UserID, &entity.Notification{
+			ID:        resp.ID,
+			UserID:    resp.UserID,
+			Type:      resp.Type,
+			ActorID:   resp.ActorID,
+			PostID:    utils.SqlInt64ToInt64(resp.PostID),
+			CreatedAt: resp.CreatedAt,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Notification.Notify() can't publish notification", "notification_id", resp.ID, "error", err)
+		}
+	}
+
+	if pref.Email {
+		err = s.email(ctx, notification)
+		if err != nil {
+			return fmt.Errorf("Notification.Notify() %w", err)
+		}
+	}
+	return nil
+}
+func (s *Notification) List(ctx context.Context, req *dto.ListNotificationDTO, userID int64) ([]*entity.Notification, int64, error) {
+	ctx, span := tracing.Start(ctx, "Notification.List")
+	defer span.End()
+
+	limit, offset := utils.GetPagination(req.Limit, req.Page)
+	resp, err := s.notificationRepository.List(ctx, repositoryNotification.ListParams{
+		UserID:     userID,
+		OnlyUnread: req.Unread,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("Notification.List() List: %w", err)
+	}
+	if len(resp) == 0 {
+		return []*entity.Notification{}, 0, nil
+	}
+
+	notifications := make([]*entity.Notification, 0, len(resp))
+	users := make(map[int64]*entity.User)
+	for _, el := range resp {
+		notification := &entity.Notification{
+			ID:        el.Notification.ID,
+			UserID:    el.Notification.UserID,
+			Type:      el.Notification.Type,
+			ActorID:   el.Notification.ActorID,
+			PostID:    utils.SqlInt64ToInt64(el.Notification.PostID),
+			ReadAt:    utils.SqlTimeToTime(el.Notification.ReadAt),
+			CreatedAt: el.Notification.CreatedAt,
+		}
+
+		user, ok := users[notification.ActorID]
+		if !ok {
+			resp, err := s.userRepository.GetByIDPublic(ctx, notification.ActorID)
+			switch {
+			case err == nil:
+				user = &entity.User{
+					ID:              resp.ID,
+					DisplayedName:   resp.DisplayedName,
+					InvitedByUserID: resp.InvitedByUser,
+					CreatedAt:       resp.CreatedAt,
+					UpdatedAt:       resp.UpdatedAt,
+				}
+			case errors.Is(err, sql.ErrNoRows):
+				// The actor was deleted
+			default:
+				return nil, 0, fmt.Errorf("Notification.List() user.GetByIDPublic: %w", err)
+			}
+			users[notification.ActorID] = user
+		}
+		notification.Actor = user
+		notifications = append(notifications, notification)
+	}
+	return notifications, resp[0].Count, nil
+}
+func (s *Notification) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	ctx, span := tracing.Start(ctx, "Notification.CountUnread")
+	defer span.End()
+
+	count, err := s.notificationRepository.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("Notification.CountUnread() CountUnread: %w", err)
+	}
+	return count, nil
+}
+func (s *Notification) MarkRead(ctx context.Context, req *dto.MarkReadNotificationDTO, userID int64) error {
+	ctx, span := tracing.Start(ctx, "Notification.MarkRead")
+	defer span.End()
+
+	_, err := s.notificationRepository.MarkRead(ctx, repositoryNotification.MarkReadParams{
+		UserID: userID,
+		Ids:    req.IDs,
+	})
+	if err != nil {
+		return fmt.Errorf("Notification.MarkRead() MarkRead: %w", err)
+	}
+	return nil
+}
+func (s *Notification) MarkAllRead(ctx context.Context, userID int64) error {
+	ctx, span := tracing.Start(ctx, "Notification.MarkAllRead")
+	defer span.End()
+
+	_, err := s.notificationRepository.MarkAllRead(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("Notification.MarkAllRead() MarkAllRead: %w", err)
+	}
+	return nil
+}
+func (s *Notification) Preferences(ctx context.Context, userID int64) ([]*entity.NotificationPreference, error) {
+	ctx, span := tracing.Start(ctx, "Notification.Preferences")
+	defer span.End()
+
+	resp, err := s.notificationRepository.ListPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Notification.Preferences() ListPreferences: %w", err)
+	}
+	saved := make(map[string]*repositoryNotification.NotificationPreference, len(resp))
+	for _, el := range resp {
+		saved[el.Type] = el
+	}
+
+	// Types without saved preferences use the default channels
+	prefs := make([]*entity.NotificationPreference, 0, len(entity.NotificationTypes))
+	for _, notificationType := range entity.NotificationTypes {
+		pref := defaultPreference(notificationType)
+		if el, ok := saved[notificationType]; ok {
+			pref.InApp = el.InApp
+			pref.Email = el.Email
+		}
+		prefs = append(prefs, pref)
+	}
+	return prefs, nil
+}
+func (s *Notification) UpdatePreferences(ctx context.Context, req *dto.UpdateNotificationPreferencesDTO, userID int64) ([]*entity.NotificationPreference, error) {
+	ctx, span := tracing.Start(ctx, "Notification.UpdatePreferences")
+	defer span.End()
+
+	for _, pref := range req.Preferences {
+		err := s.notificationRepository.UpsertPreference(ctx, repositoryNotification.UpsertPreferenceParams{
+			UserID: userID,
+			Type:   pref.Type,
+			InApp:  pref.InApp,
+			Email:  pref.Email,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Notification.UpdatePreferences() UpsertPreference: %w", err)
+		}
+	}
+
+	prefs, err := s.Preferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Notification.UpdatePreferences() %w", err)
+	}
+	return prefs, nil
+}
+
+func (s *Notification) preference(ctx context.Context, userID int64, notificationType string) (*entity.NotificationPreference, error) {
+	resp, err := s.notificationRepository.GetPreference(ctx, repositoryNotification.GetPreferenceParams{
+		UserID: userID,
+		Type:   notificationType,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return defaultPreference(notificationType), nil
+		}
+		return nil, fmt.Errorf("GetPreference: %w", err)
+	}
+	return &entity.NotificationPreference{
+		Type:  resp.Type,
+		InApp: resp.InApp,
+		Email: resp.Email,
+	}, nil
+}
+
+// email queues the notification, so a slow mail server doesn't delay the request.
+func (s *Notification) email(ctx context.Context, notification *entity.Notification) error {
+	user, err := s.userRepository.GetByIDPrivate(ctx, notification.UserID)
+	if err != nil {
+		return fmt.Errorf("Notification.email() user.GetByIDPrivate: %w", err)
+	}
+	if !user.Email.Valid || user.Email.String == "" {
+		return nil
+	}
+	actor, err := s.userRepository.GetByIDPublic(ctx, notification.ActorID)
+	if err != nil {
+		return fmt.Errorf("Notification.email() user.GetByIDPublic: %w", err)
+	}
+
+	subject, body := emailMessage(notification, actor.DisplayedName)
+	err = s.queue.Enqueue(ctx, mailer.JobSend, &mailer.Message{
+		To:      user.Email.String,
+		Subject: subject,
+		Body:    body,
+	})
+	if err != nil {
+		return fmt.Errorf("Notification.email() queue.Enqueue: %w", err)
+	}
+	return nil
+}
+
+func defaultPreference(notificationType string) *entity.NotificationPreference {
+	return &entity.NotificationPreference{
+		Type:  notificationType,
+		InApp: true,
+		Email: false,
+	}
+}
+func emailMessage(notification *entity.Notification, actor string) (string, string) {
+	switch notification.Type {
+	case entity.NotificationTypeReaction:
+		return "New reaction on your post", fmt.Sprintf("%s reacted to your post #%d.", actor, *notification.PostID)
+	case entity.NotificationTypeFollow:
+		return "You have a new follower", fmt.Sprintf("%s is now following you.", actor)
+	case entity.NotificationTypeInviteUsed:
+		return "Your invite was used", fmt.Sprintf("%s has registered with your invite.", actor)
+	}
+	return "New notification", fmt.Sprintf("%s: %s", notification.Type, actor)
+}
@@ -5,37 +5,72 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
+	"time"
 
+	"github.com/HardDie/blog_engine/internal/broker"
 	"github.com/HardDie/blog_engine/internal/dto"
 	"github.com/HardDie/blog_engine/internal/entity"
 	repositoryPost "github.com/HardDie/blog_engine/internal/repository/sqlite/post"
+	repositoryReaction "github.com/HardDie/blog_engine/internal/repository/sqlite/reaction"
+	repositoryReadingList "github.com/HardDie/blog_engine/internal/repository/sqlite/readinglist"
+	repositorySeries "github.com/HardDie/blog_engine/internal/repository/sqlite/series"
 	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+	serviceWebhook "github.com/HardDie/blog_engine/internal/service/webhook"
+	"github.com/HardDie/blog_engine/internal/tracing"
 	"github.com/HardDie/blog_engine/internal/utils"
 )
 
 type IPost interface {
-	Feed(ctx context.Context, req *dto.FeedPostDTO) ([]*entity.Post, int64, error)
-	PublicGet(ctx context.Context, id int64) (*entity.Post, error)
+	Feed(ctx context.Context, req *dto.FeedPostDTO, userID int64) ([]*entity.Post, int64, error)
+	PublicGet(ctx context.Context, id, userID int64) (*entity.Post, error)
+	PublicMeta(ctx context.Context, id int64) ([]*entity.MetaTag, error)
+
+	FeedFollowing(ctx context.Context, req *dto.FeedFollowingPostDTO, userID int64) ([]*entity.Post, int64, error)
 
 	Create(ctx context.Context, req *dto.CreatePostDTO, userID int64) (*entity.Post, error)
 	Edit(ctx context.Context, req *dto.EditPostDTO, userID int64) (*entity.Post, error)
+	Delete(ctx context.Context, id, userID int64) error
 	List(ctx context.Context, req *dto.ListPostDTO, userID int64) ([]*entity.Post, int64, error)
 }
 
 type Post struct {
-	postRepository repositoryPost.Querier
-	userRepository repositoryUser.Querier
+	postRepository        repositoryPost.Querier
+	userRepository        repositoryUser.Querier
+	seriesRepository      repositorySeries.Querier
+	reactionRepository    repositoryReaction.Querier
+	readingListRepository repositoryReadingList.Querier
+
+	webhookService serviceWebhook.IWebhook
+
+	broker broker.IBroker
 }
 
-func New(post repositoryPost.Querier, user repositoryUser.Querier) *Post {
+func New(
+	post repositoryPost.Querier,
+	user repositoryUser.Querier,
+	series repositorySeries.Querier,
+	reaction repositoryReaction.Querier,
+	readingList repositoryReadingList.Querier,
+	webhook serviceWebhook.IWebhook,
+	broker broker.IBroker,
+) *Post {
 	return &Post{
-		postRepository: post,
-		userRepository: user,
+		webhookService:        webhook,
+		broker:                broker,
+		postRepository:        post,
+		userRepository:        user,
+		seriesRepository:      series,
+		reactionRepository:    reaction,
+		readingListRepository: readingList,
 	}
 }
 
-func (p *Post) Feed(ctx context.Context, req *dto.FeedPostDTO) ([]*entity.Post, int64, error) {
+func (p *Post) Feed(ctx context.Context, req *dto.FeedPostDTO, userID int64) ([]*entity.Post, int64, error) {
+	ctx, span := tracing.Start(ctx, "Post.Feed")
+	defer span.End()
+
 	limit, offset := utils.GetPagination(req.Limit, req.Page)
 	resp, err := p.postRepository.List(ctx, repositoryPost.ListParams{
 		Limit:                limit,
@@ -50,46 +85,20 @@ func (p *Post) Feed(ctx context.Context, req *dto.FeedPostDTO) ([]*entity.Post,
 		return []*entity.Post{}, 0, nil
 	}
 
-	posts := make([]*entity.Post, 0, len(resp))
-	users := make(map[int64]*entity.User)
+	rows := make([]*repositoryPost.Post, 0, len(resp))
 	for _, el := range resp {
-		post := &entity.Post{
-			ID:          el.Post.ID,
-			UserID:      el.Post.UserID,
-			Title:       el.Post.Title,
-			Short:       el.Post.Short,
-			Body:        el.Post.Body,
-			Tags:        strings.Split(el.Post.Tags.String, ";"),
-			IsPublished: el.Post.IsPublished,
-			CreatedAt:   el.Post.CreatedAt,
-			UpdatedAt:   el.Post.UpdatedAt,
-		}
-
-		user, ok := users[post.UserID]
-		if ok {
-			post.User = user
-			posts = append(posts, post)
-			continue
-		}
-
-		resp, err := p.userRepository.GetByIDPublic(ctx, post.UserID)
-		if err != nil {
-			return nil, 0, fmt.Errorf("Post.Feed() user.GetByID: %w", err)
-		}
-		user = &entity.User{
-			ID:              resp.ID,
-			DisplayedName:   resp.DisplayedName,
-			InvitedByUserID: resp.InvitedByUser,
-			CreatedAt:       resp.CreatedAt,
-			UpdatedAt:       resp.UpdatedAt,
-		}
-		users[post.UserID] = user
-		post.User = user
-		posts = append(posts, post)
+		rows = append(rows, &el.Post)
+	}
+	posts, err := p.feedPosts(ctx, rows, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Post.Feed() %w", err)
 	}
 	return posts, resp[0].Count, nil
 }
-func (p *Post) PublicGet(ctx context.Context, id int64) (*entity.Post, error) {
+func (p *Post) PublicGet(ctx context.Context, id, userID int64) (*entity.Post, error) {
+	ctx, span := tracing.Start(ctx, "Post.PublicGet")
+	defer span.End()
+
 	resp, err := p.postRepository.GetByID(ctx, repositoryPost.GetByIDParams{
 		ID:     id,
 		UserID: utils.NewSqlInt64(nil),
@@ -102,15 +111,26 @@ func (p *Post) PublicGet(ctx context.Context, id int64) (*entity.Post, error) {
 		return nil, fmt.Errorf("Post.PublicGet() GetByID: %w", err)
 	}
 	post := &entity.Post{
-		ID:          resp.ID,
-		UserID:      resp.UserID,
-		Title:       resp.Title,
-		Short:       resp.Short,
-		Body:        resp.Body,
-		Tags:        strings.Split(resp.Tags.String, ";"),
-		IsPublished: resp.IsPublished,
-		CreatedAt:   resp.CreatedAt,
-		UpdatedAt:   resp.UpdatedAt,
+		ID:                 resp.ID,
+		UserID:             resp.UserID,
+		Title:              resp.Title,
+		Short:              resp.Short,
+		Body:               resp.Body,
+		Tags:               strings.Split(resp.Tags.String, ";"),
+		IsPublished:        resp.IsPublished,
+		CoverImage:         utils.SqlStringToString(resp.CoverImage),
+		MetaDescription:    utils.SqlStringToString(resp.MetaDescription),
+		CanonicalURL:       utils.SqlStringToString(resp.CanonicalUrl),
+		NoIndex:            resp.Noindex,
+		OGTitle:            utils.SqlStringToString(resp.OgTitle),
+		OGDescription:      utils.SqlStringToString(resp.OgDescription),
+		OGImage:            utils.SqlStringToString(resp.OgImage),
+		TwitterCard:        utils.SqlStringToString(resp.TwitterCard),
+		TwitterTitle:       utils.SqlStringToString(resp.TwitterTitle),
+		TwitterDescription: utils.SqlStringToString(resp.TwitterDescription),
+		TwitterImage:       utils.SqlStringToString(resp.TwitterImage),
+		CreatedAt:          resp.CreatedAt,
+		UpdatedAt:          resp.UpdatedAt,
 	}
 
 	respUser, err := p.userRepository.GetByIDPublic(ctx, post.UserID)
@@ -125,9 +145,149 @@ func (p *Post) PublicGet(ctx context.Context, id int64) (*entity.Post, error) {
 		UpdatedAt:       respUser.UpdatedAt,
 	}
 	post.User = user
+
+	// Navigation inside the series
+	respSeries, err := p.seriesRepository.GetByPostID(ctx, post.ID)
+	switch {
+	case err == nil:
+		post.Series, err = p.postSeries(ctx, post.ID, respSeries)
+		if err != nil {
+			return nil, fmt.Errorf("Post.PublicGet() %w", err)
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		// continue
+	default:
+		return nil, fmt.Errorf("Post.PublicGet() series.GetByPostID: %w", err)
+	}
+
+	err = p.fillReactions(ctx, []*entity.Post{post}, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Post.PublicGet() %w", err)
+	}
 	return post, nil
 }
+func (p *Post) PublicMeta(ctx context.Context, id int64) ([]*entity.MetaTag, error) {
+	ctx, span := tracing.Start(ctx, "Post.PublicMeta")
+	defer span.End()
+
+	post, err := p.PublicGet(ctx, id, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Post.PublicMeta() PublicGet: %w", err)
+	}
+
+	// Fallbacks: the dedicated SEO/OpenGraph/Twitter fields win, otherwise the
+	// regular post fields are used
+	description := post.Short
+	if post.MetaDescription != nil {
+		description = *post.MetaDescription
+	}
+	ogTitle := post.Title
+	if post.OGTitle != nil {
+		ogTitle = *post.OGTitle
+	}
+	ogDescription := description
+	if post.OGDescription != nil {
+		ogDescription = *post.OGDescription
+	}
+	var ogImage string
+	switch {
+	case post.OGImage != nil:
+		ogImage = *post.OGImage
+	case post.CoverImage != nil:
+		ogImage = *post.CoverImage
+	}
+	twitterCard := "summary"
+	if ogImage != "" {
+		twitterCard = "summary_large_image"
+	}
+	if post.TwitterCard != nil {
+		twitterCard = *post.TwitterCard
+	}
+	twitterTitle := ogTitle
+	if post.TwitterTitle != nil {
+		twitterTitle = *post.TwitterTitle
+	}
+	twitterDescription := ogDescription
+	if post.TwitterDescription != nil {
+		twitterDescription = *post.TwitterDescription
+	}
+	twitterImage := ogImage
+	if post.TwitterImage != nil {
+		twitterImage = *post.TwitterImage
+	}
+
+	tags := []*entity.MetaTag{
+		{Name: "description", Content: description},
+	}
+	if post.NoIndex {
+		tags = append(tags, &entity.MetaTag{Name: "robots", Content: "noindex"})
+	}
+	if post.CanonicalURL != nil {
+		tags = append(tags, &entity.MetaTag{Rel: "canonical", Content: *post.CanonicalURL})
+	}
+	tags = append(tags,
+		&entity.MetaTag{Property: "og:type", Content: "article"},
+		&entity.MetaTag{Property: "og:title", Content: ogTitle},
+		&entity.MetaTag{Property: "og:description", Content: ogDescription},
+	)
+	if post.CanonicalURL != nil {
+		tags = append(tags, &entity.MetaTag{Property: "og:url", Content: *post.CanonicalURL})
+	}
+	if ogImage != "" {
+		tags = append(tags, &entity.MetaTag{Property: "og:image", Content: ogImage})
+	}
+	tags = append(tags,
+		&entity.MetaTag{Property: "article:published_time", Content: post.CreatedAt.Format(time.RFC3339)},
+		&entity.MetaTag{Property: "article:modified_time", Content: post.UpdatedAt.Format(time.RFC3339)},
+		&entity.MetaTag{Property: "article:author", Content: post.User.DisplayedName},
+	)
+	for _, tag := range post.Tags {
+		if tag == "" {
+			continue
+		}
+		tags = append(tags, &entity.MetaTag{Property: "article:tag", Content: tag})
+	}
+	tags = append(tags,
+		&entity.MetaTag{Name: "twitter:card", Content: twitterCard},
+		&entity.MetaTag{Name: "twitter:title", Content: twitterTitle},
+		&entity.MetaTag{Name: "twitter:description", Content: twitterDescription},
+	)
+	if twitterImage != "" {
+		tags = append(tags, &entity.MetaTag{Name: "twitter:image", Content: twitterImage})
+	}
+	return tags, nil
+}
+func (p *Post) FeedFollowing(ctx context.Context, req *dto.FeedFollowingPostDTO, userID int64) ([]*entity.Post, int64, error) {
+	ctx, span := tracing.Start(ctx, "Post.FeedFollowing")
+	defer span.End()
+
+	limit, offset := utils.GetPagination(req.Limit, req.Page)
+	resp, err := p.postRepository.ListFollowing(ctx, repositoryPost.ListFollowingParams{
+		FollowerID: userID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("Post.FeedFollowing() ListFollowing: %w", err)
+	}
+	if len(resp) == 0 {
+		return []*entity.Post{}, 0, nil
+	}
+
+	rows := make([]*repositoryPost.Post, 0, len(resp))
+	for _, el := range resp {
+		rows = append(rows, &el.Post)
+	}
+	posts, err := p.feedPosts(ctx, rows, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Post.FeedFollowing() %w", err)
+	}
+	return posts, resp[0].Count, nil
+}
 func (p *Post) Create(ctx context.Context, req *dto.CreatePostDTO, userID int64) (*entity.Post, error) {
+	ctx, span := tracing.Start(ctx, "Post.Create")
+	defer span.End()
+
 	resp, err := p.postRepository.Create(ctx, repositoryPost.CreateParams{
 		UserID: userID,
 		Title:  req.Title,
@@ -137,51 +297,171 @@ func (p *Post) Create(ctx context.Context, req *dto.CreatePostDTO, userID int64)
 			String: strings.Join(req.Tags, ";"),
 			Valid:  true,
 		},
-		IsPublished: req.IsPublished,
+		IsPublished:        req.IsPublished,
+		CoverImage:         utils.NewSqlString(req.CoverImage),
+		MetaDescription:    utils.NewSqlString(req.MetaDescription),
+		CanonicalUrl:       utils.NewSqlString(req.CanonicalURL),
+		Noindex:            req.NoIndex,
+		OgTitle:            utils.NewSqlString(req.OGTitle),
+		OgDescription:      utils.NewSqlString(req.OGDescription),
+		OgImage:            utils.NewSqlString(req.OGImage),
+		TwitterCard:        utils.NewSqlString(req.TwitterCard),
+		TwitterTitle:       utils.NewSqlString(req.TwitterTitle),
+		TwitterDescription: utils.NewSqlString(req.TwitterDescription),
+		TwitterImage:       utils.NewSqlString(req.TwitterImage),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("Post.Create() Create: %w", err)
 	}
 	post := &entity.Post{
-		ID:          resp.ID,
-		UserID:      resp.UserID,
-		Title:       resp.Title,
-		Short:       resp.Short,
-		Body:        resp.Body,
-		Tags:        strings.Split(resp.Tags.String, ";"),
-		IsPublished: resp.IsPublished,
-		CreatedAt:   resp.CreatedAt,
-		UpdatedAt:   resp.UpdatedAt,
+		ID:                 resp.ID,
+		UserID:             resp.UserID,
+		Title:              resp.Title,
+		Short:              resp.Short,
+		Body:               resp.Body,
+		Tags:               strings.Split(resp.Tags.String, ";"),
+		IsPublished:        resp.IsPublished,
+		CoverImage:         utils.SqlStringToString(resp.CoverImage),
+		MetaDescription:    utils.SqlStringToString(resp.MetaDescription),
+		CanonicalURL:       utils.SqlStringToString(resp.CanonicalUrl),
+		NoIndex:            resp.Noindex,
+		OGTitle:            utils.SqlStringToString(resp.OgTitle),
+		OGDescription:      utils.SqlStringToString(resp.OgDescription),
+		OGImage:            utils.SqlStringToString(resp.OgImage),
+		TwitterCard:        utils.SqlStringToString(resp.TwitterCard),
+		TwitterTitle:       utils.SqlStringToString(resp.TwitterTitle),
+		TwitterDescription: utils.SqlStringToString(resp.TwitterDescription),
+		TwitterImage:       utils.SqlStringToString(resp.TwitterImage),
+		CreatedAt:          resp.CreatedAt,
+		UpdatedAt:          resp.UpdatedAt,
+	}
+	if post.IsPublished {
+		p.publish(broker.EventPostPublished, post)
+		p.dispatch(ctx, entity.WebhookEventPostPublished, post)
 	}
 	return post, nil
 }
 func (p *Post) Edit(ctx context.Context, req *dto.EditPostDTO, userID int64) (*entity.Post, error) {
+	ctx, span := tracing.Start(ctx, "Post.Edit")
+	defer span.End()
+
+	// Needed to tell the publication of a draft from the update of a published post
+	prev, err := p.postRepository.GetByID(ctx, repositoryPost.GetByIDParams{
+		ID:     req.ID,
+		UserID: utils.NewSqlInt64(&userID),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrorPostNotFound
+		}
+		return nil, fmt.Errorf("Post.Edit() GetByID: %w", err)
+	}
+
 	resp, err := p.postRepository.Edit(ctx, repositoryPost.EditParams{
 		Title: req.Title,
 		Short: req.Short,
 		Body:  req.Body,
 		//Tags        sql.NullString `json:"tags"`
-		IsPublished: req.IsPublished,
-		ID:          req.ID,
-		UserID:      userID,
+		IsPublished:        req.IsPublished,
+		CoverImage:         utils.NewSqlString(req.CoverImage),
+		MetaDescription:    utils.NewSqlString(req.MetaDescription),
+		CanonicalUrl:       utils.NewSqlString(req.CanonicalURL),
+		Noindex:            req.NoIndex,
+		OgTitle:            utils.NewSqlString(req.OGTitle),
+		OgDescription:      utils.NewSqlString(req.OGDescription),
+		OgImage:            utils.NewSqlString(req.OGImage),
+		TwitterCard:        utils.NewSqlString(req.TwitterCard),
+		TwitterTitle:       utils.NewSqlString(req.TwitterTitle),
+		TwitterDescription: utils.NewSqlString(req.TwitterDescription),
+		TwitterImage:       utils.NewSqlString(req.TwitterImage),
+		ID:                 req.ID,
+		UserID:             userID,
 	})
 	if err != nil {
+		switch {
+		// The post is deleted after the check above
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrorPostNotFound
+		}
 		return nil, fmt.Errorf("Post.Edit() Edit: %w", err)
 	}
 	post := &entity.Post{
+		ID:                 resp.ID,
+		UserID:             resp.UserID,
+		Title:              resp.Title,
+		Short:              resp.Short,
+		Body:               resp.Body,
+		Tags:               strings.Split(resp.Tags.String, ";"),
+		IsPublished:        resp.IsPublished,
+		CoverImage:         utils.SqlStringToString(resp.CoverImage),
+		MetaDescription:    utils.SqlStringToString(resp.MetaDescription),
+		CanonicalURL:       utils.SqlStringToString(resp.CanonicalUrl),
+		NoIndex:            resp.Noindex,
+		OGTitle:            utils.SqlStringToString(resp.OgTitle),
+		OGDescription:      utils.SqlStringToString(resp.OgDescription),
+		OGImage:            utils.SqlStringToString(resp.OgImage),
+		TwitterCard:        utils.SqlStringToString(resp.TwitterCard),
+		TwitterTitle:       utils.SqlStringToString(resp.TwitterTitle),
+		TwitterDescription: utils.SqlStringToString(resp.TwitterDescription),
+		TwitterImage:       utils.SqlStringToString(resp.TwitterImage),
+		CreatedAt:          resp.CreatedAt,
+		UpdatedAt:          resp.UpdatedAt,
+	}
+	switch {
+	case post.IsPublished && prev.IsPublished:
+		p.publish(broker.EventPostUpdated, post)
+		p.dispatch(ctx, entity.WebhookEventPostUpdated, post)
+	case post.IsPublished:
+		p.publish(broker.EventPostPublished, post)
+		p.dispatch(ctx, entity.WebhookEventPostPublished, post)
+	case prev.IsPublished:
+		// The post was hidden, mirrors have to know about it
+		p.dispatch(ctx, entity.WebhookEventPostUpdated, post)
+	}
+	return post, nil
+}
+func (p *Post) Delete(ctx context.Context, id, userID int64) error {
+	ctx, span := tracing.Start(ctx, "Post.Delete")
+	defer span.End()
+
+	resp, err := p.postRepository.GetByID(ctx, repositoryPost.GetByIDParams{
+		ID:     id,
+		UserID: utils.NewSqlInt64(&userID),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrorPostNotFound
+		}
+		return fmt.Errorf("Post.Delete() GetByID: %w", err)
+	}
+
+	rows, err := p.postRepository.Delete(ctx, repositoryPost.DeleteParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("Post.Delete() Delete: %w", err)
+	}
+	if rows == 0 {
+		return ErrorPostNotFound
+	}
+
+	p.dispatch(ctx, entity.WebhookEventPostDeleted, &entity.Post{
 		ID:          resp.ID,
 		UserID:      resp.UserID,
 		Title:       resp.Title,
-		Short:       resp.Short,
-		Body:        resp.Body,
-		Tags:        strings.Split(resp.Tags.String, ";"),
 		IsPublished: resp.IsPublished,
 		CreatedAt:   resp.CreatedAt,
 		UpdatedAt:   resp.UpdatedAt,
-	}
-	return post, nil
+	})
+	return nil
 }
 func (p *Post) List(ctx context.Context, req *dto.ListPostDTO, userID int64) ([]*entity.Post, int64, error) {
+	ctx, span := tracing.Start(ctx, "Post.List")
+	defer span.End()
+
 	limit, offset := utils.GetPagination(req.Limit, req.Page)
 	resp, err := p.postRepository.List(ctx, repositoryPost.ListParams{
 		Limit:                limit,
@@ -201,15 +481,26 @@ func (p *Post) List(ctx context.Context, req *dto.ListPostDTO, userID int64) ([]
 	users := make(map[int64]*entity.User)
 	for _, el := range resp {
 		post := &entity.Post{
-			ID:          el.Post.ID,
-			UserID:      el.Post.UserID,
-			Title:       el.Post.Title,
-			Short:       el.Post.Short,
-			Body:        el.Post.Body,
-			Tags:        strings.Split(el.Post.Tags.String, ";"),
-			IsPublished: el.Post.IsPublished,
-			CreatedAt:   el.Post.CreatedAt,
-			UpdatedAt:   el.Post.UpdatedAt,
+			ID:                 el.Post.ID,
+			UserID:             el.Post.UserID,
+			Title:              el.Post.Title,
+			Short:              el.Post.Short,
+			Body:               el.Post.Body,
+			Tags:               strings.Split(el.Post.Tags.String, ";"),
+			IsPublished:        el.Post.IsPublished,
+			CoverImage:         utils.SqlStringToString(el.Post.CoverImage),
+			MetaDescription:    utils.SqlStringToString(el.Post.MetaDescription),
+			CanonicalURL:       utils.SqlStringToString(el.Post.CanonicalUrl),
+			NoIndex:            el.Post.Noindex,
+			OGTitle:            utils.SqlStringToString(el.Post.OgTitle),
+			OGDescription:      utils.SqlStringToString(el.Post.OgDescription),
+			OGImage:            utils.SqlStringToString(el.Post.OgImage),
+			TwitterCard:        utils.SqlStringToString(el.Post.TwitterCard),
+			TwitterTitle:       utils.SqlStringToString(el.Post.TwitterTitle),
+			TwitterDescription: utils.SqlStringToString(el.Post.TwitterDescription),
+			TwitterImage:       utils.SqlStringToString(el.Post.TwitterImage),
+			CreatedAt:          el.Post.CreatedAt,
+			UpdatedAt:          el.Post.UpdatedAt,
 		}
 
 		user, ok := users[post.UserID]
@@ -234,9 +525,193 @@ func (p *Post) List(ctx context.Context, req *dto.ListPostDTO, userID int64) ([]
 		post.User = user
 		posts = append(posts, post)
 	}
+
+	err = p.fillReactions(ctx, posts, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Post.List() %w", err)
+	}
 	return posts, resp[0].Count, nil
 }
 
+// publish notifies live streams about the post, a failure doesn't affect the request.
+func (p *Post) publish(eventType string, post *entity.Post) {
+	err := p.broker.Publish(eventType, 0, post)
+	if err != nil {
+		slog.Error("Post.publish() can't publish event", "event", eventType, "post_id", post.ID, "error", err)
+	}
+}
+
+// dispatch queues the event for webhooks of the author, a failure doesn't affect the request.
+func (p *Post) dispatch(ctx context.Context, event string, post *entity.Post) {
+	err := p.webhookService.Dispatch(ctx, event, post.UserID, post)
+	if err != nil {
+		slog.ErrorContext(ctx, "Post.dispatch() can't dispatch event", "event", event, "post_id", post.ID, "error", err)
+	}
+}
+
+// feedPosts converts rows of a feed into posts with authors, reactions and bookmarks.
+func (p *Post) feedPosts(ctx context.Context, rows []*repositoryPost.Post, userID int64) ([]*entity.Post, error) {
+	posts := make([]*entity.Post, 0, len(rows))
+	users := make(map[int64]*entity.User)
+	for _, el := range rows {
+		post := &entity.Post{
+			ID:                 el.ID,
+			UserID:             el.UserID,
+			Title:              el.Title,
+			Short:              el.Short,
+			Body:               el.Body,
+			Tags:               strings.Split(el.Tags.String, ";"),
+			IsPublished:        el.IsPublished,
+			CoverImage:         utils.SqlStringToString(el.CoverImage),
+			MetaDescription:    utils.SqlStringToString(el.MetaDescription),
+			CanonicalURL:       utils.SqlStringToString(el.CanonicalUrl),
+			NoIndex:            el.Noindex,
+			OGTitle:            utils.SqlStringToString(el.OgTitle),
+			OGDescription:      utils.SqlStringToString(el.OgDescription),
+			OGImage:            utils.SqlStringToString(el.OgImage),
+			TwitterCard:        utils.SqlStringToString(el.TwitterCard),
+			TwitterTitle:       utils.SqlStringToString(el.TwitterTitle),
+			TwitterDescription: utils.SqlStringToString(el.TwitterDescription),
+			TwitterImage:       utils.SqlStringToString(el.TwitterImage),
+			CreatedAt:          el.CreatedAt,
+			UpdatedAt:          el.UpdatedAt,
+		}
+
+		user, ok := users[post.UserID]
+		if ok {
+			post.User = user
+			posts = append(posts, post)
+			continue
+		}
+
+		resp, err := p.userRepository.GetByIDPublic(ctx, post.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("user.GetByID: %w", err)
+		}
+		user = &entity.User{
+			ID:              resp.ID,
+			DisplayedName:   resp.DisplayedName,
+			InvitedByUserID: resp.InvitedByUser,
+			CreatedAt:       resp.CreatedAt,
+			UpdatedAt:       resp.UpdatedAt,
+		}
+		users[post.UserID] = user
+		post.User = user
+		posts = append(posts, post)
+	}
+
+	err := p.fillReactions(ctx, posts, userID)
+	if err != nil {
+		return nil, err
+	}
+	err = p.fillSaved(ctx, posts, userID)
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// postSeries builds the navigation of the post inside the series, only published parts are taken into account.
+func (p *Post) postSeries(ctx context.Context, postID int64, series *repositorySeries.Series) (*entity.PostSeries, error) {
+	parts, err := p.seriesRepository.ListPosts(ctx, repositorySeries.ListPostsParams{
+		SeriesID:             series.ID,
+		DisplayOnlyPublished: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("series.ListPosts: %w", err)
+	}
+
+	res := &entity.PostSeries{
+		ID:    series.ID,
+		Title: series.Title,
+		Total: len(parts),
+	}
+	for i, el := range parts {
+		if el.Post.ID != postID {
+			continue
+		}
+		res.Position = i + 1
+		if i > 0 {
+			res.Prev = &entity.PostLink{
+				ID:    parts[i-1].Post.ID,
+				Title: parts[i-1].Post.Title,
+			}
+		}
+		if i < len(parts)-1 {
+			res.Next = &entity.PostLink{
+				ID:    parts[i+1].Post.ID,
+				Title: parts[i+1].Post.Title,
+			}
+		}
+		break
+	}
+	return res, nil
+}
+
+// fillReactions sets the reaction counters of the posts and the reactions of the user, if the user is known.
+func (p *Post) fillReactions(ctx context.Context, posts []*entity.Post, userID int64) error {
+	postIDs := make([]int64, 0, len(posts))
+	postByID := make(map[int64]*entity.Post, len(posts))
+	for _, post := range posts {
+		post.Reactions = make(map[string]int64)
+		post.UserReactions = []string{}
+		postIDs = append(postIDs, post.ID)
+		postByID[post.ID] = post
+	}
+
+	counts, err := p.reactionRepository.ListCounts(ctx, postIDs)
+	if err != nil {
+		return fmt.Errorf("reaction.ListCounts: %w", err)
+	}
+	for _, el := range counts {
+		postByID[el.PostID].Reactions[el.Reaction] = el.Count
+	}
+
+	if userID == 0 {
+		return nil
+	}
+	userReactions, err := p.reactionRepository.ListByUser(ctx, repositoryReaction.ListByUserParams{
+		UserID:  userID,
+		PostIds: postIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("reaction.ListByUser: %w", err)
+	}
+	for _, el := range userReactions {
+		post := postByID[el.PostID]
+		post.UserReactions = append(post.UserReactions, el.Reaction)
+	}
+	return nil
+}
+
+// fillSaved marks the posts that the user has saved to any of the reading lists.
+func (p *Post) fillSaved(ctx context.Context, posts []*entity.Post, userID int64) error {
+	if userID == 0 {
+		return nil
+	}
+
+	postIDs := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	saved, err := p.readingListRepository.ListSavedPostIDs(ctx, repositoryReadingList.ListSavedPostIDsParams{
+		UserID:  userID,
+		PostIds: postIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("readingList.ListSavedPostIDs: %w", err)
+	}
+
+	isSaved := make(map[int64]bool, len(saved))
+	for _, postID := range saved {
+		isSaved[postID] = true
+	}
+	for _, post := range posts {
+		post.IsSaved = isSaved[post.ID]
+	}
+	return nil
+}
+
 var (
 	ErrorPostNotFound = errors.New("post not found")
 )
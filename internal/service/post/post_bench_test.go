@@ -0,0 +1,188 @@
+package post
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/broker"
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/db"
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/logger"
+	"github.com/HardDie/blog_engine/internal/migration"
+	repositoryPost "github.com/HardDie/blog_engine/internal/repository/sqlite/post"
+	repositoryReaction "github.com/HardDie/blog_engine/internal/repository/sqlite/reaction"
+	repositoryReadingList "github.com/HardDie/blog_engine/internal/repository/sqlite/readinglist"
+	repositorySeries "github.com/HardDie/blog_engine/internal/repository/sqlite/series"
+	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+	repositoryWebhook "github.com/HardDie/blog_engine/internal/repository/sqlite/webhook"
+	serviceWebhook "github.com/HardDie/blog_engine/internal/service/webhook"
+)
+
+const (
+	benchPosts = 500
+	// writeInterval of the background writer, the fixed rate keeps the size of the table comparable between setups
+	writeInterval = 2 * time.Millisecond
+)
+
+// BenchmarkFeed reads the first page of the feed in parallel, while a background writer creates posts.
+// The legacy setup is the single pool in the rollback journal mode, which was used before the WAL.
+//
+//	go test ./internal/service/post -run '^$' -bench Feed -cpu 1,4,8
+func BenchmarkFeed(b *testing.B) {
+	for _, bc := range []struct {
+		name     string
+		open     func(b *testing.B, path string) *db.DB
+		prepared bool
+	}{
+		{"legacy", openLegacy, false},
+		{"wal", openWAL, false},
+		{"wal_prepared", openWAL, true},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			s, userID := setupBench(b, bc.open(b, filepath.Join(b.TempDir(), "blog.db")), bc.prepared)
+			ctx := context.Background()
+
+			// Background writer
+			var writes, writeErrors atomic.Int64
+			stop := make(chan struct{})
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ticker := time.NewTicker(writeInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-stop:
+						return
+					case <-ticker.C:
+					}
+					_, err := s.Create(ctx, &dto.CreatePostDTO{Title: "title", Short: "short", Body: "body", IsPublished: true}, userID)
+					if err != nil {
+						writeErrors.Add(1)
+						continue
+					}
+					writes.Add(1)
+				}
+			}()
+
+			var readErrors atomic.Int64
+			start := time.Now()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_, _, err := s.Feed(ctx, &dto.FeedPostDTO{Limit: 20}, userID)
+					if err != nil {
+						readErrors.Add(1)
+					}
+				}
+			})
+			b.StopTimer()
+			close(stop)
+			wg.Wait()
+
+			elapsed := time.Since(start).Seconds()
+			b.ReportMetric(float64(b.N)/elapsed, "feeds/s")
+			b.ReportMetric(float64(writes.Load())/elapsed, "writes/s")
+			b.ReportMetric(float64(readErrors.Load()+writeErrors.Load()), "errors")
+		})
+	}
+}
+
+func openLegacy(b *testing.B, path string) *db.DB {
+	b.Helper()
+
+	conn, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		b.Fatal(err)
+	}
+	return &db.DB{
+		Driver: db.DriverSQLite,
+		Write:  conn,
+		Read:   conn,
+	}
+}
+func openWAL(b *testing.B, path string) *db.DB {
+	b.Helper()
+
+	database, err := db.Get(path, 4, 5000)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return database
+}
+
+func setupBench(b *testing.B, database *db.DB, prepared bool) (*Post, int64) {
+	b.Helper()
+	ctx := context.Background()
+
+	b.Cleanup(func() { database.Close() })
+	// Migration logs would be mixed with the results
+	err := logger.Init("error", "text", io.Discard)
+	if err != nil {
+		b.Fatal(err)
+	}
+	err = migration.NewMigrate(database).Up()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	user, err := repositoryUser.New(database).Create(ctx, repositoryUser.CreateParams{
+		Username:      "alice",
+		DisplayedName: "Alice",
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	webhook := serviceWebhook.New(&config.Config{}, repositoryWebhook.New(database), nil)
+	s := New(
+		repositoryPost.New(database),
+		repositoryUser.New(database),
+		repositorySeries.New(database),
+		repositoryReaction.New(database),
+		repositoryReadingList.New(database),
+		webhook,
+		broker.New(1),
+	)
+	if prepared {
+		s = New(
+			must(repositoryPost.Prepare(ctx, database)),
+			must(repositoryUser.Prepare(ctx, database)),
+			must(repositorySeries.Prepare(ctx, database)),
+			must(repositoryReaction.Prepare(ctx, database)),
+			must(repositoryReadingList.Prepare(ctx, database)),
+			webhook,
+			broker.New(1),
+		)
+	}
+	for i := 0; i < benchPosts; i++ {
+		_, err = s.Create(ctx, &dto.CreatePostDTO{
+			Title:       fmt.Sprintf("Post %d", i),
+			Short:       "short",
+			Body:        "body",
+			Tags:        []string{"go", "sqlite"},
+			IsPublished: true,
+		}, user.ID)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	return s, user.ID
+}
+
+// must takes the results of Prepare directly, the setup can't continue without the repository anyway.
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
@@ -0,0 +1,170 @@
+package reaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+	repositoryPost "github.com/HardDie/blog_engine/internal/repository/sqlite/post"
+	repositoryReaction "github.com/HardDie/blog_engine/internal/repository/sqlite/reaction"
+	serviceNotification "github.com/HardDie/blog_engine/internal/service/notification"
+	"github.com/HardDie/blog_engine/internal/tracing"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+type IReaction interface {
+	Available(ctx context.Context) []string
+
+	Add(ctx context.Context, req *dto.ReactPostDTO, userID int64) (*entity.PostReactions, error)
+	Remove(ctx context.Context, req *dto.ReactPostDTO, userID int64) (*entity.PostReactions, error)
+}
+
+type Reaction struct {
+	reactionRepository repositoryReaction.Querier
+	postRepository     repositoryPost.Querier
+
+	notificationService serviceNotification.INotification
+
+	cfg *config.Config
+}
+
+func New(
+	cfg *config.Config,
+	reaction repositoryReaction.Querier,
+	post repositoryPost.Querier,
+	notification serviceNotification.INotification,
+) *Reaction {
+	return &Reaction{
+		cfg:                 cfg,
+		reactionRepository:  reaction,
+		postRepository:      post,
+		notificationService: notification,
+	}
+}
+
+func (s *Reaction) Available(_ context.Context) []string {
+	return s.cfg.Reactions
+}
+func (s *Reaction) Add(ctx context.Context, req *dto.ReactPostDTO, userID int64) (*entity.PostReactions, error) {
+	ctx, span := tracing.Start(ctx, "Reaction.Add")
+	defer span.End()
+
+	post, err := s.check(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("Reaction.Add() %w", err)
+	}
+
+	// A repeated reaction of the same type is ignored
+	rows, err := s.reactionRepository.Add(ctx, repositoryReaction.AddParams{
+		PostID:   req.ID,
+		UserID:   userID,
+		Reaction: req.Reaction,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Reaction.Add() Add: %w", err)
+	}
+	if rows > 0 {
+		err = s.notificationService.Notify(ctx, &entity.Notification{
+			UserID:  post.UserID,
+			Type:    entity.NotificationTypeReaction,
+			ActorID: userID,
+			PostID:  &post.ID,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Reaction.Add() can't notify about reaction", "post_id", post.ID, "error", err)
+		}
+	}
+
+	res, err := s.get(ctx, req.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Reaction.Add() %w", err)
+	}
+	return res, nil
+}
+func (s *Reaction) Remove(ctx context.Context, req *dto.ReactPostDTO, userID int64) (*entity.PostReactions, error) {
+	ctx, span := tracing.Start(ctx, "Reaction.Remove")
+	defer span.End()
+
+	_, err := s.check(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("Reaction.Remove() %w", err)
+	}
+
+	_, err = s.reactionRepository.Remove(ctx, repositoryReaction.RemoveParams{
+		PostID:   req.ID,
+		UserID:   userID,
+		Reaction: req.Reaction,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Reaction.Remove() Remove: %w", err)
+	}
+
+	res, err := s.get(ctx, req.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Reaction.Remove() %w", err)
+	}
+	return res, nil
+}
+
+// check verifies that the reaction is allowed and the post is published.
+func (s *Reaction) check(ctx context.Context, req *dto.ReactPostDTO) (*repositoryPost.Post, error) {
+	isAvailable := false
+	for _, reaction := range s.cfg.Reactions {
+		if reaction == req.Reaction {
+			isAvailable = true
+			break
+		}
+	}
+	if !isAvailable {
+		return nil, ErrorUnknownReaction
+	}
+
+	post, err := s.postRepository.GetByID(ctx, repositoryPost.GetByIDParams{
+		ID:     req.ID,
+		UserID: utils.NewSqlInt64(nil),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrorPostNotFound
+		}
+		return nil, fmt.Errorf("post.GetByID: %w", err)
+	}
+	return post, nil
+}
+func (s *Reaction) get(ctx context.Context, postID, userID int64) (*entity.PostReactions, error) {
+	counts, err := s.reactionRepository.ListCounts(ctx, []int64{postID})
+	if err != nil {
+		return nil, fmt.Errorf("ListCounts: %w", err)
+	}
+	userReactions, err := s.reactionRepository.ListByUser(ctx, repositoryReaction.ListByUserParams{
+		UserID:  userID,
+		PostIds: []int64{postID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListByUser: %w", err)
+	}
+
+	res := &entity.PostReactions{
+		PostID:        postID,
+		Reactions:     make(map[string]int64, len(counts)),
+		UserReactions: make([]string, 0, len(userReactions)),
+	}
+	for _, el := range counts {
+		res.Reactions[el.Reaction] = el.Count
+	}
+	for _, el := range userReactions {
+		res.UserReactions = append(res.UserReactions, el.Reaction)
+	}
+	return res, nil
+}
+
+var (
+	ErrorUnknownReaction = errors.New("unknown reaction")
+	ErrorPostNotFound    = errors.New("post not found")
+)
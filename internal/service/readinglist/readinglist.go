@@ -0,0 +1,339 @@
+package readinglist
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+	repositoryPost "github.com/HardDie/blog_engine/internal/repository/sqlite/post"
+	repositoryReadingList "github.com/HardDie/blog_engine/internal/repository/sqlite/readinglist"
+	"github.com/HardDie/blog_engine/internal/tracing"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+type IReadingList interface {
+	PublicGet(ctx context.Context, id, userID int64) (*entity.ReadingList, error)
+
+	List(ctx context.Context, req *dto.ListReadingListDTO, userID int64) ([]*entity.ReadingList, int64, error)
+	Create(ctx context.Context, req *dto.CreateReadingListDTO, userID int64) (*entity.ReadingList, error)
+	Edit(ctx context.Context, req *dto.EditReadingListDTO, userID int64) (*entity.ReadingList, error)
+	Delete(ctx context.Context, id, userID int64) error
+	AddItem(ctx context.Context, req *dto.AddReadingListItemDTO, userID int64) (*entity.ReadingListItem, error)
+	RemoveItem(ctx context.Context, req *dto.RemoveReadingListItemDTO, userID int64) error
+	Reorder(ctx context.Context, req *dto.ReorderReadingListDTO, userID int64) (*entity.ReadingList, error)
+}
+
+type ReadingList struct {
+	readingListRepository repositoryReadingList.Querier
+	postRepository        repositoryPost.Querier
+}
+
+func New(readingList repositoryReadingList.Querier, post repositoryPost.Querier) *ReadingList {
+	return &ReadingList{
+		readingListRepository: readingList,
+		postRepository:        post,
+	}
+}
+
+func (s *ReadingList) PublicGet(ctx context.Context, id, userID int64) (*entity.ReadingList, error) {
+	ctx, span := tracing.Start(ctx, "ReadingList.PublicGet")
+	defer span.End()
+
+	resp, err := s.readingListRepository.GetByID(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrorReadingListNotFound
+		}
+		return nil, fmt.Errorf("ReadingList.PublicGet() GetByID: %w", err)
+	}
+	// Private lists are visible only to the owner
+	if !resp.IsPublic && resp.UserID != userID {
+		return nil, ErrorReadingListNotFound
+	}
+	list := &entity.ReadingList{
+		ID:        resp.ID,
+		UserID:    resp.UserID,
+		Title:     resp.Title,
+		IsPublic:  resp.IsPublic,
+		CreatedAt: resp.CreatedAt,
+		UpdatedAt: resp.UpdatedAt,
+	}
+
+	list.Items, err = s.listItems(ctx, list.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ReadingList.PublicGet() %w", err)
+	}
+	list.ItemsCount = int64(len(list.Items))
+	return list, nil
+}
+func (s *ReadingList) List(ctx context.Context, req *dto.ListReadingListDTO, userID int64) ([]*entity.ReadingList, int64, error) {
+	ctx, span := tracing.Start(ctx, "ReadingList.List")
+	defer span.End()
+
+	limit, offset := utils.GetPagination(req.Limit, req.Page)
+	resp, err := s.readingListRepository.ListByUser(ctx, repositoryReadingList.ListByUserParams{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("ReadingList.List() ListByUser: %w", err)
+	}
+	if len(resp) == 0 {
+		return []*entity.ReadingList{}, 0, nil
+	}
+
+	lists := make([]*entity.ReadingList, 0, len(resp))
+	for _, el := range resp {
+		lists = append(lists, &entity.ReadingList{
+			ID:         el.ReadingList.ID,
+			UserID:     el.ReadingList.UserID,
+			Title:      el.ReadingList.Title,
+			IsPublic:   el.ReadingList.IsPublic,
+			ItemsCount: el.ItemsCount,
+			CreatedAt:  el.ReadingList.CreatedAt,
+			UpdatedAt:  el.ReadingList.UpdatedAt,
+		})
+	}
+	return lists, resp[0].Count, nil
+}
+func (s *ReadingList) Create(ctx context.Context, req *dto.CreateReadingListDTO, userID int64) (*entity.ReadingList, error) {
+	ctx, span := tracing.Start(ctx, "ReadingList.Create")
+	defer span.End()
+
+	resp, err := s.readingListRepository.Create(ctx, repositoryReadingList.CreateParams{
+		UserID:   userID,
+		Title:    req.Title,
+		IsPublic: req.IsPublic,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ReadingList.Create() Create: %w", err)
+	}
+	list := &entity.ReadingList{
+		ID:        resp.ID,
+		UserID:    resp.UserID,
+		Title:     resp.Title,
+		IsPublic:  resp.IsPublic,
+		CreatedAt: resp.CreatedAt,
+		UpdatedAt: resp.UpdatedAt,
+	}
+	return list, nil
+}
+func (s *ReadingList) Edit(ctx context.Context, req *dto.EditReadingListDTO, userID int64) (*entity.ReadingList, error) {
+	ctx, span := tracing.Start(ctx, "ReadingList.Edit")
+	defer span.End()
+
+	resp, err := s.readingListRepository.Edit(ctx, repositoryReadingList.EditParams{
+		Title:    req.Title,
+		IsPublic: req.IsPublic,
+		ID:       req.ID,
+		UserID:   userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrorReadingListNotFound
+		}
+		return nil, fmt.Errorf("ReadingList.Edit() Edit: %w", err)
+	}
+	list := &entity.ReadingList{
+		ID:        resp.ID,
+		UserID:    resp.UserID,
+		Title:     resp.Title,
+		IsPublic:  resp.IsPublic,
+		CreatedAt: resp.CreatedAt,
+		UpdatedAt: resp.UpdatedAt,
+	}
+	return list, nil
+}
+func (s *ReadingList) Delete(ctx context.Context, id, userID int64) error {
+	ctx, span := tracing.Start(ctx, "ReadingList.Delete")
+	defer span.End()
+
+	rows, err := s.readingListRepository.Delete(ctx, repositoryReadingList.DeleteParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("ReadingList.Delete() Delete: %w", err)
+	}
+	if rows == 0 {
+		return ErrorReadingListNotFound
+	}
+	return nil
+}
+func (s *ReadingList) AddItem(ctx context.Context, req *dto.AddReadingListItemDTO, userID int64) (*entity.ReadingListItem, error) {
+	ctx, span := tracing.Start(ctx, "ReadingList.AddItem")
+	defer span.End()
+
+	err := s.checkOwner(ctx, req.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ReadingList.AddItem() %w", err)
+	}
+
+	// Only published posts can be bookmarked
+	_, err = s.postRepository.GetByID(ctx, repositoryPost.GetByIDParams{
+		ID:     req.PostID,
+		UserID: utils.NewSqlInt64(nil),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrorPostNotFound
+		}
+		return nil, fmt.Errorf("ReadingList.AddItem() post.GetByID: %w", err)
+	}
+
+	resp, err := s.readingListRepository.AddItem(ctx, repositoryReadingList.AddItemParams{
+		ListID: req.ID,
+		PostID: req.PostID,
+		Note:   utils.NewSqlString(req.Note),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ReadingList.AddItem() AddItem: %w", err)
+	}
+	err = s.readingListRepository.Touch(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ReadingList.AddItem() Touch: %w", err)
+	}
+	item := &entity.ReadingListItem{
+		PostID:    resp.PostID,
+		Note:      utils.SqlStringToString(resp.Note),
+		Position:  resp.Position,
+		CreatedAt: resp.CreatedAt,
+	}
+	return item, nil
+}
+func (s *ReadingList) RemoveItem(ctx context.Context, req *dto.RemoveReadingListItemDTO, userID int64) error {
+	ctx, span := tracing.Start(ctx, "ReadingList.RemoveItem")
+	defer span.End()
+
+	err := s.checkOwner(ctx, req.ID, userID)
+	if err != nil {
+		return fmt.Errorf("ReadingList.RemoveItem() %w", err)
+	}
+
+	rows, err := s.readingListRepository.RemoveItem(ctx, repositoryReadingList.RemoveItemParams{
+		ListID: req.ID,
+		PostID: req.PostID,
+	})
+	if err != nil {
+		return fmt.Errorf("ReadingList.RemoveItem() RemoveItem: %w", err)
+	}
+	if rows == 0 {
+		return ErrorItemNotFound
+	}
+	err = s.readingListRepository.Touch(ctx, req.ID)
+	if err != nil {
+		return fmt.Errorf("ReadingList.RemoveItem() Touch: %w", err)
+	}
+	return nil
+}
+func (s *ReadingList) Reorder(ctx context.Context, req *dto.ReorderReadingListDTO, userID int64) (*entity.ReadingList, error) {
+	ctx, span := tracing.Start(ctx, "ReadingList.Reorder")
+	defer span.End()
+
+	err := s.checkOwner(ctx, req.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ReadingList.Reorder() %w", err)
+	}
+
+	current, err := s.readingListRepository.ListItemPostIDs(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ReadingList.Reorder() ListItemPostIDs: %w", err)
+	}
+	inList := make(map[int64]bool, len(current))
+	for _, postID := range current {
+		inList[postID] = true
+	}
+
+	// The passed posts go first in the passed order, the rest keep their relative order after them
+	order := make([]int64, 0, len(current))
+	for _, postID := range req.PostIDs {
+		if !inList[postID] {
+			return nil, ErrorItemNotFound
+		}
+		order = append(order, postID)
+		delete(inList, postID)
+	}
+	for _, postID := range current {
+		if inList[postID] {
+			order = append(order, postID)
+		}
+	}
+
+	for i, postID := range order {
+		err = s.readingListRepository.SetItemPosition(ctx, repositoryReadingList.SetItemPositionParams{
+			Position: int64(i),
+			ListID:   req.ID,
+			PostID:   postID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("ReadingList.Reorder() SetItemPosition: %w", err)
+		}
+	}
+	err = s.readingListRepository.Touch(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ReadingList.Reorder() Touch: %w", err)
+	}
+
+	list, err := s.PublicGet(ctx, req.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ReadingList.Reorder() %w", err)
+	}
+	return list, nil
+}
+
+func (s *ReadingList) checkOwner(ctx context.Context, id, userID int64) error {
+	resp, err := s.readingListRepository.GetByID(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrorReadingListNotFound
+		}
+		return fmt.Errorf("GetByID: %w", err)
+	}
+	if resp.UserID != userID {
+		return ErrorReadingListNotFound
+	}
+	return nil
+}
+func (s *ReadingList) listItems(ctx context.Context, id int64) ([]*entity.ReadingListItem, error) {
+	resp, err := s.readingListRepository.ListItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("ListItems: %w", err)
+	}
+
+	items := make([]*entity.ReadingListItem, 0, len(resp))
+	for _, el := range resp {
+		items = append(items, &entity.ReadingListItem{
+			PostID: el.ReadingListItem.PostID,
+			Post: &entity.Post{
+				ID:          el.Post.ID,
+				UserID:      el.Post.UserID,
+				Title:       el.Post.Title,
+				Short:       el.Post.Short,
+				Tags:        strings.Split(el.Post.Tags.String, ";"),
+				IsPublished: el.Post.IsPublished,
+				CoverImage:  utils.SqlStringToString(el.Post.CoverImage),
+				CreatedAt:   el.Post.CreatedAt,
+				UpdatedAt:   el.Post.UpdatedAt,
+			},
+			Note:      utils.SqlStringToString(el.ReadingListItem.Note),
+			Position:  el.ReadingListItem.Position,
+			CreatedAt: el.ReadingListItem.CreatedAt,
+		})
+	}
+	return items, nil
+}
+
+var (
+	ErrorReadingListNotFound = errors.New("reading list not found")
+	ErrorItemNotFound        = errors.New("reading list item not found")
+	ErrorPostNotFound        = errors.New("post not found")
+)
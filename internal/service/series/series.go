@@ -0,0 +1,260 @@
+package series
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HardDie/blog_engine/internal/db"
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+	repositoryPost "github.com/HardDie/blog_engine/internal/repository/sqlite/post"
+	repositorySeries "github.com/HardDie/blog_engine/internal/repository/sqlite/series"
+	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+	"github.com/HardDie/blog_engine/internal/tracing"
+	"github.com/HardDie/blog_engine/internal/transaction"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+type ISeries interface {
+	PublicGet(ctx context.Context, id int64) (*entity.Series, error)
+
+	Create(ctx context.Context, req *dto.CreateSeriesDTO, userID int64) (*entity.Series, error)
+	Reorder(ctx context.Context, req *dto.ReorderSeriesDTO, userID int64) (*entity.Series, error)
+}
+
+type Series struct {
+	seriesRepository repositorySeries.Querier
+	postRepository   repositoryPost.Querier
+	userRepository   repositoryUser.Querier
+	transaction      transaction.IManager
+}
+
+func New(series repositorySeries.Querier, post repositoryPost.Querier, user repositoryUser.Querier, transaction transaction.IManager) *Series {
+	return &Series{
+		seriesRepository: series,
+		postRepository:   post,
+		userRepository:   user,
+		transaction:      transaction,
+	}
+}
+
+func (s *Series) PublicGet(ctx context.Context, id int64) (*entity.Series, error) {
+	ctx, span := tracing.Start(ctx, "Series.PublicGet")
+	defer span.End()
+
+	resp, err := s.seriesRepository.GetByID(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrorSeriesNotFound
+		}
+		return nil, fmt.Errorf("Series.PublicGet() GetByID: %w", err)
+	}
+	series := &entity.Series{
+		ID:          resp.ID,
+		UserID:      resp.UserID,
+		Title:       resp.Title,
+		Description: utils.SqlStringToString(resp.Description),
+		CreatedAt:   resp.CreatedAt,
+		UpdatedAt:   resp.UpdatedAt,
+	}
+
+	respUser, err := s.userRepository.GetByIDPublic(ctx, series.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("Series.PublicGet() user.GetByID: %w", err)
+	}
+	series.User = &entity.User{
+		ID:              respUser.ID,
+		DisplayedName:   respUser.DisplayedName,
+		InvitedByUserID: respUser.InvitedByUser,
+		CreatedAt:       respUser.CreatedAt,
+		UpdatedAt:       respUser.UpdatedAt,
+	}
+
+	series.Posts, err = s.listPosts(ctx, series.ID, true)
+	if err != nil {
+		return nil, fmt.Errorf("Series.PublicGet() %w", err)
+	}
+	return series, nil
+}
+func (s *Series) Create(ctx context.Context, req *dto.CreateSeriesDTO, userID int64) (*entity.Series, error) {
+	ctx, span := tracing.Start(ctx, "Series.Create")
+	defer span.End()
+
+	err := s.checkPosts(ctx, req.PostIDs, 0, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Series.Create() %w", err)
+	}
+
+	// The series is not created if any of the posts can't be added
+	var resp *repositorySeries.Series
+	err = s.transaction.Do(ctx, func(repos *transaction.Repositories) error {
+		resp, err = repos.Series.Create(ctx, repositorySeries.CreateParams{
+			UserID:      userID,
+			Title:       req.Title,
+			Description: utils.NewSqlString(req.Description),
+		})
+		if err != nil {
+			return fmt.Errorf("Create: %w", err)
+		}
+		return addPosts(ctx, repos.Series, resp.ID, req.PostIDs)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Series.Create() %w", err)
+	}
+	series := &entity.Series{
+		ID:          resp.ID,
+		UserID:      resp.UserID,
+		Title:       resp.Title,
+		Description: utils.SqlStringToString(resp.Description),
+		CreatedAt:   resp.CreatedAt,
+		UpdatedAt:   resp.UpdatedAt,
+	}
+
+	series.Posts, err = s.listPosts(ctx, series.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("Series.Create() %w", err)
+	}
+	return series, nil
+}
+func (s *Series) Reorder(ctx context.Context, req *dto.ReorderSeriesDTO, userID int64) (*entity.Series, error) {
+	ctx, span := tracing.Start(ctx, "Series.Reorder")
+	defer span.End()
+
+	resp, err := s.seriesRepository.GetByID(ctx, req.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrorSeriesNotFound
+		}
+		return nil, fmt.Errorf("Series.Reorder() GetByID: %w", err)
+	}
+	if resp.UserID != userID {
+		return nil, ErrorSeriesNotFound
+	}
+
+	err = s.checkPosts(ctx, req.PostIDs, resp.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Series.Reorder() %w", err)
+	}
+
+	// The new list of posts completely replaces the old one, the old list is kept if the new one fails
+	err = s.transaction.Do(ctx, func(repos *transaction.Repositories) error {
+		err := repos.Series.DeletePosts(ctx, resp.ID)
+		if err != nil {
+			return fmt.Errorf("DeletePosts: %w", err)
+		}
+		err = addPosts(ctx, repos.Series, resp.ID, req.PostIDs)
+		if err != nil {
+			return err
+		}
+		err = repos.Series.Touch(ctx, resp.ID)
+		if err != nil {
+			return fmt.Errorf("Touch: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Series.Reorder() %w", err)
+	}
+
+	resp, err = s.seriesRepository.GetByID(ctx, resp.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Series.Reorder() GetByID: %w", err)
+	}
+	series := &entity.Series{
+		ID:          resp.ID,
+		UserID:      resp.UserID,
+		Title:       resp.Title,
+		Description: utils.SqlStringToString(resp.Description),
+		CreatedAt:   resp.CreatedAt,
+		UpdatedAt:   resp.UpdatedAt,
+	}
+	series.Posts, err = s.listPosts(ctx, series.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("Series.Reorder() %w", err)
+	}
+	return series, nil
+}
+
+// addPosts keeps the order of the list. A post added to another series after checkPosts is caught by the unique index.
+func addPosts(ctx context.Context, series repositorySeries.Querier, seriesID int64, postIDs []int64) error {
+	for i, postID := range postIDs {
+		err := series.AddPost(ctx, repositorySeries.AddPostParams{
+			SeriesID: seriesID,
+			PostID:   postID,
+			Position: int64(i),
+		})
+		if err != nil {
+			if db.IsUniqueViolation(err) {
+				return ErrorPostInAnotherSeries
+			}
+			return fmt.Errorf("AddPost: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkPosts verifies that all posts belong to the user and are not part of another series.
+func (s *Series) checkPosts(ctx context.Context, postIDs []int64, seriesID, userID int64) error {
+	for _, postID := range postIDs {
+		_, err := s.postRepository.GetByID(ctx, repositoryPost.GetByIDParams{
+			ID:     postID,
+			UserID: utils.NewSqlInt64(&userID),
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrorPostNotFound
+			}
+			return fmt.Errorf("post.GetByID: %w", err)
+		}
+
+		series, err := s.seriesRepository.GetByPostID(ctx, postID)
+		switch {
+		case err == nil:
+			if series.ID != seriesID {
+				return ErrorPostInAnotherSeries
+			}
+		case errors.Is(err, sql.ErrNoRows):
+			// continue
+		default:
+			return fmt.Errorf("GetByPostID: %w", err)
+		}
+	}
+	return nil
+}
+func (s *Series) listPosts(ctx context.Context, seriesID int64, onlyPublished bool) ([]*entity.Post, error) {
+	resp, err := s.seriesRepository.ListPosts(ctx, repositorySeries.ListPostsParams{
+		SeriesID:             seriesID,
+		DisplayOnlyPublished: onlyPublished,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListPosts: %w", err)
+	}
+
+	posts := make([]*entity.Post, 0, len(resp))
+	for _, el := range resp {
+		posts = append(posts, &entity.Post{
+			ID:          el.Post.ID,
+			UserID:      el.Post.UserID,
+			Title:       el.Post.Title,
+			Short:       el.Post.Short,
+			Tags:        strings.Split(el.Post.Tags.String, ";"),
+			IsPublished: el.Post.IsPublished,
+			CoverImage:  utils.SqlStringToString(el.Post.CoverImage),
+			CreatedAt:   el.Post.CreatedAt,
+			UpdatedAt:   el.Post.UpdatedAt,
+		})
+	}
+	return posts, nil
+}
+
+var (
+	ErrorSeriesNotFound      = errors.New("series not found")
+	ErrorPostNotFound        = errors.New("post not found")
+	ErrorPostInAnotherSeries = errors.New("post already belongs to another series")
+)
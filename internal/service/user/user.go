@@ -5,35 +5,54 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/HardDie/blog_engine/internal/dto"
 	"github.com/HardDie/blog_engine/internal/entity"
+	repositoryFollow "github.com/HardDie/blog_engine/internal/repository/sqlite/follow"
 	repositoryPassword "github.com/HardDie/blog_engine/internal/repository/sqlite/password"
 	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+	serviceNotification "github.com/HardDie/blog_engine/internal/service/notification"
+	"github.com/HardDie/blog_engine/internal/tracing"
 	"github.com/HardDie/blog_engine/internal/utils"
 )
 
 type IUser interface {
-	Get(ctx context.Context, userID int64) (*entity.User, error)
+	Get(ctx context.Context, id, userID int64) (*entity.User, error)
 
 	Password(ctx context.Context, req *dto.UpdatePasswordDTO, userID int64) error
 	Profile(ctx context.Context, req *dto.UpdateProfileDTO, userID int64) (*entity.User, error)
+	Follow(ctx context.Context, id, userID int64) error
+	Unfollow(ctx context.Context, id, userID int64) error
 }
 
 type User struct {
 	userRepository     repositoryUser.Querier
 	passwordRepository repositoryPassword.Querier
+	followRepository   repositoryFollow.Querier
+
+	notificationService serviceNotification.INotification
 }
 
-func New(user repositoryUser.Querier, password repositoryPassword.Querier) *User {
+func New(
+	user repositoryUser.Querier,
+	password repositoryPassword.Querier,
+	follow repositoryFollow.Querier,
+	notification serviceNotification.INotification,
+) *User {
 	return &User{
-		userRepository:     user,
-		passwordRepository: password,
+		userRepository:      user,
+		passwordRepository:  password,
+		followRepository:    follow,
+		notificationService: notification,
 	}
 }
 
-func (s *User) Get(ctx context.Context, userID int64) (*entity.User, error) {
-	resp, err := s.userRepository.GetByIDPublic(ctx, userID)
+func (s *User) Get(ctx context.Context, id, userID int64) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "User.Get")
+	defer span.End()
+
+	resp, err := s.userRepository.GetByIDPublic(ctx, id)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -48,9 +67,32 @@ func (s *User) Get(ctx context.Context, userID int64) (*entity.User, error) {
 		CreatedAt:       resp.CreatedAt,
 		UpdatedAt:       resp.UpdatedAt,
 	}
+
+	counts, err := s.followRepository.Counts(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("User.Get() Counts: %w", err)
+	}
+	user.FollowersCount = &counts.FollowersCount
+	user.FollowingCount = &counts.FollowingCount
+
+	// Anonymous users and the owner of the profile don't get the follow flag
+	if userID != 0 && userID != user.ID {
+		isFollowing, err := s.followRepository.IsFollowing(ctx, repositoryFollow.IsFollowingParams{
+			FollowerID: userID,
+			FolloweeID: user.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("User.Get() IsFollowing: %w", err)
+		}
+		isFollowed := isFollowing != 0
+		user.IsFollowed = &isFollowed
+	}
 	return user, nil
 }
 func (s *User) Password(ctx context.Context, req *dto.UpdatePasswordDTO, userID int64) error {
+	ctx, span := tracing.Start(ctx, "User.Password")
+	defer span.End()
+
 	// Get password from DB
 	password, err := s.passwordRepository.GetByUserID(ctx, userID)
 	if err != nil {
@@ -79,6 +121,9 @@ func (s *User) Password(ctx context.Context, req *dto.UpdatePasswordDTO, userID
 	return nil
 }
 func (s *User) Profile(ctx context.Context, req *dto.UpdateProfileDTO, userID int64) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "User.Profile")
+	defer span.End()
+
 	resp, err := s.userRepository.Update(ctx, repositoryUser.UpdateParams{
 		ID:            userID,
 		DisplayedName: req.DisplayedName,
@@ -98,8 +143,62 @@ func (s *User) Profile(ctx context.Context, req *dto.UpdateProfileDTO, userID in
 	}
 	return user, nil
 }
+func (s *User) Follow(ctx context.Context, id, userID int64) error {
+	ctx, span := tracing.Start(ctx, "User.Follow")
+	defer span.End()
+
+	if id == userID {
+		return ErrorFollowYourself
+	}
+	_, err := s.userRepository.GetByIDPublic(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrorUserNotFound
+		}
+		return fmt.Errorf("User.Follow() GetByIDPublic: %w", err)
+	}
+
+	// A repeated follow is ignored
+	rows, err := s.followRepository.Follow(ctx, repositoryFollow.FollowParams{
+		FollowerID: userID,
+		FolloweeID: id,
+	})
+	if err != nil {
+		return fmt.Errorf("User.Follow() Follow: %w", err)
+	}
+	if rows > 0 {
+		err = s.notificationService.Notify(ctx, &entity.Notification{
+			UserID:  id,
+			Type:    entity.NotificationTypeFollow,
+			ActorID: userID,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "User.Follow() can't notify about new follower", "recipient_id", id, "error", err)
+		}
+	}
+	return nil
+}
+func (s *User) Unfollow(ctx context.Context, id, userID int64) error {
+	ctx, span := tracing.Start(ctx, "User.Unfollow")
+	defer span.End()
+
+	rows, err := s.followRepository.Unfollow(ctx, repositoryFollow.UnfollowParams{
+		FollowerID: userID,
+		FolloweeID: id,
+	})
+	if err != nil {
+		return fmt.Errorf("User.Unfollow() Unfollow: %w", err)
+	}
+	if rows == 0 {
+		return ErrorNotFollowing
+	}
+	return nil
+}
 
 var (
 	ErrorUserNotFound    = errors.New("user not found")
 	ErrorInvalidPassword = errors.New("invalid password")
+	ErrorFollowYourself  = errors.New("can't follow yourself")
+	ErrorNotFollowing    = errors.New("user is not followed")
 )
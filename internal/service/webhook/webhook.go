@@ -0,0 +1,450 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+	"github.com/HardDie/blog_engine/internal/job"
+	repositoryWebhook "github.com/HardDie/blog_engine/internal/repository/sqlite/webhook"
+	"github.com/HardDie/blog_engine/internal/tracing"
+	"github.com/HardDie/blog_engine/internal/utils"
+)
+
+const (
+	// JobDeliver sends one delivery, a failed attempt queues the job again after the backoff
+	JobDeliver = "webhook.deliver"
+
+	maxBackoff = 24 * time.Hour
+)
+
+// DeliverJob is the payload of JobDeliver.
+type DeliverJob struct {
+	DeliveryID int64 `json:"deliveryId"`
+}
+
+type IWebhook interface {
+	// Dispatch queues the event for all webhooks of the user subscribed to it
+	Dispatch(ctx context.Context, event string, userID int64, data interface{}) error
+	// Deliver makes an attempt of the delivery, it is the handler of JobDeliver
+	Deliver(ctx context.Context, deliveryID int64) error
+
+	Create(ctx context.Context, req *dto.CreateWebhookDTO, userID int64) (*entity.Webhook, error)
+	List(ctx context.Context, userID int64) ([]*entity.Webhook, error)
+	Delete(ctx context.Context, id, userID int64) error
+	Deliveries(ctx context.Context, req *dto.ListWebhookDeliveriesDTO, userID int64) ([]*entity.WebhookDelivery, int64, error)
+	Redeliver(ctx context.Context, req *dto.RedeliverWebhookDTO, userID int64) (*entity.WebhookDelivery, error)
+}
+
+type Webhook struct {
+	webhookRepository repositoryWebhook.Querier
+
+	cfg    *config.Config
+	client *http.Client
+	queue  job.IQueue
+}
+
+func New(cfg *config.Config, webhook repositoryWebhook.Querier, queue job.IQueue) *Webhook {
+	dialer := &net.Dialer{
+		Timeout: time.Duration(cfg.WebhookTimeout) * time.Second,
+	}
+	if !cfg.WebhookAllowPrivate {
+		// The address is checked after the resolution, so a DNS name can't switch to an internal IP between checks
+		dialer.Control = checkAddress
+	}
+	return &Webhook{
+		webhookRepository: webhook,
+		cfg:               cfg,
+		client: &http.Client{
+			// No proxy from the environment, the dialer must see the address of the receiver
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: time.Duration(cfg.WebhookTimeout) * time.Second,
+			},
+			// A redirect could lead to an internal address, the 3xx response fails the delivery instead
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+			Timeout: time.Duration(cfg.WebhookTimeout) * time.Second,
+		},
+		queue: queue,
+	}
+}
+
+func (s *Webhook) Dispatch(ctx context.Context, event string, userID int64, data interface{}) error {
+	ctx, span := tracing.Start(ctx, "Webhook.Dispatch")
+	defer span.End()
+
+	webhooks, err := s.webhookRepository.ListByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("Webhook.Dispatch() ListByUser: %w", err)
+	}
+
+	var payload []byte
+	for _, webhook := range webhooks {
+		if !subscribed(webhook, event) {
+			continue
+		}
+		if payload == nil {
+			payload, err = json.Marshal(&entity.WebhookPayload{
+				Event:     event,
+				CreatedAt: time.Now().UTC(),
+				Data:      data,
+			})
+			if err != nil {
+				return fmt.Errorf("Webhook.Dispatch() Marshal: %w", err)
+			}
+		}
+		delivery, err := s.webhookRepository.CreateDelivery(ctx, repositoryWebhook.CreateDeliveryParams{
+			WebhookID: webhook.ID,
+			Event:     event,
+			Payload:   string(payload),
+		})
+		if err != nil {
+			return fmt.Errorf("Webhook.Dispatch() CreateDelivery: %w", err)
+		}
+		err = s.enqueue(ctx, delivery.ID, 0)
+		if err != nil {
+			return fmt.Errorf("Webhook.Dispatch() %w", err)
+		}
+	}
+	return nil
+}
+
+// Deliver sends the pending delivery. Failures of the receiver are recorded in the delivery log and the job is queued
+// again after the backoff, so the error is returned only if the log can't be updated or the attempt is interrupted.
+// The delivery is skipped if it is already finished or its webhook is deleted.
+func (s *Webhook) Deliver(ctx context.Context, deliveryID int64) error {
+	ctx, span := tracing.Start(ctx, "Webhook.Deliver")
+	defer span.End()
+
+	delivery, err := s.webhookRepository.GetPendingDelivery(ctx, deliveryID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil
+		}
+		return fmt.Errorf("Webhook.Deliver() GetPendingDelivery: %w", err)
+	}
+	err = s.deliver(ctx, delivery)
+	if err != nil {
+		return fmt.Errorf("Webhook.Deliver() %w", err)
+	}
+	return nil
+}
+
+func (s *Webhook) Create(ctx context.Context, req *dto.CreateWebhookDTO, userID int64) (*entity.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "Webhook.Create")
+	defer span.End()
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.Create() %w", err)
+	}
+
+	resp, err := s.webhookRepository.Create(ctx, repositoryWebhook.CreateParams{
+		UserID: userID,
+		Url:    req.URL,
+		Secret: secret,
+		Events: strings.Join(req.Events, ","),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.Create() Create: %w", err)
+	}
+	webhook := toWebhook(resp)
+	webhook.Secret = resp.Secret
+	return webhook, nil
+}
+func (s *Webhook) List(ctx context.Context, userID int64) ([]*entity.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "Webhook.List")
+	defer span.End()
+
+	resp, err := s.webhookRepository.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.List() ListByUser: %w", err)
+	}
+	webhooks := make([]*entity.Webhook, 0, len(resp))
+	for _, el := range resp {
+		webhooks = append(webhooks, toWebhook(el))
+	}
+	return webhooks, nil
+}
+func (s *Webhook) Delete(ctx context.Context, id, userID int64) error {
+	ctx, span := tracing.Start(ctx, "Webhook.Delete")
+	defer span.End()
+
+	rows, err := s.webhookRepository.Delete(ctx, repositoryWebhook.DeleteParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("Webhook.Delete() Delete: %w", err)
+	}
+	if rows == 0 {
+		return ErrorWebhookNotFound
+	}
+	err = s.webhookRepository.CancelDeliveries(ctx, id)
+	if err != nil {
+		return fmt.Errorf("Webhook.Delete() CancelDeliveries: %w", err)
+	}
+	return nil
+}
+func (s *Webhook) Deliveries(ctx context.Context, req *dto.ListWebhookDeliveriesDTO, userID int64) ([]*entity.WebhookDelivery, int64, error) {
+	ctx, span := tracing.Start(ctx, "Webhook.Deliveries")
+	defer span.End()
+
+	err := s.checkOwner(ctx, req.ID, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Webhook.Deliveries() %w", err)
+	}
+
+	limit, offset := utils.GetPagination(req.Limit, req.Page)
+	resp, err := s.webhookRepository.ListDeliveries(ctx, repositoryWebhook.ListDeliveriesParams{
+		WebhookID: req.ID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("Webhook.Deliveries() ListDeliveries: %w", err)
+	}
+	if len(resp) == 0 {
+		return []*entity.WebhookDelivery{}, 0, nil
+	}
+
+	deliveries := make([]*entity.WebhookDelivery, 0, len(resp))
+	for _, el := range resp {
+		deliveries = append(deliveries, toDelivery(&el.WebhookDelivery))
+	}
+	return deliveries, resp[0].Count, nil
+}
+func (s *Webhook) Redeliver(ctx context.Context, req *dto.RedeliverWebhookDTO, userID int64) (*entity.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "Webhook.Redeliver")
+	defer span.End()
+
+	err := s.checkOwner(ctx, req.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.Redeliver() %w", err)
+	}
+
+	delivery, err := s.webhookRepository.GetDelivery(ctx, repositoryWebhook.GetDeliveryParams{
+		ID:        req.DeliveryID,
+		WebhookID: req.ID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrorDeliveryNotFound
+		}
+		return nil, fmt.Errorf("Webhook.Redeliver() GetDelivery: %w", err)
+	}
+
+	// The original delivery stays in the log as is, the same payload is queued again
+	resp, err := s.webhookRepository.CreateDelivery(ctx, repositoryWebhook.CreateDeliveryParams{
+		WebhookID: delivery.WebhookID,
+		Event:     delivery.Event,
+		Payload:   delivery.Payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.Redeliver() CreateDelivery: %w", err)
+	}
+	err = s.enqueue(ctx, resp.ID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.Redeliver() %w", err)
+	}
+	return toDelivery(resp), nil
+}
+
+// Sign calculates the signature sent in the X-Webhook-Signature header.
+// Receivers should calculate the same HMAC-SHA256 of "<X-Webhook-Timestamp>.<body>" with the webhook secret.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Webhook) deliver(ctx context.Context, delivery *repositoryWebhook.GetPendingDeliveryRow) error {
+	status, deliveryErr := s.send(ctx, delivery)
+	if ctx.Err() != nil {
+		// Interrupted by shutdown, the job is returned to the queue on the next start
+		return ctx.Err()
+	}
+
+	responseStatus := sql.NullInt64{Int64: int64(status), Valid: status != 0}
+	if deliveryErr == nil {
+		err := s.webhookRepository.MarkDeliverySucceeded(ctx, repositoryWebhook.MarkDeliverySucceededParams{
+			ResponseStatus: responseStatus,
+			ID:             delivery.WebhookDelivery.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("MarkDeliverySucceeded: %w", err)
+		}
+		return nil
+	}
+
+	errMessage := sql.NullString{String: deliveryErr.Error(), Valid: true}
+	attempts := delivery.WebhookDelivery.Attempts + 1
+	if attempts >= int64(s.cfg.WebhookMaxAttempts) {
+		err := s.webhookRepository.MarkDeliveryFailed(ctx, repositoryWebhook.MarkDeliveryFailedParams{
+			ResponseStatus: responseStatus,
+			Error:          errMessage,
+			ID:             delivery.WebhookDelivery.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("MarkDeliveryFailed: %w", err)
+		}
+		return nil
+	}
+
+	backoff := s.backoff(attempts)
+	err := s.webhookRepository.MarkDeliveryRetry(ctx, repositoryWebhook.MarkDeliveryRetryParams{
+		ResponseStatus: responseStatus,
+		Error:          errMessage,
+		Delay:          fmt.Sprintf("+%d seconds", int64(backoff/time.Second)),
+		ID:             delivery.WebhookDelivery.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("MarkDeliveryRetry: %w", err)
+	}
+	return s.enqueue(ctx, delivery.WebhookDelivery.ID, backoff)
+}
+func (s *Webhook) send(ctx context.Context, delivery *repositoryWebhook.GetPendingDeliveryRow) (int, error) {
+	body := []byte(delivery.WebhookDelivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("bad request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "blog_engine-webhook")
+	req.Header.Set("X-Webhook-Event", delivery.WebhookDelivery.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.WebhookDelivery.ID, 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", Sign(delivery.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// The body isn't kept in the delivery log, it would let the owner of the webhook read the responses
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff doubles the delay after each failed attempt.
+func (s *Webhook) backoff(attempts int64) time.Duration {
+	delay := time.Duration(s.cfg.WebhookBackoff) * time.Second
+	for i := int64(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
+func (s *Webhook) enqueue(ctx context.Context, deliveryID int64, delay time.Duration) error {
+	err := s.queue.EnqueueIn(ctx, JobDeliver, &DeliverJob{DeliveryID: deliveryID}, delay)
+	if err != nil {
+		return fmt.Errorf("queue.EnqueueIn: %w", err)
+	}
+	return nil
+}
+func (s *Webhook) checkOwner(ctx context.Context, id, userID int64) error {
+	_, err := s.webhookRepository.GetByID(ctx, repositoryWebhook.GetByIDParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrorWebhookNotFound
+		}
+		return fmt.Errorf("GetByID: %w", err)
+	}
+	return nil
+}
+
+// checkAddress rejects receivers in the loopback, private, link-local and unspecified ranges.
+func checkAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("ParseAddrPort: %w", err)
+	}
+	ip := addrPort.Addr().Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s", ErrorPrivateAddress, ip)
+	}
+	return nil
+}
+
+func subscribed(webhook *repositoryWebhook.Webhook, event string) bool {
+	for _, el := range strings.Split(webhook.Events, ",") {
+		if el == event {
+			return true
+		}
+	}
+	return false
+}
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", fmt.Errorf("generating secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+func toWebhook(resp *repositoryWebhook.Webhook) *entity.Webhook {
+	return &entity.Webhook{
+		ID:        resp.ID,
+		UserID:    resp.UserID,
+		URL:       resp.Url,
+		Events:    strings.Split(resp.Events, ","),
+		CreatedAt: resp.CreatedAt,
+		UpdatedAt: resp.UpdatedAt,
+	}
+}
+func toDelivery(resp *repositoryWebhook.WebhookDelivery) *entity.WebhookDelivery {
+	return &entity.WebhookDelivery{
+		ID:             resp.ID,
+		WebhookID:      resp.WebhookID,
+		Event:          resp.Event,
+		Payload:        json.RawMessage(resp.Payload),
+		Status:         resp.Status,
+		Attempts:       resp.Attempts,
+		NextAttemptAt:  resp.NextAttemptAt,
+		ResponseStatus: utils.SqlInt64ToInt64(resp.ResponseStatus),
+		Error:          utils.SqlStringToString(resp.Error),
+		CreatedAt:      resp.CreatedAt,
+		UpdatedAt:      resp.UpdatedAt,
+	}
+}
+
+var (
+	ErrorWebhookNotFound  = errors.New("webhook not found")
+	ErrorDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrorPrivateAddress   = errors.New("webhook address is private")
+)
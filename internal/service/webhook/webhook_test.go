@@ -0,0 +1,310 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HardDie/blog_engine/internal/config"
+	"github.com/HardDie/blog_engine/internal/db"
+	"github.com/HardDie/blog_engine/internal/dto"
+	"github.com/HardDie/blog_engine/internal/entity"
+	"github.com/HardDie/blog_engine/internal/migration"
+	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+	repositoryWebhook "github.com/HardDie/blog_engine/internal/repository/sqlite/webhook"
+)
+
+type receiver struct {
+	mutex    sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	w.WriteHeader(rc.status)
+}
+
+// queue keeps jobs of the test, run delivers them synchronously ignoring delays.
+type queue struct {
+	jobs []*DeliverJob
+}
+
+func (q *queue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	return q.EnqueueIn(ctx, jobType, payload, 0)
+}
+func (q *queue) EnqueueIn(_ context.Context, jobType string, payload interface{}, _ time.Duration) error {
+	if jobType != JobDeliver {
+		return fmt.Errorf("unexpected job type %q", jobType)
+	}
+	q.jobs = append(q.jobs, payload.(*DeliverJob))
+	return nil
+}
+func (q *queue) run(t *testing.T, s *Webhook) {
+	t.Helper()
+
+	for len(q.jobs) > 0 {
+		job := q.jobs[0]
+		q.jobs = q.jobs[1:]
+		err := s.Deliver(context.Background(), job.DeliveryID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func setup(t *testing.T, cfg *config.Config) (*Webhook, *queue, int64) {
+	t.Helper()
+
+	database, err := db.Get(filepath.Join(t.TempDir(), "blog.db"), 2, 5000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	err = migration.NewMigrate(database).Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := repositoryUser.New(database).Create(context.Background(), repositoryUser.CreateParams{
+		Username:      "alice",
+		DisplayedName: "Alice",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := &queue{}
+	return New(cfg, repositoryWebhook.New(database), q), q, user.ID
+}
+
+func TestDeliverySigned(t *testing.T) {
+	ctx := context.Background()
+	rc := &receiver{status: http.StatusOK}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	s, q, userID := setup(t, &config.Config{WebhookMaxAttempts: 3, WebhookTimeout: 5, WebhookAllowPrivate: true})
+	webhook, err := s.Create(ctx, &dto.CreateWebhookDTO{
+		URL:    srv.URL,
+		Events: []string{entity.WebhookEventPostPublished},
+	}, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Not subscribed event must be skipped
+	err = s.Dispatch(ctx, entity.WebhookEventPostDeleted, userID, map[string]int{"id": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Dispatch(ctx, entity.WebhookEventPostPublished, userID, map[string]int{"id": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(q.jobs))
+	}
+	q.run(t, s)
+
+	if len(rc.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(rc.requests))
+	}
+	req, body := rc.requests[0], rc.bodies[0]
+	if got := req.Header.Get("X-Webhook-Event"); got != entity.WebhookEventPostPublished {
+		t.Errorf("X-Webhook-Event = %q", got)
+	}
+	expected := Sign(webhook.Secret, req.Header.Get("X-Webhook-Timestamp"), body)
+	if got := req.Header.Get("X-Webhook-Signature"); got != expected {
+		t.Errorf("X-Webhook-Signature = %q, expected %q", got, expected)
+	}
+	payload := &entity.WebhookPayload{}
+	err = json.Unmarshal(body, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload.Event != entity.WebhookEventPostPublished {
+		t.Errorf("payload event = %q", payload.Event)
+	}
+
+	deliveries, total, err := s.Deliveries(ctx, &dto.ListWebhookDeliveriesDTO{ID: webhook.ID}, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || deliveries[0].Status != entity.WebhookDeliveryStatusSucceeded {
+		t.Errorf("unexpected deliveries: total %d, %+v", total, deliveries)
+	}
+}
+
+func TestDeliveryRetriesAndRedeliver(t *testing.T) {
+	ctx := context.Background()
+	rc := &receiver{status: http.StatusInternalServerError}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	// Zero backoff makes retries due immediately
+	s, q, userID := setup(t, &config.Config{WebhookMaxAttempts: 3, WebhookTimeout: 5, WebhookAllowPrivate: true})
+	webhook, err := s.Create(ctx, &dto.CreateWebhookDTO{
+		URL:    srv.URL,
+		Events: []string{entity.WebhookEventUserRegistered},
+	}, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Dispatch(ctx, entity.WebhookEventUserRegistered, userID, map[string]int{"id": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.run(t, s)
+
+	if len(rc.requests) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(rc.requests))
+	}
+	deliveries, _, err := s.Deliveries(ctx, &dto.ListWebhookDeliveriesDTO{ID: webhook.ID}, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := deliveries[0]
+	if failed.Status != entity.WebhookDeliveryStatusFailed || failed.Attempts != 3 {
+		t.Fatalf("expected failed delivery after 3 attempts, got %+v", failed)
+	}
+	if failed.ResponseStatus == nil || *failed.ResponseStatus != http.StatusInternalServerError {
+		t.Errorf("unexpected response status %v", failed.ResponseStatus)
+	}
+
+	rc.status = http.StatusNoContent
+	_, err = s.Redeliver(ctx, &dto.RedeliverWebhookDTO{ID: webhook.ID, DeliveryID: failed.ID}, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.run(t, s)
+	deliveries, total, err := s.Deliveries(ctx, &dto.ListWebhookDeliveriesDTO{ID: webhook.ID}, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || deliveries[0].Status != entity.WebhookDeliveryStatusSucceeded {
+		t.Errorf("redelivery is not succeeded: %+v", deliveries[0])
+	}
+	if string(deliveries[0].Payload) != string(failed.Payload) {
+		t.Errorf("redelivered payload differs")
+	}
+}
+
+func TestDeliveryPrivateAddress(t *testing.T) {
+	ctx := context.Background()
+	rc := &receiver{status: http.StatusOK}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	s, q, userID := setup(t, &config.Config{WebhookMaxAttempts: 1, WebhookTimeout: 5})
+	webhook, err := s.Create(ctx, &dto.CreateWebhookDTO{
+		URL:    srv.URL,
+		Events: []string{entity.WebhookEventPostPublished},
+	}, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Dispatch(ctx, entity.WebhookEventPostPublished, userID, map[string]int{"id": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.run(t, s)
+
+	if len(rc.requests) != 0 {
+		t.Fatalf("the loopback receiver is reached")
+	}
+	deliveries, _, err := s.Deliveries(ctx, &dto.ListWebhookDeliveriesDTO{ID: webhook.ID}, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := deliveries[0]
+	if failed.Status != entity.WebhookDeliveryStatusFailed || failed.Error == nil || !strings.Contains(*failed.Error, ErrorPrivateAddress.Error()) {
+		t.Fatalf("expected failed delivery to the private address, got %+v", failed)
+	}
+}
+
+func TestDeliveryRedirectNotFollowed(t *testing.T) {
+	ctx := context.Background()
+	internal := &receiver{status: http.StatusOK}
+	internalSrv := httptest.NewServer(internal)
+	defer internalSrv.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", internalSrv.URL)
+		w.WriteHeader(http.StatusFound)
+		w.Write([]byte("secret of the internal service"))
+	}))
+	defer srv.Close()
+
+	s, q, userID := setup(t, &config.Config{WebhookMaxAttempts: 1, WebhookTimeout: 5, WebhookAllowPrivate: true})
+	webhook, err := s.Create(ctx, &dto.CreateWebhookDTO{
+		URL:    srv.URL,
+		Events: []string{entity.WebhookEventPostPublished},
+	}, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Dispatch(ctx, entity.WebhookEventPostPublished, userID, map[string]int{"id": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.run(t, s)
+
+	if len(internal.requests) != 0 {
+		t.Fatalf("the redirect is followed")
+	}
+	deliveries, _, err := s.Deliveries(ctx, &dto.ListWebhookDeliveriesDTO{ID: webhook.ID}, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := deliveries[0]
+	if failed.ResponseStatus == nil || *failed.ResponseStatus != http.StatusFound {
+		t.Fatalf("unexpected response status %v", failed.ResponseStatus)
+	}
+	// The response body must not be readable through the delivery log
+	if failed.Error == nil || strings.Contains(*failed.Error, "secret") {
+		t.Fatalf("unexpected error of the delivery %v", failed.Error)
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	for address, allowed := range map[string]bool{
+		"93.184.216.34:443":     true,
+		"[2606:4700::1111]:443": true,
+		"127.0.0.1:80":          false,
+		"10.1.2.3:80":           false,
+		"172.16.0.1:80":         false,
+		"192.168.1.1:80":        false,
+		"169.254.169.254:80":    false,
+		"0.0.0.0:80":            false,
+		"[::1]:80":              false,
+		"[fe80::1]:80":          false,
+		"[fd00::1]:80":          false,
+		"[::ffff:127.0.0.1]:80": false,
+	} {
+		err := checkAddress("tcp", address, nil)
+		if (err == nil) != allowed {
+			t.Errorf("checkAddress(%s) = %v, expected allowed %t", address, err, allowed)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	s := New(&config.Config{WebhookBackoff: 30}, nil, nil)
+	for attempts, expected := range map[int64]int64{1: 30, 2: 60, 3: 120, 100: 86400} {
+		if got := int64(s.backoff(attempts).Seconds()); got != expected {
+			t.Errorf("backoff(%d) = %d, expected %d", attempts, got, expected)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/HardDie/blog_engine/internal/db"
+	repositoryInvite "github.com/HardDie/blog_engine/internal/repository/sqlite/invite"
+	repositoryPassword "github.com/HardDie/blog_engine/internal/repository/sqlite/password"
+	repositorySeries "github.com/HardDie/blog_engine/internal/repository/sqlite/series"
+	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+	"github.com/HardDie/blog_engine/internal/tracing"
+)
+
+// Repositories are bound to one transaction, they must not be used after the function passed to Do returns.
+type Repositories struct {
+	Invite   repositoryInvite.Querier
+	Password repositoryPassword.Querier
+	Series   repositorySeries.Querier
+	User     repositoryUser.Querier
+}
+
+// Bind returns the repositories of the driver bound to the transaction.
+type Bind func(tx *sql.Tx) *Repositories
+
+type IManager interface {
+	// Do runs fn in a transaction, which is committed if fn returns nil and rolled back otherwise.
+	// Repositories of services must not be used inside fn: the transaction holds the only connection of the writer.
+	Do(ctx context.Context, fn func(repos *Repositories) error) error
+}
+
+type Manager struct {
+	db   *db.DB
+	bind Bind
+}
+
+func New(db *db.DB, bind Bind) *Manager {
+	return &Manager{
+		db:   db,
+		bind: bind,
+	}
+}
+
+func (m *Manager) Do(ctx context.Context, fn func(repos *Repositories) error) error {
+	ctx, span := tracing.Start(ctx, "Transaction.Do")
+	defer span.End()
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Transaction.Do() BeginTx: %w", err)
+	}
+	// Rollback after the commit does nothing, it only covers errors and panics of fn
+	defer tx.Rollback()
+
+	err = fn(m.bind(tx))
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("Transaction.Do() Commit: %w", err)
+	}
+	return nil
+}
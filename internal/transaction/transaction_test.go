@@ -0,0 +1,112 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/HardDie/blog_engine/internal/db"
+	"github.com/HardDie/blog_engine/internal/migration"
+	repositoryInvite "github.com/HardDie/blog_engine/internal/repository/sqlite/invite"
+	repositoryPassword "github.com/HardDie/blog_engine/internal/repository/sqlite/password"
+	repositorySeries "github.com/HardDie/blog_engine/internal/repository/sqlite/series"
+	repositoryUser "github.com/HardDie/blog_engine/internal/repository/sqlite/user"
+)
+
+func setup(t *testing.T) (*Manager, *repositoryUser.Queries) {
+	t.Helper()
+
+	database, err := db.Get(filepath.Join(t.TempDir(), "blog.db"), 2, 5000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	err = migration.NewMigrate(database).Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager := New(database, func(tx *sql.Tx) *Repositories {
+		return &Repositories{
+			Invite:   repositoryInvite.New(tx),
+			Password: repositoryPassword.New(tx),
+			Series:   repositorySeries.New(tx),
+			User:     repositoryUser.New(tx),
+		}
+	})
+	return manager, repositoryUser.New(database)
+}
+
+func createUser(ctx context.Context, repos *Repositories, username string) error {
+	_, err := repos.User.Create(ctx, repositoryUser.CreateParams{
+		Username:      username,
+		DisplayedName: username,
+	})
+	return err
+}
+
+func TestDoCommit(t *testing.T) {
+	ctx := context.Background()
+	manager, users := setup(t)
+
+	err := manager.Do(ctx, func(repos *Repositories) error {
+		return createUser(ctx, repos, "alice")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = users.GetByName(ctx, "alice")
+	if err != nil {
+		t.Fatalf("the user is not committed: %v", err)
+	}
+}
+
+func TestDoRollback(t *testing.T) {
+	ctx := context.Background()
+	manager, users := setup(t)
+
+	errFailed := errors.New("failed")
+	err := manager.Do(ctx, func(repos *Repositories) error {
+		err := createUser(ctx, repos, "alice")
+		if err != nil {
+			return err
+		}
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected the error of fn, got %v", err)
+	}
+	_, err = users.GetByName(ctx, "alice")
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("the user is not rolled back: %v", err)
+	}
+
+	// A panic rolls back too, otherwise the transaction would keep the only connection of the writer
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("the panic is not passed through")
+			}
+		}()
+		_ = manager.Do(ctx, func(repos *Repositories) error {
+			err := createUser(ctx, repos, "bob")
+			if err != nil {
+				return err
+			}
+			panic("failed")
+		})
+	}()
+	_, err = users.GetByName(ctx, "bob")
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("the user is not rolled back: %v", err)
+	}
+
+	err = manager.Do(ctx, func(repos *Repositories) error {
+		return createUser(ctx, repos, "carol")
+	})
+	if err != nil {
+		t.Fatalf("the writer is not released: %v", err)
+	}
+}
@@ -12,3 +12,12 @@ func GetUserIDFromContext(ctx context.Context) int64 {
 func GetSessionFromContext(ctx context.Context) *entity.Session {
 	return ctx.Value("session").(*entity.Session)
 }
+
+// GetOptionalUserIDFromContext returns 0 for anonymous requests.
+func GetOptionalUserIDFromContext(ctx context.Context) int64 {
+	userID, ok := ctx.Value("userID").(int64)
+	if !ok {
+		return 0
+	}
+	return userID
+}
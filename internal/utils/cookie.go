@@ -4,15 +4,16 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
-
-	"github.com/HardDie/blog_engine/internal/logger"
 )
 
+const SessionCookieName = "session"
+
 func SetSessionCookie(session string, w http.ResponseWriter) {
 	cookie := http.Cookie{
-		Name:     "session",
+		Name:     SessionCookieName,
 		Path:     "/",
 		Value:    session,
 		HttpOnly: true,
@@ -25,7 +26,7 @@ func SetSessionCookie(session string, w http.ResponseWriter) {
 }
 func DeleteSessionCookie(w http.ResponseWriter) {
 	cookie := http.Cookie{
-		Name:     "session",
+		Name:     SessionCookieName,
 		Path:     "/",
 		Value:    "",
 		Expires:  time.Unix(0, 0),
@@ -38,10 +39,22 @@ func DeleteSessionCookie(w http.ResponseWriter) {
 	http.SetCookie(w, &cookie)
 }
 
+// SetCSRFCookie is readable by JavaScript, so the client can send it back in the header.
+func SetCSRFCookie(name, token string, w http.ResponseWriter) {
+	cookie := http.Cookie{
+		Name:     name,
+		Path:     "/",
+		Value:    token,
+		SameSite: http.SameSiteStrictMode,
+		Secure:   true,
+	}
+	http.SetCookie(w, &cookie)
+}
+
 func GetCookie(r *http.Request) *http.Cookie {
-	cookie, err := r.Cookie("session")
+	cookie, err := r.Cookie(SessionCookieName)
 	if err != nil {
-		logger.Error.Println("Can't read cookie from request:", err.Error())
+		slog.ErrorContext(r.Context(), "can't read cookie from request", "error", err)
 		return nil
 	}
 	return cookie
@@ -67,6 +67,14 @@ func GetInt32FromQuery(r *http.Request, key string, defaultValue int32) int32 {
 	}
 	return int32(value)
 }
+func GetBoolFromQuery(r *http.Request, key string, defaultValue bool) bool {
+	strValue := r.URL.Query().Get(key)
+	value, err := strconv.ParseBool(strValue)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
 func GetInt32FromPath(r *http.Request, key string) (int32, error) {
 	m := mux.Vars(r)
 	if m == nil {
@@ -3,6 +3,7 @@ package utils
 import (
 	"database/sql"
 	"strings"
+	"time"
 )
 
 func PrepareStringToLike(s string) string {
@@ -39,3 +40,15 @@ func SqlStringToString(val sql.NullString) *string {
 	}
 	return &val.String
 }
+func SqlInt64ToInt64(val sql.NullInt64) *int64 {
+	if !val.Valid {
+		return nil
+	}
+	return &val.Int64
+}
+func SqlTimeToTime(val sql.NullTime) *time.Time {
+	if !val.Valid {
+		return nil
+	}
+	return &val.Time
+}
@@ -2,5 +2,7 @@ package migrations
 
 import "embed"
 
-//go:embed *.sql
+// Migrations has a directory for each dialect, named as the driver of the DB
+//
+//go:embed sqlite/*.sql postgres/*.sql
 var Migrations embed.FS
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS users (
+    id              BIGSERIAL   PRIMARY KEY,
+    username        TEXT        NOT NULL UNIQUE,
+    displayed_name  TEXT        NOT NULL,
+    email           TEXT,
+    invited_by_user BIGINT      NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at      TIMESTAMPTZ
+);
+INSERT INTO users (id, username, displayed_name, invited_by_user) VALUES (0, 'root', 'root', 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE users;
+-- +goose StatementEnd
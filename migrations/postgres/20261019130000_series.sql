@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS series (
+    id          BIGSERIAL   PRIMARY KEY,
+    user_id     BIGINT      NOT NULL REFERENCES users(id),
+    title       TEXT        NOT NULL,
+    description TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at  TIMESTAMPTZ
+);
+CREATE TABLE IF NOT EXISTS series_posts (
+    series_id BIGINT NOT NULL REFERENCES series(id),
+    post_id   BIGINT NOT NULL REFERENCES posts(id) UNIQUE,
+    position  BIGINT NOT NULL,
+    PRIMARY KEY (series_id, post_id)
+);
+CREATE INDEX series_user_id_idx ON series (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE series_posts;
+DROP TABLE series;
+-- +goose StatementEnd
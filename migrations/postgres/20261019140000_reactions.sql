@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS post_reactions (
+    post_id    BIGINT      NOT NULL REFERENCES posts(id),
+    user_id    BIGINT      NOT NULL REFERENCES users(id),
+    reaction   TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (post_id, user_id, reaction)
+);
+CREATE INDEX post_reactions_user_id_idx ON post_reactions (user_id, post_id);
+-- Denormalized counters, maintained by triggers, so the feed doesn't need to aggregate reactions
+CREATE TABLE IF NOT EXISTS post_reaction_counts (
+    post_id  BIGINT NOT NULL REFERENCES posts(id),
+    reaction TEXT   NOT NULL,
+    count    BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, reaction)
+);
+CREATE FUNCTION post_reactions_count() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO post_reaction_counts (post_id, reaction, count)
+        VALUES (new.post_id, new.reaction, 1)
+        ON CONFLICT (post_id, reaction) DO UPDATE SET count = post_reaction_counts.count + 1;
+        RETURN new;
+    END IF;
+    UPDATE post_reaction_counts
+    SET count = count - 1
+    WHERE post_id = old.post_id
+      AND reaction = old.reaction;
+    RETURN old;
+END;
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER post_reactions_after_insert AFTER INSERT ON post_reactions
+    FOR EACH ROW EXECUTE FUNCTION post_reactions_count();
+CREATE TRIGGER post_reactions_after_delete AFTER DELETE ON post_reactions
+    FOR EACH ROW EXECUTE FUNCTION post_reactions_count();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER post_reactions_after_delete ON post_reactions;
+DROP TRIGGER post_reactions_after_insert ON post_reactions;
+DROP FUNCTION post_reactions_count;
+DROP TABLE post_reaction_counts;
+DROP TABLE post_reactions;
+-- +goose StatementEnd
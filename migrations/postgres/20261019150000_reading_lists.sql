@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS reading_lists (
+    id         BIGSERIAL   PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users(id),
+    title      TEXT        NOT NULL,
+    is_public  BOOLEAN     NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ
+);
+CREATE INDEX reading_lists_user_id_idx ON reading_lists (user_id);
+CREATE TABLE IF NOT EXISTS reading_list_items (
+    list_id    BIGINT      NOT NULL REFERENCES reading_lists(id),
+    post_id    BIGINT      NOT NULL REFERENCES posts(id),
+    note       TEXT,
+    position   BIGINT      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (list_id, post_id)
+);
+CREATE INDEX reading_list_items_post_id_idx ON reading_list_items (post_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE reading_list_items;
+DROP TABLE reading_lists;
+-- +goose StatementEnd
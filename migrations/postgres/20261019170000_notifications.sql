@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS notifications (
+    id         BIGSERIAL   PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users(id),
+    type       TEXT        NOT NULL,
+    actor_id   BIGINT      NOT NULL REFERENCES users(id),
+    post_id    BIGINT      REFERENCES posts(id),
+    read_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX notifications_user_id_idx ON notifications (user_id, id);
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id BIGINT  NOT NULL REFERENCES users(id),
+    type    TEXT    NOT NULL,
+    in_app  BOOLEAN NOT NULL,
+    email   BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, type)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE notification_preferences;
+DROP TABLE notifications;
+-- +goose StatementEnd
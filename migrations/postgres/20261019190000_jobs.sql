@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS jobs (
+    id           BIGSERIAL   PRIMARY KEY,
+    type         TEXT        NOT NULL,
+    payload      TEXT        NOT NULL,
+    status       TEXT        NOT NULL DEFAULT 'pending',
+    attempts     BIGINT      NOT NULL DEFAULT 0,
+    max_attempts BIGINT      NOT NULL,
+    run_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error   TEXT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX jobs_status_run_at_idx ON jobs (status, run_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE jobs;
+-- +goose StatementEnd
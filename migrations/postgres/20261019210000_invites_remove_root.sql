@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The well-known 00000000-0000-0000-0000-000000000000 invite is replaced by the setup token
+DELETE FROM invites
+WHERE id = 0
+  AND invite_hash = 'Erk3fL5-XJTopw2dI5KVI9FK-pVHkxMPijlZx7hJrKg='
+  AND is_activated IS FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+INSERT INTO invites (id, user_id, invite_hash) VALUES (0, 0, 'Erk3fL5-XJTopw2dI5KVI9FK-pVHkxMPijlZx7hJrKg=') ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
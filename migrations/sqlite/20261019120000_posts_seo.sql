@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts ADD COLUMN cover_image TEXT;
+ALTER TABLE posts ADD COLUMN meta_description TEXT;
+ALTER TABLE posts ADD COLUMN canonical_url TEXT;
+ALTER TABLE posts ADD COLUMN noindex BOOLEAN NOT NULL DEFAULT (false);
+ALTER TABLE posts ADD COLUMN og_title TEXT;
+ALTER TABLE posts ADD COLUMN og_description TEXT;
+ALTER TABLE posts ADD COLUMN og_image TEXT;
+ALTER TABLE posts ADD COLUMN twitter_card TEXT;
+ALTER TABLE posts ADD COLUMN twitter_title TEXT;
+ALTER TABLE posts ADD COLUMN twitter_description TEXT;
+ALTER TABLE posts ADD COLUMN twitter_image TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE posts DROP COLUMN cover_image;
+ALTER TABLE posts DROP COLUMN meta_description;
+ALTER TABLE posts DROP COLUMN canonical_url;
+ALTER TABLE posts DROP COLUMN noindex;
+ALTER TABLE posts DROP COLUMN og_title;
+ALTER TABLE posts DROP COLUMN og_description;
+ALTER TABLE posts DROP COLUMN og_image;
+ALTER TABLE posts DROP COLUMN twitter_card;
+ALTER TABLE posts DROP COLUMN twitter_title;
+ALTER TABLE posts DROP COLUMN twitter_description;
+ALTER TABLE posts DROP COLUMN twitter_image;
+-- +goose StatementEnd
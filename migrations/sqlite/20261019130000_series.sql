@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS series (
+    id          INTEGER   PRIMARY KEY AUTOINCREMENT,
+    user_id     INTEGER   NOT NULL REFERENCES users(id),
+    title       TEXT      NOT NULL,
+    description TEXT,
+    created_at  TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    updated_at  TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    deleted_at  TIMESTAMP
+);
+CREATE TABLE IF NOT EXISTS series_posts (
+    series_id INTEGER NOT NULL REFERENCES series(id),
+    post_id   INTEGER NOT NULL REFERENCES posts(id) UNIQUE,
+    position  INTEGER NOT NULL,
+    PRIMARY KEY (series_id, post_id)
+);
+CREATE INDEX series_user_id_idx ON series (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE series_posts;
+DROP TABLE series;
+-- +goose StatementEnd
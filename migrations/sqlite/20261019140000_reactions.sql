@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS post_reactions (
+    post_id    INTEGER   NOT NULL REFERENCES posts(id),
+    user_id    INTEGER   NOT NULL REFERENCES users(id),
+    reaction   TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (post_id, user_id, reaction)
+);
+CREATE INDEX post_reactions_user_id_idx ON post_reactions (user_id, post_id);
+-- Denormalized counters, maintained by triggers, so the feed doesn't need to aggregate reactions
+CREATE TABLE IF NOT EXISTS post_reaction_counts (
+    post_id  INTEGER NOT NULL REFERENCES posts(id),
+    reaction TEXT    NOT NULL,
+    count    INTEGER NOT NULL DEFAULT (0),
+    PRIMARY KEY (post_id, reaction)
+);
+CREATE TRIGGER post_reactions_after_insert AFTER INSERT ON post_reactions
+BEGIN
+    INSERT INTO post_reaction_counts (post_id, reaction, count)
+    VALUES (new.post_id, new.reaction, 1)
+    ON CONFLICT (post_id, reaction) DO UPDATE SET count = count + 1;
+END;
+CREATE TRIGGER post_reactions_after_delete AFTER DELETE ON post_reactions
+BEGIN
+    UPDATE post_reaction_counts
+    SET count = count - 1
+    WHERE post_id = old.post_id
+      AND reaction = old.reaction;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER post_reactions_after_delete;
+DROP TRIGGER post_reactions_after_insert;
+DROP TABLE post_reaction_counts;
+DROP TABLE post_reactions;
+-- +goose StatementEnd
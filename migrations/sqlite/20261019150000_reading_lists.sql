@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS reading_lists (
+    id         INTEGER   PRIMARY KEY AUTOINCREMENT,
+    user_id    INTEGER   NOT NULL REFERENCES users(id),
+    title      TEXT      NOT NULL,
+    is_public  BOOLEAN   NOT NULL DEFAULT (false),
+    created_at TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    deleted_at TIMESTAMP
+);
+CREATE INDEX reading_lists_user_id_idx ON reading_lists (user_id);
+CREATE TABLE IF NOT EXISTS reading_list_items (
+    list_id    INTEGER   NOT NULL REFERENCES reading_lists(id),
+    post_id    INTEGER   NOT NULL REFERENCES posts(id),
+    note       TEXT,
+    position   INTEGER   NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (list_id, post_id)
+);
+CREATE INDEX reading_list_items_post_id_idx ON reading_list_items (post_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE reading_list_items;
+DROP TABLE reading_lists;
+-- +goose StatementEnd
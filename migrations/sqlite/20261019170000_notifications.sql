@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS notifications (
+    id         INTEGER   PRIMARY KEY AUTOINCREMENT,
+    user_id    INTEGER   NOT NULL REFERENCES users(id),
+    type       TEXT      NOT NULL,
+    actor_id   INTEGER   NOT NULL REFERENCES users(id),
+    post_id    INTEGER   REFERENCES posts(id),
+    read_at    TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (datetime('now'))
+);
+CREATE INDEX notifications_user_id_idx ON notifications (user_id, id);
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    type    TEXT    NOT NULL,
+    in_app  BOOLEAN NOT NULL,
+    email   BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, type)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE notification_preferences;
+DROP TABLE notifications;
+-- +goose StatementEnd
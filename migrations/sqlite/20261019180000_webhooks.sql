@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS webhooks (
+    id         INTEGER   PRIMARY KEY AUTOINCREMENT,
+    user_id    INTEGER   NOT NULL REFERENCES users(id),
+    url        TEXT      NOT NULL,
+    secret     TEXT      NOT NULL,
+    events     TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    deleted_at TIMESTAMP
+);
+CREATE INDEX webhooks_user_id_idx ON webhooks (user_id);
+-- Delivery log, pending rows are the queue of the dispatcher
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              INTEGER   PRIMARY KEY AUTOINCREMENT,
+    webhook_id      INTEGER   NOT NULL REFERENCES webhooks(id),
+    event           TEXT      NOT NULL,
+    payload         TEXT      NOT NULL,
+    status          TEXT      NOT NULL DEFAULT ('pending'),
+    attempts        INTEGER   NOT NULL DEFAULT (0),
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    response_status INTEGER,
+    error           TEXT,
+    created_at      TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    updated_at      TIMESTAMP NOT NULL DEFAULT (datetime('now'))
+);
+CREATE INDEX webhook_deliveries_status_idx ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_deliveries;
+DROP TABLE webhooks;
+-- +goose StatementEnd
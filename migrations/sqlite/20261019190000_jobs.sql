@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS jobs (
+    id           INTEGER   PRIMARY KEY AUTOINCREMENT,
+    type         TEXT      NOT NULL,
+    payload      TEXT      NOT NULL,
+    status       TEXT      NOT NULL DEFAULT ('pending'),
+    attempts     INTEGER   NOT NULL DEFAULT (0),
+    max_attempts INTEGER   NOT NULL,
+    run_at       TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    last_error   TEXT,
+    created_at   TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    updated_at   TIMESTAMP NOT NULL DEFAULT (datetime('now'))
+);
+CREATE INDEX jobs_status_run_at_idx ON jobs (status, run_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE jobs;
+-- +goose StatementEnd
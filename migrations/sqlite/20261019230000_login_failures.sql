@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS login_failures (
+    user_id    INTEGER   NOT NULL REFERENCES users(id),
+    ip         TEXT      NOT NULL,
+    attempts   INTEGER   NOT NULL DEFAULT (0),
+    updated_at TIMESTAMP NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (user_id, ip)
+);
+CREATE INDEX login_failures_updated_at_idx ON login_failures (updated_at);
+ALTER TABLE passwords DROP COLUMN failed_attempts;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE passwords ADD COLUMN failed_attempts INTEGER NOT NULL DEFAULT (0);
+DROP TABLE login_failures;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Pending deliveries were polled from the table, now each of them is sent by a job of the queue.
+-- 5 is the default of JOB_MAX_ATTEMPTS, the job fails only if the delivery log can't be updated
+INSERT INTO jobs (type, payload, max_attempts, run_at)
+SELECT 'webhook.deliver', json_object('deliveryId', id), 5, next_attempt_at
+FROM webhook_deliveries
+WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM jobs
+WHERE type = 'webhook.deliver';
+-- +goose StatementEnd